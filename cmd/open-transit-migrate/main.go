@@ -6,49 +6,255 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strconv"
+	"time"
 
 	_ "github.com/jackc/pgx/v5/stdlib"
 	"github.com/technopolitica/open-transit/internal/db"
 )
 
+// Exit codes, so that deploy scripts can tell a database that was already up to date from a
+// failure.
+const (
+	exitOK = 0
+	// exitError means that the command failed. Migrations are applied in a transaction each, so
+	// the ones before the failing migration stay applied.
+	exitError = 1
+	// exitUsage means that the command line was invalid and nothing was done.
+	exitUsage = 2
+	// exitNoChange means that the database was already at the requested version.
+	exitNoChange = 3
+)
+
 var connectionURL = flag.String("db-url", "", "URL-formatted connection string to the DB to operate upon")
 
-func main() {
-	ctx := context.Background()
+func usage() {
+	output := flag.CommandLine.Output()
+	fmt.Fprintf(output, "Usage: %s -db-url <url> <command> [flags]\n\n", os.Args[0])
+	fmt.Fprint(output, `Commands:
+  migrate -to <version|latest> [-dry-run]  apply pending migrations up to and including a version
+  down -to <version> [-dry-run]            roll back applied migrations newer than a version. 0 rolls back every migration.
+  redo [-dry-run]                          roll back the newest applied migration and apply it again
+  status                                   list migrations and whether they are applied
+  version                                  print the version of the newest applied migration
 
+Exit codes:
+  0  success
+  1  error
+  2  invalid usage
+  3  nothing to do: the database was already at the requested version
+
+Flags:
+`)
+	flag.PrintDefaults()
+}
+
+func main() {
+	flag.Usage = usage
 	flag.Parse()
+	os.Exit(run(context.Background(), flag.Args()))
+}
 
+func run(ctx context.Context, args []string) int {
 	if *connectionURL == "" {
 		fmt.Print("missing required -db-url param\n")
 		flag.Usage()
-		os.Exit(1)
+		return exitUsage
 	}
-
-	args := flag.Args()
 	if len(args) == 0 {
 		fmt.Print("expected a subcommand\n")
 		flag.Usage()
-		os.Exit(1)
+		return exitUsage
 	}
-	command := args[0]
 
-	if command != "migrate" {
+	migrator, err := db.OpenMigrator(*connectionURL)
+	if err != nil {
+		log.Printf("%s\n", err)
+		return exitError
+	}
+	defer migrator.Close()
+
+	command := args[0]
+	switch command {
+	case "migrate":
+		return migrate(ctx, migrator, args[1:])
+	case "down":
+		return down(ctx, migrator, args[1:])
+	case "redo":
+		return redo(ctx, migrator, args[1:])
+	case "status":
+		return status(ctx, migrator)
+	case "version":
+		return version(ctx, migrator)
+	default:
 		fmt.Printf("unknown subcommand \"%s\"\n", command)
 		flag.Usage()
-		os.Exit(1)
+		return exitUsage
 	}
-	migrateCmd := flag.NewFlagSet("migrate", flag.ExitOnError)
-	version := migrateCmd.String("to", "", "version to which the database should be migrated. May specify \"latest\" to migrate to the latest version.")
+}
 
-	migrateCmd.Parse(args[1:])
+func migrate(ctx context.Context, migrator *db.Migrator, args []string) int {
+	migrateCmd := flag.NewFlagSet("migrate", flag.ExitOnError)
+	to := migrateCmd.String("to", "", "version to which the database should be migrated. May specify \"latest\" to migrate to the latest version.")
+	dryRun := migrateCmd.Bool("dry-run", false, "print the SQL of the migrations that would be applied without applying them")
+	migrateCmd.Parse(args)
 
-	if *version == "" {
+	if *to == "" {
 		fmt.Print("missing required parameter -to\n")
 		migrateCmd.Usage()
-		os.Exit(1)
+		return exitUsage
 	}
-	err := db.MigrateTo(ctx, *connectionURL, *version)
+	target, err := strconv.ParseInt(*to, 10, 64)
+	if *to == "latest" {
+		target, err = db.LatestMigrationVersion()
+		if err != nil {
+			log.Printf("%s\n", err)
+			return exitError
+		}
+	}
+	if err != nil {
+		fmt.Printf("invalid version \"%s\"\n", *to)
+		return exitUsage
+	}
+
+	plan, err := migrator.PlanUp(ctx, target)
+	if err != nil {
+		log.Printf("failed to plan migration: %s\n", err)
+		return exitError
+	}
+	if len(plan) == 0 {
+		return upToDate(ctx, migrator)
+	}
+	if *dryRun {
+		return printPlan(plan, true)
+	}
+	err = migrator.Up(ctx, target)
 	if err != nil {
-		log.Fatalf("failed to run migration: %s\n", err)
+		log.Printf("failed to run migration: %s\n", err)
+		return exitError
+	}
+	return exitOK
+}
+
+func down(ctx context.Context, migrator *db.Migrator, args []string) int {
+	downCmd := flag.NewFlagSet("down", flag.ExitOnError)
+	to := downCmd.String("to", "", "version to which the database should be rolled back. May specify 0 to roll back every migration.")
+	dryRun := downCmd.Bool("dry-run", false, "print the SQL of the migrations that would be rolled back without rolling them back")
+	downCmd.Parse(args)
+
+	if *to == "" {
+		fmt.Print("missing required parameter -to\n")
+		downCmd.Usage()
+		return exitUsage
+	}
+	target, err := strconv.ParseInt(*to, 10, 64)
+	if err != nil {
+		fmt.Printf("invalid version \"%s\"\n", *to)
+		return exitUsage
+	}
+
+	plan, err := migrator.PlanDown(ctx, target)
+	if err != nil {
+		log.Printf("failed to plan rollback: %s\n", err)
+		return exitError
+	}
+	if len(plan) == 0 {
+		return upToDate(ctx, migrator)
+	}
+	if *dryRun {
+		return printPlan(plan, false)
+	}
+	err = migrator.Down(ctx, target)
+	if err != nil {
+		log.Printf("failed to roll back migration: %s\n", err)
+		return exitError
+	}
+	return exitOK
+}
+
+func redo(ctx context.Context, migrator *db.Migrator, args []string) int {
+	redoCmd := flag.NewFlagSet("redo", flag.ExitOnError)
+	dryRun := redoCmd.Bool("dry-run", false, "print the SQL that would roll back and apply the migration again without running it")
+	redoCmd.Parse(args)
+
+	plan, err := migrator.PlanRedo(ctx)
+	if err != nil {
+		log.Printf("failed to plan redo: %s\n", err)
+		return exitError
+	}
+	if len(plan) == 0 {
+		fmt.Print("no migrations have been applied\n")
+		return exitNoChange
+	}
+	if *dryRun {
+		code := printPlan(plan, false)
+		if code != exitOK {
+			return code
+		}
+		return printPlan(plan, true)
+	}
+	err = migrator.Redo(ctx)
+	if err != nil {
+		log.Printf("failed to redo migration: %s\n", err)
+		return exitError
+	}
+	return exitOK
+}
+
+func status(ctx context.Context, migrator *db.Migrator) int {
+	migrations, _, err := migrator.Status(ctx)
+	if err != nil {
+		log.Printf("failed to read migration status: %s\n", err)
+		return exitError
+	}
+	fmt.Printf("%-29s %s\n", "Applied At", "Migration")
+	for _, migration := range migrations {
+		appliedAt := "Pending"
+		if migration.AppliedAt != nil {
+			appliedAt = migration.AppliedAt.UTC().Format(time.RFC3339)
+		}
+		fmt.Printf("%-29s %s\n", appliedAt, migration.Name)
+	}
+	return exitOK
+}
+
+func version(ctx context.Context, migrator *db.Migrator) int {
+	current, err := migrator.Version(ctx)
+	if err != nil {
+		log.Printf("failed to read version: %s\n", err)
+		return exitError
+	}
+	fmt.Printf("%d\n", current)
+	return exitOK
+}
+
+func upToDate(ctx context.Context, migrator *db.Migrator) int {
+	current, err := migrator.Version(ctx)
+	if err != nil {
+		log.Printf("failed to read version: %s\n", err)
+		return exitError
+	}
+	fmt.Printf("already at version %d, nothing to do\n", current)
+	return exitNoChange
+}
+
+// printPlan prints the SQL that would apply, or if up is false roll back, every migration in plan.
+func printPlan(plan []db.Migration, up bool) int {
+	direction := "down"
+	if up {
+		direction = "up"
+	}
+	for _, migration := range plan {
+		sql, ok, err := db.MigrationSQL(migration, up)
+		if err != nil {
+			log.Printf("%s\n", err)
+			return exitError
+		}
+		if !ok {
+			log.Printf("migration %s has no %s section\n", migration.Name, direction)
+			return exitError
+		}
+		fmt.Printf("-- %s (%s)\n%s\n\n", migration.Name, direction, sql)
 	}
+	return exitOK
 }
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/technopolitica/open-transit/internal/config"
+	"github.com/technopolitica/open-transit/internal/db"
+	"github.com/technopolitica/open-transit/internal/domain"
+)
+
+func runCommand(ctx context.Context, cfg config.Config, args []string) {
+	command := args[0]
+	if command != "credentials" {
+		fmt.Printf("unknown subcommand \"%s\"\n", command)
+		os.Exit(1)
+	}
+	if len(args) < 2 {
+		fmt.Print("expected \"credentials create\" or \"credentials revoke\"\n")
+		os.Exit(1)
+	}
+
+	conn, err := pgx.Connect(ctx, cfg.Database.URL)
+	if err != nil {
+		log.Fatalf("failed to connect to database: %s\n", err)
+	}
+	defer conn.Close(ctx)
+	repository, err := db.NewRepository(ctx, conn)
+	if err != nil {
+		log.Fatalf("failed to construct repository: %s\n", err)
+	}
+
+	switch args[1] {
+	case "create":
+		createCredential(ctx, repository, args[2:])
+	case "revoke":
+		revokeCredential(ctx, repository, args[2:])
+	default:
+		fmt.Printf("unknown credentials subcommand \"%s\"\n", args[1])
+		os.Exit(1)
+	}
+}
+
+// createCredential registers a new client for a provider and prints its client ID and secret. The
+// secret is only shown once. Clients bound to a TLS client certificate use the certificate's subject
+// as their client ID and have no secret.
+func createCredential(ctx context.Context, repository db.Repository, args []string) {
+	createCmd := flag.NewFlagSet("credentials create", flag.ExitOnError)
+	providerID := createCmd.String("provider-id", "", "provider that tokens issued to the client act on behalf of")
+	scope := createCmd.String("scope", "", "space-delimited scopes that the client may request. Defaults to every scope granted to providers.")
+	tlsSubject := createCmd.String("tls-subject", "", "subject of a TLS client certificate, in RFC 2253 form, to authenticate as the provider instead of creating a client secret")
+	createCmd.Parse(args)
+
+	if *providerID == "" {
+		fmt.Print("missing required parameter -provider-id\n")
+		createCmd.Usage()
+		os.Exit(1)
+	}
+	id, err := uuid.Parse(*providerID)
+	if err != nil {
+		fmt.Print("-provider-id must be a valid UUID\n")
+		os.Exit(1)
+	}
+	scopes := domain.ParseScopes(*scope)
+	if len(scopes) == 0 {
+		scopes = domain.ScopesForRole(domain.RoleProvider)
+	}
+
+	var credential domain.ProviderCredential
+	var secret string
+	if *tlsSubject != "" {
+		credential = domain.NewCertificateCredential(*tlsSubject, id, scopes)
+	} else {
+		credential, secret, err = domain.NewProviderCredential(id, scopes)
+		if err != nil {
+			log.Fatalf("failed to create credential: %s\n", err)
+		}
+	}
+	errs := domain.ValidateProviderCredential(credential)
+	if len(errs) > 0 {
+		fmt.Printf("invalid credential:\n%s\n", strings.Join(errs, "\n"))
+		os.Exit(1)
+	}
+	_, err = repository.FetchProvider(ctx, id)
+	if err != nil && errors.Is(err, db.ErrNotFound) {
+		fmt.Printf("provider %s is not registered\n", id)
+		os.Exit(1)
+	}
+	if err != nil {
+		log.Fatalf("failed to fetch provider: %s\n", err)
+	}
+	err = repository.InsertProviderCredential(ctx, credential)
+	if err != nil && errors.Is(err, db.ErrConflict) {
+		fmt.Printf("client_id \"%s\" already exists\n", credential.ClientID)
+		os.Exit(1)
+	}
+	if err != nil {
+		log.Fatalf("failed to store credential: %s\n", err)
+	}
+	if credential.IsCertificateBound() {
+		fmt.Printf("client_id: %s\nscope: %s\n", credential.ClientID, domain.FormatScopes(credential.Scopes))
+		return
+	}
+	fmt.Printf("client_id: %s\nclient_secret: %s\nscope: %s\n", credential.ClientID, secret, domain.FormatScopes(credential.Scopes))
+}
+
+// revokeCredential stops a client from obtaining new tokens, or from authenticating with its client
+// certificate. Tokens that were already issued to it remain valid until they expire.
+func revokeCredential(ctx context.Context, repository db.Repository, args []string) {
+	revokeCmd := flag.NewFlagSet("credentials revoke", flag.ExitOnError)
+	clientID := revokeCmd.String("client-id", "", "client to revoke")
+	revokeCmd.Parse(args)
+
+	if *clientID == "" {
+		fmt.Print("missing required parameter -client-id\n")
+		revokeCmd.Usage()
+		os.Exit(1)
+	}
+	err := repository.RevokeProviderCredential(ctx, *clientID)
+	if err != nil && errors.Is(err, db.ErrNotFound) {
+		fmt.Printf("unknown client_id \"%s\"\n", *clientID)
+		os.Exit(1)
+	}
+	if err != nil {
+		log.Fatalf("failed to revoke credential: %s\n", err)
+	}
+	fmt.Printf("revoked %s\n", *clientID)
+}
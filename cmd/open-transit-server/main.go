@@ -2,99 +2,253 @@ package main
 
 import (
 	"context"
-	"crypto/rsa"
+	"crypto/tls"
 	"crypto/x509"
-	"encoding/pem"
+	"errors"
 	"flag"
 	"fmt"
 	"log"
+	"log/slog"
 	"net"
 	"net/http"
 	"net/url"
 	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/technopolitica/open-transit/internal/config"
+	"github.com/technopolitica/open-transit/internal/keys"
 	"github.com/technopolitica/open-transit/internal/server"
 )
 
-func loadPublicKey(publicKeyURL *url.URL) (publicKey *rsa.PublicKey, err error) {
-	switch publicKeyURL.Scheme {
-	case "file":
-		filePath := publicKeyURL.Path
-		var pemBytes []byte
-		pemBytes, err = os.ReadFile(filePath)
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	cfg, flags, err := config.Load(os.Args[0], os.Args[1:], os.Environ(), os.Stderr)
+	if errors.Is(err, flag.ErrHelp) {
+		os.Exit(0)
+	}
+	if err != nil {
+		log.Printf("%s\n", err)
+		os.Exit(2)
+	}
+	errs := config.Validate(cfg)
+	if len(errs) > 0 {
+		log.Printf("invalid configuration:\n%s\n", strings.Join(errs, "\n"))
+		os.Exit(1)
+	}
+
+	if flags.PrintConfig {
+		err = config.Print(os.Stdout, config.Redacted(cfg))
 		if err != nil {
-			return
-		}
-		var pemBlock *pem.Block
-		pemBlock, _ = pem.Decode(pemBytes)
-		if pemBlock.Type != "RSA PUBLIC KEY" {
-			err = fmt.Errorf("invalid public key of type %s", pemBlock.Type)
-			return
+			log.Fatalf("failed to print configuration: %s\n", err)
 		}
-		publicKey, err = x509.ParsePKCS1PublicKey(pemBlock.Bytes)
-		return
-	default:
-		err = fmt.Errorf("unsupported public key source: %s", publicKeyURL.Scheme)
 		return
 	}
-}
-
-var (
-	dbURL     = flag.String("db-url", "", "URL-formatted connection string to the database server. Currently only postgres:// URLS are supported.")
-	port      = flag.Int("port", 0, "port to listen on")
-	publicKey = flag.String("public-key", "", "URL to the public key used to sign auth tokens. Currently only file:// proptocols are supported.")
-)
 
-func main() {
-	ctx := context.Background()
-
-	flag.Parse()
-	if *dbURL == "" {
-		log.Print("-db-url is required\n")
-		flag.Usage()
-		os.Exit(1)
+	if len(flags.Args) > 0 {
+		runCommand(ctx, cfg, flags.Args)
+		return
 	}
 
-	if *publicKey == "" {
-		log.Print("-public-key is required\n")
-		flag.Usage()
+	if len(cfg.Auth.PublicKeys) == 0 && cfg.Auth.TokenSigningKey == "" {
+		log.Print("-public-key or -token-signing-key is required\n")
 		os.Exit(1)
 	}
-	publicKeyURL, err := url.Parse(*publicKey)
+
+	slog.SetDefault(slog.New(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{Level: cfg.Log.Level})))
+
+	poolConfig, err := pgxpool.ParseConfig(cfg.Database.URL)
 	if err != nil {
-		log.Fatalf("failed to parse public key as URL: %s\n", err)
+		fatal("failed to parse database URL", "error", err)
 	}
-	if publicKeyURL.Path == "" {
-		log.Fatalf("public key url cannot have an empty path\n")
+	if cfg.Database.MaxConns > 0 {
+		poolConfig.MaxConns = int32(cfg.Database.MaxConns)
 	}
-
-	db, err := pgxpool.New(ctx, *dbURL)
+	poolConfig.MinConns = int32(cfg.Database.MinConns)
+	if cfg.Database.MaxConnLifetime > 0 {
+		poolConfig.MaxConnLifetime = cfg.Database.MaxConnLifetime
+	}
+	if cfg.Database.MaxConnIdleTime > 0 {
+		poolConfig.MaxConnIdleTime = cfg.Database.MaxConnIdleTime
+	}
+	db, err := pgxpool.NewWithConfig(ctx, poolConfig)
 	if err != nil {
-		log.Fatalf("failed to connect to database: %s\n", err)
+		fatal("failed to connect to database", "error", err)
 	}
 	defer db.Close()
 
-	publicKey, err := loadPublicKey(publicKeyURL)
-	if err != nil {
-		log.Fatalf("failed to read public key: %s\n", err)
+	readiness := server.CheckReadiness(ctx, db)
+	if !readiness.Ready {
+		args := []any{
+			"database", readiness.Database.Error,
+			"schema", readiness.Schema.Error,
+			"version", readiness.Schema.Version,
+			"latest_version", readiness.Schema.LatestVersion,
+		}
+		if cfg.Database.SchemaCheck == config.SchemaCheckRefuse {
+			fatal("refusing to start while the database isn't ready", args...)
+		}
+		slog.Warn("starting while the database isn't ready", args...)
 	}
 
-	router := server.New(db, *publicKey)
-	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", *port))
+	keySets := make([]keys.Set, 0, len(cfg.Auth.PublicKeys))
+	for _, publicKey := range cfg.Auth.PublicKeys {
+		publicKeyURL, err := url.Parse(publicKey)
+		if err != nil {
+			fatal("failed to parse public key URL", "url", publicKey, "error", err)
+		}
+		keySet, err := keys.Load(ctx, publicKeyURL, cfg.Auth.PublicKeyRefresh)
+		if err != nil {
+			fatal("failed to read public key", "url", publicKeyURL, "error", err)
+		}
+		keySets = append(keySets, keySet)
+	}
+
+	var issuer *server.TokenIssuer
+	if cfg.Auth.TokenSigningKey != "" {
+		key, err := keys.LoadSigningKey(cfg.Auth.TokenSigningKey)
+		if err != nil {
+			fatal("failed to read token signing key", "error", err)
+		}
+		issuer = &server.TokenIssuer{Key: key, TTL: cfg.Auth.TokenTTL}
+		keySets = append(keySets, keys.Static(key.Public()))
+	}
+
+	var tlsConfig *tls.Config
+	if cfg.Server.TLS.Cert != "" {
+		tlsConfig, err = loadTLSConfig(cfg.Server.TLS)
+		if err != nil {
+			fatal("failed to configure TLS", "error", err)
+		}
+	}
+
+	done := make(chan error, 2)
+	var instruments *server.Instruments
+	var adminServer *http.Server
+	if cfg.Server.AdminPort != 0 {
+		registry := prometheus.NewRegistry()
+		registry.MustRegister(
+			collectors.NewGoCollector(),
+			collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+			server.NewPoolCollector(db),
+		)
+		instruments = server.NewInstruments(registry)
+		adminListener, err := net.Listen("tcp", net.JoinHostPort(cfg.Server.Host, strconv.Itoa(cfg.Server.AdminPort)))
+		if err != nil {
+			fatal("failed to listen on specified admin address", "error", err)
+		}
+		adminServer = &http.Server{
+			Handler:           server.NewAdminRouter(registry),
+			ReadHeaderTimeout: cfg.Server.ReadHeaderTimeout,
+			WriteTimeout:      cfg.Server.WriteTimeout,
+		}
+		go func() {
+			done <- adminServer.Serve(adminListener)
+		}()
+		slog.Info("serving operational endpoints", "address", fmt.Sprintf("http://%s", adminListener.Addr()))
+	}
+
+	router := server.New(db, keys.Multi(keySets...), issuer, server.Options{
+		MaxBodyBytes: cfg.Server.MaxBodyBytes,
+		MaxPageSize:  cfg.API.MaxPageSize,
+		Modules:      cfg.API.Modules,
+		LogRequests:  cfg.Log.Requests,
+		Instruments:  instruments,
+		// Responses can't be written past the write timeout, so handlers stop working on them
+		// then too.
+		HandlerTimeout: cfg.Server.WriteTimeout,
+	})
+	listener, err := net.Listen("tcp", net.JoinHostPort(cfg.Server.Host, strconv.Itoa(cfg.Server.Port)))
 	if err != nil {
-		log.Fatalf("failed to listen on specified address: %s\n", err)
+		fatal("failed to listen on specified address", "error", err)
 	}
 
-	done := make(chan error)
+	httpServer := &http.Server{
+		Handler:           router,
+		ReadTimeout:       cfg.Server.ReadTimeout,
+		ReadHeaderTimeout: cfg.Server.ReadHeaderTimeout,
+		WriteTimeout:      cfg.Server.WriteTimeout,
+		IdleTimeout:       cfg.Server.IdleTimeout,
+		MaxHeaderBytes:    cfg.Server.MaxHeaderBytes,
+		TLSConfig:         tlsConfig,
+	}
 	go func() {
-		done <- http.Serve(listener, router)
+		if tlsConfig != nil {
+			// The certificate is already loaded into tlsConfig.
+			done <- httpServer.ServeTLS(listener, "", "")
+		} else {
+			done <- httpServer.Serve(listener)
+		}
 	}()
-	log.Printf("listening on http://%s...\n", listener.Addr())
-	err = <-done
+	scheme := "http"
+	if tlsConfig != nil {
+		scheme = "https"
+	}
+	slog.Info("listening", "address", fmt.Sprintf("%s://%s", scheme, listener.Addr()))
+	select {
+	case err = <-done:
+		fatal("failed to start server", "error", err)
+	case <-ctx.Done():
+	}
+
+	// A second signal kills the server without waiting for in-flight requests.
+	stop()
+	slog.Info("shutting down")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+	defer cancel()
+	err = httpServer.Shutdown(shutdownCtx)
 	if err != nil {
-		log.Fatalf("failed to start server: %s", err)
+		slog.Error("failed to finish in-flight requests", "error", err)
+	}
+	if adminServer != nil {
+		err = adminServer.Shutdown(shutdownCtx)
+		if err != nil {
+			slog.Error("failed to shut down operational endpoints", "error", err)
+		}
+	}
+}
+
+// fatal logs msg at the error level with the alternating keys and values in args and exits.
+func fatal(msg string, args ...any) {
+	slog.Error(msg, args...)
+	os.Exit(1)
+}
+
+// loadTLSConfig loads the server's certificate and, if a client CA bundle is set, the CAs that
+// client certificates are verified against. Client certificates are optional so that clients can
+// still authenticate with bearer tokens.
+func loadTLSConfig(tlsOptions config.TLS) (tlsConfig *tls.Config, err error) {
+	cert, err := tls.LoadX509KeyPair(tlsOptions.Cert, tlsOptions.Key)
+	if err != nil {
+		err = fmt.Errorf("failed to load certificate: %w", err)
+		return
+	}
+	tlsConfig = &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+	if tlsOptions.ClientCA == "" {
+		return
+	}
+	caPEM, err := os.ReadFile(tlsOptions.ClientCA)
+	if err != nil {
+		err = fmt.Errorf("failed to read client CA bundle: %w", err)
+		return
+	}
+	clientCAs := x509.NewCertPool()
+	if !clientCAs.AppendCertsFromPEM(caPEM) {
+		err = fmt.Errorf("no certificates found in client CA bundle %s", tlsOptions.ClientCA)
+		return
 	}
-	fmt.Printf("shutting down...")
+	tlsConfig.ClientCAs = clientCAs
+	tlsConfig.ClientAuth = tls.VerifyClientCertIfGiven
+	return
 }
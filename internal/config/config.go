@@ -0,0 +1,391 @@
+// Package config loads the server's configuration from, in increasing order of precedence,
+// built-in defaults, a YAML file, environment variables and command-line flags.
+package config
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/technopolitica/open-transit/internal/domain"
+	"gopkg.in/yaml.v3"
+)
+
+// EnvPrefix prefixes the environment variable of every flag. The variable for a flag is named
+// after it in upper case with dashes replaced by underscores, so -db-url is read from
+// OPEN_TRANSIT_DB_URL.
+const EnvPrefix = "OPEN_TRANSIT_"
+
+type Config struct {
+	Database Database `yaml:"database"`
+	Server   Server   `yaml:"server"`
+	Auth     Auth     `yaml:"auth"`
+	API      API      `yaml:"api"`
+	Log      Log      `yaml:"log"`
+}
+
+type Database struct {
+	// URL is a postgres:// connection string.
+	URL             string        `yaml:"url"`
+	MaxConns        int           `yaml:"max_conns"`
+	MinConns        int           `yaml:"min_conns"`
+	MaxConnLifetime time.Duration `yaml:"max_conn_lifetime"`
+	MaxConnIdleTime time.Duration `yaml:"max_conn_idle_time"`
+	// SchemaCheck is what the server does when it starts while the database schema is behind its
+	// migrations or can't be checked: SchemaCheckRefuse or SchemaCheckWarn.
+	SchemaCheck string `yaml:"schema_check"`
+}
+
+const (
+	// SchemaCheckRefuse stops the server from starting.
+	SchemaCheckRefuse = "refuse"
+	// SchemaCheckWarn logs a warning and starts the server anyway.
+	SchemaCheckWarn = "warn"
+)
+
+type Server struct {
+	Host              string        `yaml:"host"`
+	Port              int           `yaml:"port"`
+	AdminPort         int           `yaml:"admin_port"`
+	ReadTimeout       time.Duration `yaml:"read_timeout"`
+	ReadHeaderTimeout time.Duration `yaml:"read_header_timeout"`
+	WriteTimeout      time.Duration `yaml:"write_timeout"`
+	IdleTimeout       time.Duration `yaml:"idle_timeout"`
+	ShutdownTimeout   time.Duration `yaml:"shutdown_timeout"`
+	MaxHeaderBytes    int           `yaml:"max_header_bytes"`
+	MaxBodyBytes      int64         `yaml:"max_body_bytes"`
+	TLS               TLS           `yaml:"tls"`
+}
+
+type TLS struct {
+	Cert     string `yaml:"cert"`
+	Key      string `yaml:"key"`
+	ClientCA string `yaml:"client_ca"`
+}
+
+type Auth struct {
+	PublicKeys       []string      `yaml:"public_keys"`
+	PublicKeyRefresh time.Duration `yaml:"public_key_refresh"`
+	TokenSigningKey  string        `yaml:"token_signing_key"`
+	TokenTTL         time.Duration `yaml:"token_ttl"`
+}
+
+type API struct {
+	MaxPageSize int             `yaml:"max_page_size"`
+	Modules     []domain.Module `yaml:"modules"`
+}
+
+type Log struct {
+	Level    slog.Level `yaml:"level"`
+	Requests bool       `yaml:"requests"`
+}
+
+func Defaults() Config {
+	return Config{
+		Database: Database{
+			SchemaCheck: SchemaCheckRefuse,
+		},
+		Server: Server{
+			ReadTimeout:       30 * time.Second,
+			ReadHeaderTimeout: 10 * time.Second,
+			WriteTimeout:      60 * time.Second,
+			IdleTimeout:       2 * time.Minute,
+			ShutdownTimeout:   30 * time.Second,
+			MaxHeaderBytes:    http.DefaultMaxHeaderBytes,
+			MaxBodyBytes:      10 << 20,
+		},
+		Auth: Auth{
+			PublicKeyRefresh: 15 * time.Minute,
+			TokenTTL:         time.Hour,
+		},
+		API: API{
+			MaxPageSize: domain.DefaultMaxPageSize,
+			Modules:     domain.AllModules(),
+		},
+		Log: Log{
+			Level:    slog.LevelInfo,
+			Requests: true,
+		},
+	}
+}
+
+// listValue is a flag that may be given several times, or once with a comma-separated list. The
+// values replace rather than add to those loaded before the flag set is parsed.
+type listValue struct {
+	list  *[]string
+	isSet bool
+}
+
+func (value *listValue) String() string {
+	if value.list == nil {
+		return ""
+	}
+	return strings.Join(*value.list, ",")
+}
+
+func (value *listValue) Set(s string) error {
+	if !value.isSet {
+		*value.list = nil
+		value.isSet = true
+	}
+	*value.list = append(*value.list, strings.Split(s, ",")...)
+	return nil
+}
+
+type modulesValue struct {
+	modules *[]domain.Module
+}
+
+func (value modulesValue) String() string {
+	if value.modules == nil {
+		return ""
+	}
+	names := make([]string, 0, len(*value.modules))
+	for _, module := range *value.modules {
+		names = append(names, module.String())
+	}
+	return strings.Join(names, ",")
+}
+
+func (value modulesValue) Set(s string) error {
+	var modules []domain.Module
+	for _, name := range strings.Split(s, ",") {
+		module, err := domain.ParseModule(strings.TrimSpace(name))
+		if err != nil {
+			return err
+		}
+		modules = append(modules, module)
+	}
+	*value.modules = modules
+	return nil
+}
+
+// Flags are the command-line options that aren't part of the configuration itself.
+type Flags struct {
+	ConfigFile  string
+	PrintConfig bool
+	// Args are the arguments left after the flags, naming a subcommand.
+	Args []string
+}
+
+func newFlagSet(name string, config *Config, flags *Flags, output io.Writer) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(output)
+
+	fs.StringVar(&flags.ConfigFile, "config", flags.ConfigFile, "path to a YAML configuration file. Environment variables and flags take precedence over it.")
+	fs.BoolVar(&flags.PrintConfig, "print-config", flags.PrintConfig, "print the effective configuration as YAML and exit")
+
+	fs.StringVar(&config.Database.URL, "db-url", config.Database.URL, "URL-formatted connection string to the database server. Currently only postgres:// URLS are supported.")
+	fs.IntVar(&config.Database.MaxConns, "db-max-conns", config.Database.MaxConns, "maximum number of open database connections. Set to 0 for the larger of 4 and the number of CPUs.")
+	fs.IntVar(&config.Database.MinConns, "db-min-conns", config.Database.MinConns, "minimum number of open database connections")
+	fs.DurationVar(&config.Database.MaxConnLifetime, "db-max-conn-lifetime", config.Database.MaxConnLifetime, "duration after which database connections are closed. Set to 0 for one hour.")
+	fs.DurationVar(&config.Database.MaxConnIdleTime, "db-max-conn-idle-time", config.Database.MaxConnIdleTime, "duration after which idle database connections are closed. Set to 0 for 30 minutes.")
+	fs.StringVar(&config.Database.SchemaCheck, "schema-check", config.Database.SchemaCheck, "what to do when starting while the database schema is behind the server's migrations or can't be checked: refuse to start, or warn and start anyway")
+
+	fs.StringVar(&config.Server.Host, "host", config.Server.Host, "host or IP address to listen on. Defaults to every interface.")
+	fs.IntVar(&config.Server.Port, "port", config.Server.Port, "port to listen on")
+	fs.IntVar(&config.Server.AdminPort, "admin-port", config.Server.AdminPort, "port to serve operational endpoints, such as Prometheus metrics at /metrics, on. Set to 0 to disable.")
+	fs.DurationVar(&config.Server.ReadTimeout, "read-timeout", config.Server.ReadTimeout, "maximum duration for reading an entire request, including the body")
+	fs.DurationVar(&config.Server.ReadHeaderTimeout, "read-header-timeout", config.Server.ReadHeaderTimeout, "maximum duration for reading request headers")
+	fs.DurationVar(&config.Server.WriteTimeout, "write-timeout", config.Server.WriteTimeout, "maximum duration before timing out writes of a response")
+	fs.DurationVar(&config.Server.IdleTimeout, "idle-timeout", config.Server.IdleTimeout, "maximum duration to wait for the next request on a keep-alive connection")
+	fs.DurationVar(&config.Server.ShutdownTimeout, "shutdown-timeout", config.Server.ShutdownTimeout, "maximum duration to wait for in-flight requests to finish when shutting down")
+	fs.IntVar(&config.Server.MaxHeaderBytes, "max-header-bytes", config.Server.MaxHeaderBytes, "maximum size of request headers in bytes")
+	fs.Int64Var(&config.Server.MaxBodyBytes, "max-body-bytes", config.Server.MaxBodyBytes, "maximum size of request bodies in bytes. Set to 0 to disable.")
+	fs.StringVar(&config.Server.TLS.Cert, "tls-cert", config.Server.TLS.Cert, "path to a PEM-encoded certificate chain. When set together with -tls-key, the server only accepts HTTPS connections.")
+	fs.StringVar(&config.Server.TLS.Key, "tls-key", config.Server.TLS.Key, "path to the PEM-encoded private key of -tls-cert")
+	fs.StringVar(&config.Server.TLS.ClientCA, "tls-client-ca", config.Server.TLS.ClientCA, "path to a PEM-encoded bundle of CA certificates. When set, clients may authenticate with a certificate issued by one of these CAs instead of a bearer token.")
+
+	fs.Var(&listValue{list: &config.Auth.PublicKeys}, "public-key", "URL to the public key(s) used to sign auth tokens: either a JWKS document served over http(s):// or a file:// containing a JWKS document or PEM-encoded keys and certificates. May be given several times, or as a comma-separated list, to accept tokens signed by any of the keys.")
+	fs.DurationVar(&config.Auth.PublicKeyRefresh, "public-key-refresh", config.Auth.PublicKeyRefresh, "interval at which JWKS documents are reloaded. Set to 0 to disable.")
+	fs.StringVar(&config.Auth.TokenSigningKey, "token-signing-key", config.Auth.TokenSigningKey, "path to a PEM-encoded private key. When set, providers can obtain access tokens signed with this key from POST /oauth/token using credentials created with the credentials subcommand.")
+	fs.DurationVar(&config.Auth.TokenTTL, "token-ttl", config.Auth.TokenTTL, "lifetime of access tokens issued by POST /oauth/token")
+
+	fs.IntVar(&config.API.MaxPageSize, "max-page-size", config.API.MaxPageSize, "largest page[limit] accepted by paginated endpoints")
+	fs.Var(modulesValue{modules: &config.API.Modules}, "modules", "comma-separated list of MDS modules to serve: agency, provider, policy, geography, jurisdiction and metrics")
+
+	fs.TextVar(&config.Log.Level, "log-level", config.Log.Level, "minimum level of log messages: debug, info, warn or error")
+	fs.BoolVar(&config.Log.Requests, "log-requests", config.Log.Requests, "log a line for every request")
+	return fs
+}
+
+// EnvName returns the environment variable that sets the flag named name.
+func EnvName(name string) string {
+	return EnvPrefix + strings.ToUpper(strings.ReplaceAll(name, "-", "_"))
+}
+
+// Load parses the command-line arguments args and environment variables environ, in the format of
+// os.Environ, on top of the configuration file given with -config or OPEN_TRANSIT_CONFIG. Usage and
+// parse errors are written to output.
+func Load(name string, args []string, environ []string, output io.Writer) (config Config, flags Flags, err error) {
+	env := map[string]string{}
+	for _, entry := range environ {
+		key, value, _ := strings.Cut(entry, "=")
+		env[key] = value
+	}
+
+	// The flags are parsed once up front to find the configuration file, and again after loading it
+	// so that they take precedence.
+	flags.ConfigFile = env[EnvName("config")]
+	probe := Defaults()
+	err = newFlagSet(name, &probe, &flags, output).Parse(args)
+	if err != nil {
+		return
+	}
+
+	config = Defaults()
+	if flags.ConfigFile != "" {
+		err = loadFile(flags.ConfigFile, &config)
+		if err != nil {
+			return
+		}
+	}
+
+	envFlags := newFlagSet(name, &config, &Flags{}, io.Discard)
+	envFlags.VisitAll(func(f *flag.Flag) {
+		value, ok := env[EnvName(f.Name)]
+		if !ok || err != nil {
+			return
+		}
+		setErr := envFlags.Set(f.Name, value)
+		if setErr != nil {
+			err = fmt.Errorf("invalid value %q for %s: %w", value, EnvName(f.Name), setErr)
+		}
+	})
+	if err != nil {
+		return
+	}
+
+	fs := newFlagSet(name, &config, &flags, output)
+	err = fs.Parse(args)
+	flags.Args = fs.Args()
+	return
+}
+
+func loadFile(path string, config *Config) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	err = decoder.Decode(config)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+	return nil
+}
+
+// Validate returns every problem with config. Whether the server has any keys to authenticate
+// requests with is left to the caller, since subcommands don't need them.
+func Validate(config Config) []string {
+	var errs []string
+	if config.Database.URL == "" {
+		errs = append(errs, "database.url: missing required field")
+	} else if u, err := url.Parse(config.Database.URL); err != nil || (u.Scheme != "postgres" && u.Scheme != "postgresql") {
+		errs = append(errs, "database.url: must be a postgres:// URL")
+	}
+	if config.Database.MaxConns < 0 {
+		errs = append(errs, "database.max_conns: must not be negative")
+	}
+	if config.Database.MinConns < 0 {
+		errs = append(errs, "database.min_conns: must not be negative")
+	}
+	if config.Database.MaxConns > 0 && config.Database.MinConns > config.Database.MaxConns {
+		errs = append(errs, "database.min_conns: must not be greater than max_conns")
+	}
+	if config.Database.MaxConnLifetime < 0 {
+		errs = append(errs, "database.max_conn_lifetime: must not be negative")
+	}
+	if config.Database.MaxConnIdleTime < 0 {
+		errs = append(errs, "database.max_conn_idle_time: must not be negative")
+	}
+	if config.Database.SchemaCheck != SchemaCheckRefuse && config.Database.SchemaCheck != SchemaCheckWarn {
+		errs = append(errs, "database.schema_check: must be refuse or warn")
+	}
+
+	if config.Server.Port < 0 || config.Server.Port > 65535 {
+		errs = append(errs, "server.port: must be between 0 and 65535")
+	}
+	if config.Server.AdminPort < 0 || config.Server.AdminPort > 65535 {
+		errs = append(errs, "server.admin_port: must be between 0 and 65535")
+	} else if config.Server.AdminPort != 0 && config.Server.AdminPort == config.Server.Port {
+		errs = append(errs, "server.admin_port: must not be the same as port")
+	}
+	for field, timeout := range map[string]time.Duration{
+		"read_timeout":        config.Server.ReadTimeout,
+		"read_header_timeout": config.Server.ReadHeaderTimeout,
+		"write_timeout":       config.Server.WriteTimeout,
+		"idle_timeout":        config.Server.IdleTimeout,
+		"shutdown_timeout":    config.Server.ShutdownTimeout,
+	} {
+		if timeout < 0 {
+			errs = append(errs, fmt.Sprintf("server.%s: must not be negative", field))
+		}
+	}
+	if config.Server.MaxHeaderBytes <= 0 {
+		errs = append(errs, "server.max_header_bytes: must be positive")
+	}
+	if config.Server.MaxBodyBytes < 0 {
+		errs = append(errs, "server.max_body_bytes: must not be negative")
+	}
+	if (config.Server.TLS.Cert == "") != (config.Server.TLS.Key == "") {
+		errs = append(errs, "server.tls: cert and key must be set together")
+	}
+	if config.Server.TLS.ClientCA != "" && config.Server.TLS.Cert == "" {
+		errs = append(errs, "server.tls.client_ca: requires cert and key")
+	}
+
+	for _, publicKey := range config.Auth.PublicKeys {
+		u, err := url.Parse(publicKey)
+		if err != nil || u.Path == "" {
+			errs = append(errs, fmt.Sprintf("auth.public_keys: %s must be a URL with a path", publicKey))
+		}
+	}
+	if config.Auth.PublicKeyRefresh < 0 {
+		errs = append(errs, "auth.public_key_refresh: must not be negative")
+	}
+	if config.Auth.TokenTTL <= 0 {
+		errs = append(errs, "auth.token_ttl: must be positive")
+	}
+
+	if config.API.MaxPageSize <= 0 {
+		errs = append(errs, "api.max_page_size: must be positive")
+	}
+	if len(config.API.Modules) == 0 {
+		errs = append(errs, "api.modules: at least one module is required")
+	}
+	return errs
+}
+
+// Redacted returns config with secrets, such as the database password, masked so that it can be
+// printed.
+func Redacted(config Config) Config {
+	u, err := url.Parse(config.Database.URL)
+	if err == nil && u.User != nil {
+		if _, ok := u.User.Password(); ok {
+			u.User = url.UserPassword(u.User.Username(), "xxxxx")
+			config.Database.URL = u.String()
+		}
+	}
+	return config
+}
+
+// Print writes config as YAML.
+func Print(w io.Writer, config Config) error {
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	err := encoder.Encode(config)
+	if err != nil {
+		return err
+	}
+	return encoder.Close()
+}
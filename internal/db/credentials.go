@@ -0,0 +1,92 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	_ "embed"
+
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/technopolitica/open-transit/internal/domain"
+)
+
+func providerCredentialFromDTO(credential ProviderCredentialDTO) domain.ProviderCredential {
+	scopes := make([]domain.Scope, 0, len(credential.Scopes))
+	for _, scope := range credential.Scopes {
+		scopes = append(scopes, domain.Scope(scope))
+	}
+	return domain.ProviderCredential{
+		ClientID:   credential.ClientID,
+		SecretHash: credential.SecretHash,
+		ProviderID: credential.ProviderID,
+		Scopes:     scopes,
+		CreatedAt:  credential.CreatedAt,
+		RevokedAt:  credential.RevokedAt,
+	}
+}
+
+//go:embed queries/insert-provider-credential.sql
+var insertProviderCredentialQuery string
+
+func (repo Repository) InsertProviderCredential(ctx context.Context, credential domain.ProviderCredential) error {
+	scopes := make([]string, 0, len(credential.Scopes))
+	for _, scope := range credential.Scopes {
+		scopes = append(scopes, string(scope))
+	}
+	_, err := repo.Exec(ctx, insertProviderCredentialQuery, pgx.NamedArgs{
+		"client_id":   credential.ClientID,
+		"secret_hash": credential.SecretHash,
+		"provider_id": credential.ProviderID,
+		"scopes":      scopes,
+		"created_at":  credential.CreatedAt,
+	})
+
+	var pgErr *pgconn.PgError
+	if err != nil && errors.As(err, &pgErr) && pgErr.ConstraintName == "provider_credentials_pkey" && pgErr.Code == pgerrcode.UniqueViolation {
+		return ErrConflict
+	}
+
+	return err
+}
+
+//go:embed queries/fetch-provider-credential.sql
+var fetchProviderCredentialQuery string
+
+func (repo Repository) FetchProviderCredential(ctx context.Context, clientID string) (credential domain.ProviderCredential, err error) {
+	rows, err := repo.Query(ctx, fetchProviderCredentialQuery, pgx.NamedArgs{"client_id": clientID})
+	if err != nil {
+		err = fmt.Errorf("failed to execute query: %w", err)
+		return
+	}
+
+	credentialDTOs, err := pgx.CollectRows(rows, pgx.RowToStructByName[ProviderCredentialDTO])
+	if err != nil {
+		err = fmt.Errorf("failed to map row to ProviderCredentialDTO: %w", err)
+		return
+	}
+	if len(credentialDTOs) == 0 {
+		err = ErrNotFound
+		return
+	}
+
+	credential = providerCredentialFromDTO(credentialDTOs[0])
+	return
+}
+
+//go:embed queries/revoke-provider-credential.sql
+var revokeProviderCredentialQuery string
+
+// RevokeProviderCredential stops a credential from being used to obtain new tokens. Revoking a
+// credential twice keeps the time it was first revoked.
+func (repo Repository) RevokeProviderCredential(ctx context.Context, clientID string) error {
+	res, err := repo.Exec(ctx, revokeProviderCredentialQuery, pgx.NamedArgs{"client_id": clientID})
+
+	if err == nil && res.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return err
+}
@@ -0,0 +1,194 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	_ "embed"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/technopolitica/open-transit/internal/domain"
+)
+
+func dtoFromEvent(domainEvent domain.Event) EventDTO {
+	return EventDTO{
+		ID:               domainEvent.EventID,
+		Vehicle:          domainEvent.DeviceID,
+		Provider:         domainEvent.ProviderID,
+		DataProvider:     domainEvent.DataProviderID,
+		VehicleState:     domainEvent.VehicleState.String(),
+		EventTypes:       domain.Stringify(domainEvent.EventTypes),
+		Timestamp:        domainEvent.Timestamp.Time,
+		PublicationTime:  timeFromTimestamp(domainEvent.PublicationTime),
+		Location:         domainEvent.Location,
+		EventGeographies: nonNil(domainEvent.EventGeographies),
+		BatteryPercent:   int32(domainEvent.BatteryPercent),
+		FuelPercent:      int32(domainEvent.FuelPercent),
+		TripIDs:          nonNil(domainEvent.TripIDs),
+		AssociatedTicket: domainEvent.AssociatedTicket,
+	}
+}
+
+func eventFromDTO(event EventDTO) (domain.Event, error) {
+	vehicleState, err := domain.ParseVehicleState(event.VehicleState)
+	if err != nil {
+		return domain.Event{}, fmt.Errorf("invalid stored event %s: %w", event.ID, err)
+	}
+	eventTypes := make([]domain.EventType, 0, len(event.EventTypes))
+	for _, et := range event.EventTypes {
+		etParsed, err := domain.ParseEventType(et)
+		if err != nil {
+			return domain.Event{}, fmt.Errorf("invalid stored event %s: %w", event.ID, err)
+		}
+		eventTypes = append(eventTypes, etParsed)
+	}
+	return domain.Event{
+		EventID:          event.ID,
+		DeviceID:         event.Vehicle,
+		ProviderID:       event.Provider,
+		DataProviderID:   event.DataProvider,
+		VehicleState:     vehicleState,
+		EventTypes:       eventTypes,
+		Timestamp:        domain.NewTimestamp(event.Timestamp),
+		PublicationTime:  timestampFromTime(event.PublicationTime),
+		Location:         event.Location,
+		EventGeographies: nilIfEmpty(event.EventGeographies),
+		BatteryPercent:   int(event.BatteryPercent),
+		FuelPercent:      int(event.FuelPercent),
+		TripIDs:          nilIfEmpty(event.TripIDs),
+		AssociatedTicket: event.AssociatedTicket,
+	}, nil
+}
+
+//go:embed queries/list-events.sql
+var listEventsQuery string
+
+func (repo Repository) ListEvents(ctx context.Context, params domain.ListEventsParams) (events []domain.Event, err error) {
+	rows, err := repo.Query(ctx, listEventsQuery, pgx.NamedArgs{"provider": params.ProviderID, "from_time": params.Timestamp.Start, "to_time": params.Timestamp.End})
+	if err != nil {
+		err = fmt.Errorf("failed to execute query: %w", err)
+		return
+	}
+
+	eventDTOs, err := pgx.CollectRows(rows, pgx.RowToStructByName[EventDTO])
+	if err != nil {
+		err = fmt.Errorf("failed to map row to EventDTO: %w", err)
+		return
+	}
+	events = make([]domain.Event, 0, len(eventDTOs))
+	for _, dto := range eventDTOs {
+		var event domain.Event
+		event, err = eventFromDTO(dto)
+		if err != nil {
+			return
+		}
+		events = append(events, event)
+	}
+	return
+}
+
+//go:embed queries/fetch-event.sql
+var fetchEventQuery string
+
+func (repo Repository) FetchEvent(ctx context.Context, eventID uuid.UUID) (event domain.Event, err error) {
+	rows, err := repo.Query(ctx, fetchEventQuery, pgx.NamedArgs{"id": eventID})
+	if err != nil {
+		err = fmt.Errorf("failed to execute query: %w", err)
+		return
+	}
+
+	eventDTOs, err := pgx.CollectRows(rows, pgx.RowToStructByName[EventDTO])
+	if err != nil {
+		err = fmt.Errorf("failed to map row to EventDTO: %w", err)
+		return
+	}
+	if len(eventDTOs) == 0 {
+		err = ErrNotFound
+		return
+	}
+
+	event, err = eventFromDTO(eventDTOs[0])
+	return
+}
+
+//go:embed queries/fetch-previous-event.sql
+var fetchPreviousEventQuery string
+
+func (repo Repository) FetchPreviousEvent(ctx context.Context, params domain.FetchAdjacentEventParams) (event domain.Event, err error) {
+	rows, err := repo.Query(ctx, fetchPreviousEventQuery, pgx.NamedArgs{"vehicle": params.VehicleID, "provider": params.ProviderID, "timestamp": params.Timestamp, "id": params.EventID})
+	if err != nil {
+		err = fmt.Errorf("failed to execute query: %w", err)
+		return
+	}
+
+	eventDTOs, err := pgx.CollectRows(rows, pgx.RowToStructByName[EventDTO])
+	if err != nil {
+		err = fmt.Errorf("failed to map row to EventDTO: %w", err)
+		return
+	}
+	if len(eventDTOs) == 0 {
+		err = ErrNotFound
+		return
+	}
+
+	event, err = eventFromDTO(eventDTOs[0])
+	return
+}
+
+//go:embed queries/fetch-next-event.sql
+var fetchNextEventQuery string
+
+func (repo Repository) FetchNextEvent(ctx context.Context, params domain.FetchAdjacentEventParams) (event domain.Event, err error) {
+	rows, err := repo.Query(ctx, fetchNextEventQuery, pgx.NamedArgs{"vehicle": params.VehicleID, "provider": params.ProviderID, "timestamp": params.Timestamp, "id": params.EventID})
+	if err != nil {
+		err = fmt.Errorf("failed to execute query: %w", err)
+		return
+	}
+
+	eventDTOs, err := pgx.CollectRows(rows, pgx.RowToStructByName[EventDTO])
+	if err != nil {
+		err = fmt.Errorf("failed to map row to EventDTO: %w", err)
+		return
+	}
+	if len(eventDTOs) == 0 {
+		err = ErrNotFound
+		return
+	}
+
+	event, err = eventFromDTO(eventDTOs[0])
+	return
+}
+
+//go:embed queries/insert-event.sql
+var insertEventQuery string
+
+func (repo Repository) InsertEvent(ctx context.Context, event domain.Event) error {
+	eventDTO := dtoFromEvent(event)
+	_, err := repo.Exec(ctx, insertEventQuery, pgx.NamedArgs{
+		"id":                eventDTO.ID,
+		"vehicle":           eventDTO.Vehicle,
+		"provider":          eventDTO.Provider,
+		"data_provider":     eventDTO.DataProvider,
+		"vehicle_state":     eventDTO.VehicleState,
+		"event_types":       eventDTO.EventTypes,
+		"timestamp":         eventDTO.Timestamp,
+		"publication_time":  eventDTO.PublicationTime,
+		"location":          eventDTO.Location,
+		"event_geographies": eventDTO.EventGeographies,
+		"battery_percent":   eventDTO.BatteryPercent,
+		"fuel_percent":      eventDTO.FuelPercent,
+		"trip_ids":          eventDTO.TripIDs,
+		"associated_ticket": eventDTO.AssociatedTicket,
+	})
+
+	var pgErr *pgconn.PgError
+	if err != nil && errors.As(err, &pgErr) && pgErr.ConstraintName == "event_pkey" && pgErr.Code == pgerrcode.UniqueViolation {
+		return ErrConflict
+	}
+
+	return err
+}
@@ -0,0 +1,38 @@
+package db
+
+import (
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/technopolitica/open-transit/internal/domain"
+)
+
+var _ = Describe("eventFromDTO", func() {
+	var dto EventDTO
+	BeforeEach(func() {
+		dto = EventDTO{
+			ID:           uuid.New(),
+			VehicleState: "available",
+			EventTypes:   []string{"trip_end"},
+		}
+	})
+
+	It("parses the stored vehicle state and event types", func() {
+		event, err := eventFromDTO(dto)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(event.VehicleState).To(Equal(domain.VehicleStateAvailable))
+		Expect(event.EventTypes).To(Equal([]domain.EventType{domain.EventTypeTripEnd}))
+	})
+
+	It("rejects unknown vehicle states", func() {
+		dto.VehicleState = "teleporting"
+		_, err := eventFromDTO(dto)
+		Expect(err).To(MatchError(domain.ErrInvalidVehicleState))
+	})
+
+	It("rejects unknown event types", func() {
+		dto.EventTypes = []string{"trip_end", "teleported"}
+		_, err := eventFromDTO(dto)
+		Expect(err).To(MatchError(domain.ErrInvalidEventType))
+	})
+})
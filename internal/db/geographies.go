@@ -0,0 +1,114 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	_ "embed"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/technopolitica/open-transit/internal/domain"
+)
+
+func dtoFromGeography(domainGeography domain.Geography) GeographyDTO {
+	return GeographyDTO{
+		ID:              domainGeography.GeographyID,
+		Name:            domainGeography.Name,
+		Description:     domainGeography.Description,
+		GeographyType:   domainGeography.GeographyType,
+		GeographyJSON:   domainGeography.GeographyJSON,
+		EffectiveDate:   timeFromTimestamp(domainGeography.EffectiveDate),
+		PublishedDate:   domainGeography.PublishedDate.Time,
+		RetireDate:      timeFromTimestamp(domainGeography.RetireDate),
+		PrevGeographies: nonNil(domainGeography.PrevGeographies),
+	}
+}
+
+func geographyFromDTO(geography GeographyDTO) domain.Geography {
+	return domain.Geography{
+		GeographyID:     geography.ID,
+		Name:            geography.Name,
+		Description:     geography.Description,
+		GeographyType:   geography.GeographyType,
+		GeographyJSON:   geography.GeographyJSON,
+		EffectiveDate:   timestampFromTime(geography.EffectiveDate),
+		PublishedDate:   domain.NewTimestamp(geography.PublishedDate),
+		RetireDate:      timestampFromTime(geography.RetireDate),
+		PrevGeographies: nilIfEmpty(geography.PrevGeographies),
+	}
+}
+
+//go:embed queries/list-geographies.sql
+var listGeographiesQuery string
+
+func (repo Repository) ListGeographies(ctx context.Context) (geographies []domain.Geography, err error) {
+	rows, err := repo.Query(ctx, listGeographiesQuery)
+	if err != nil {
+		err = fmt.Errorf("failed to execute query: %w", err)
+		return
+	}
+
+	geographyDTOs, err := pgx.CollectRows(rows, pgx.RowToStructByName[GeographyDTO])
+	if err != nil {
+		err = fmt.Errorf("failed to map row to GeographyDTO: %w", err)
+		return
+	}
+	geographies = make([]domain.Geography, 0, len(geographyDTOs))
+	for _, dto := range geographyDTOs {
+		geographies = append(geographies, geographyFromDTO(dto))
+	}
+	return
+}
+
+//go:embed queries/fetch-geography.sql
+var fetchGeographyQuery string
+
+func (repo Repository) FetchGeography(ctx context.Context, geographyID uuid.UUID) (geography domain.Geography, err error) {
+	rows, err := repo.Query(ctx, fetchGeographyQuery, pgx.NamedArgs{"id": geographyID})
+	if err != nil {
+		err = fmt.Errorf("failed to execute query: %w", err)
+		return
+	}
+
+	geographyDTOs, err := pgx.CollectRows(rows, pgx.RowToStructByName[GeographyDTO])
+	if err != nil {
+		err = fmt.Errorf("failed to map row to GeographyDTO: %w", err)
+		return
+	}
+	if len(geographyDTOs) == 0 {
+		err = ErrNotFound
+		return
+	}
+
+	geography = geographyFromDTO(geographyDTOs[0])
+	return
+}
+
+//go:embed queries/insert-geography.sql
+var insertGeographyQuery string
+
+func (repo Repository) InsertGeography(ctx context.Context, geography domain.Geography) error {
+	geographyDTO := dtoFromGeography(geography)
+	_, err := repo.Exec(ctx, insertGeographyQuery, pgx.NamedArgs{
+		"id":               geographyDTO.ID,
+		"name":             geographyDTO.Name,
+		"description":      geographyDTO.Description,
+		"geography_type":   geographyDTO.GeographyType,
+		"geography_json":   geographyDTO.GeographyJSON,
+		"effective_date":   geographyDTO.EffectiveDate,
+		"published_date":   geographyDTO.PublishedDate,
+		"retire_date":      geographyDTO.RetireDate,
+		"prev_geographies": geographyDTO.PrevGeographies,
+	})
+
+	var pgErr *pgconn.PgError
+	if err != nil && errors.As(err, &pgErr) && pgErr.ConstraintName == "geography_pkey" && pgErr.Code == pgerrcode.UniqueViolation {
+		return ErrConflict
+	}
+
+	return err
+}
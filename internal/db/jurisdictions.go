@@ -0,0 +1,140 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	_ "embed"
+
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/technopolitica/open-transit/internal/domain"
+)
+
+func dtoFromJurisdiction(domainJurisdiction domain.Jurisdiction) JurisdictionVersionDTO {
+	return JurisdictionVersionDTO{
+		Jurisdiction:      domainJurisdiction.JurisdictionID,
+		Timestamp:         domainJurisdiction.Timestamp.Time,
+		AgencyKey:         domainJurisdiction.AgencyKey,
+		AgencyName:        domainJurisdiction.AgencyName,
+		GeographyID:       domainJurisdiction.GeographyID,
+		PrevJurisdictions: nonNil(domainJurisdiction.PrevJurisdictions),
+	}
+}
+
+func jurisdictionFromDTO(jurisdiction JurisdictionVersionDTO) domain.Jurisdiction {
+	return domain.Jurisdiction{
+		JurisdictionID:    jurisdiction.Jurisdiction,
+		Timestamp:         domain.NewTimestamp(jurisdiction.Timestamp),
+		AgencyKey:         jurisdiction.AgencyKey,
+		AgencyName:        jurisdiction.AgencyName,
+		GeographyID:       jurisdiction.GeographyID,
+		PrevJurisdictions: nilIfEmpty(jurisdiction.PrevJurisdictions),
+	}
+}
+
+//go:embed queries/list-jurisdictions.sql
+var listJurisdictionsQuery string
+
+func (repo Repository) ListJurisdictions(ctx context.Context, params domain.ListJurisdictionsParams) (jurisdictions []domain.Jurisdiction, err error) {
+	rows, err := repo.Query(ctx, listJurisdictionsQuery, pgx.NamedArgs{"effective": params.Effective})
+	if err != nil {
+		err = fmt.Errorf("failed to execute query: %w", err)
+		return
+	}
+
+	jurisdictionDTOs, err := pgx.CollectRows(rows, pgx.RowToStructByName[JurisdictionVersionDTO])
+	if err != nil {
+		err = fmt.Errorf("failed to map row to JurisdictionVersionDTO: %w", err)
+		return
+	}
+	jurisdictions = make([]domain.Jurisdiction, 0, len(jurisdictionDTOs))
+	for _, dto := range jurisdictionDTOs {
+		jurisdictions = append(jurisdictions, jurisdictionFromDTO(dto))
+	}
+	return
+}
+
+//go:embed queries/fetch-jurisdiction.sql
+var fetchJurisdictionQuery string
+
+func (repo Repository) FetchJurisdiction(ctx context.Context, params domain.FetchJurisdictionParams) (jurisdiction domain.Jurisdiction, err error) {
+	rows, err := repo.Query(ctx, fetchJurisdictionQuery, pgx.NamedArgs{"jurisdiction": params.JurisdictionID, "effective": params.Effective})
+	if err != nil {
+		err = fmt.Errorf("failed to execute query: %w", err)
+		return
+	}
+
+	jurisdictionDTOs, err := pgx.CollectRows(rows, pgx.RowToStructByName[JurisdictionVersionDTO])
+	if err != nil {
+		err = fmt.Errorf("failed to map row to JurisdictionVersionDTO: %w", err)
+		return
+	}
+	if len(jurisdictionDTOs) == 0 {
+		err = ErrNotFound
+		return
+	}
+
+	jurisdiction = jurisdictionFromDTO(jurisdictionDTOs[0])
+	return
+}
+
+//go:embed queries/insert-jurisdiction.sql
+var insertJurisdictionQuery string
+
+//go:embed queries/insert-jurisdiction-version.sql
+var insertJurisdictionVersionQuery string
+
+func jurisdictionVersionNamedArgs(dto JurisdictionVersionDTO) pgx.NamedArgs {
+	return pgx.NamedArgs{
+		"jurisdiction":       dto.Jurisdiction,
+		"timestamp":          dto.Timestamp,
+		"agency_key":         dto.AgencyKey,
+		"agency_name":        dto.AgencyName,
+		"geography_id":       dto.GeographyID,
+		"prev_jurisdictions": dto.PrevJurisdictions,
+	}
+}
+
+func jurisdictionVersionError(err error) error {
+	var pgErr *pgconn.PgError
+	if err == nil || !errors.As(err, &pgErr) {
+		return err
+	}
+	switch {
+	case pgErr.ConstraintName == "jurisdiction_version_jurisdiction_fkey" && pgErr.Code == pgerrcode.ForeignKeyViolation:
+		return ErrNotFound
+	case pgErr.ConstraintName == "jurisdiction_version_geography_id_fkey" && pgErr.Code == pgerrcode.ForeignKeyViolation:
+		return ErrInvalidReference
+	case pgErr.ConstraintName == "jurisdiction_version_pkey" && pgErr.Code == pgerrcode.UniqueViolation:
+		return ErrConflict
+	}
+	return err
+}
+
+// InsertJurisdiction registers a new jurisdiction along with its first version.
+func (repo Repository) InsertJurisdiction(ctx context.Context, jurisdiction domain.Jurisdiction) error {
+	jurisdictionDTO := dtoFromJurisdiction(jurisdiction)
+	return repo.WithinTransaction(ctx, func(tx pgx.Tx) error {
+		_, err := tx.Exec(ctx, insertJurisdictionQuery, pgx.NamedArgs{"jurisdiction": jurisdictionDTO.Jurisdiction})
+
+		var pgErr *pgconn.PgError
+		if err != nil && errors.As(err, &pgErr) && pgErr.ConstraintName == "jurisdiction_pkey" && pgErr.Code == pgerrcode.UniqueViolation {
+			return ErrConflict
+		}
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(ctx, insertJurisdictionVersionQuery, jurisdictionVersionNamedArgs(jurisdictionDTO))
+		return jurisdictionVersionError(err)
+	})
+}
+
+// InsertJurisdictionVersion records a change to an existing jurisdiction.
+func (repo Repository) InsertJurisdictionVersion(ctx context.Context, jurisdiction domain.Jurisdiction) error {
+	_, err := repo.Exec(ctx, insertJurisdictionVersionQuery, jurisdictionVersionNamedArgs(dtoFromJurisdiction(jurisdiction)))
+	return jurisdictionVersionError(err)
+}
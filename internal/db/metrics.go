@@ -0,0 +1,117 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	_ "embed"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/technopolitica/open-transit/internal/domain"
+)
+
+func tripFactFromDTO(fact TripFactDTO) (domain.TripFact, error) {
+	vehicleType, err := domain.ParseVehicleType(fact.VehicleType)
+	if err != nil {
+		return domain.TripFact{}, fmt.Errorf("invalid stored trip %s: %w", fact.TripID, err)
+	}
+	return domain.TripFact{
+		TripID:       fact.TripID,
+		ProviderID:   fact.Provider,
+		VehicleType:  vehicleType,
+		StartTime:    fact.StartTime,
+		Duration:     int(fact.Duration),
+		Distance:     int(fact.Distance),
+		GeographyIDs: nilIfEmpty(fact.GeographyIDs),
+	}, nil
+}
+
+func vehicleEventFactFromDTO(fact VehicleEventFactDTO) (domain.VehicleEventFact, error) {
+	vehicleType, err := domain.ParseVehicleType(fact.VehicleType)
+	if err != nil {
+		return domain.VehicleEventFact{}, fmt.Errorf("invalid stored vehicle %s: %w", fact.DeviceID, err)
+	}
+	vehicleState, err := domain.ParseVehicleState(fact.VehicleState)
+	if err != nil {
+		return domain.VehicleEventFact{}, fmt.Errorf("invalid stored event for vehicle %s: %w", fact.DeviceID, err)
+	}
+	eventTypes := make([]domain.EventType, 0, len(fact.EventTypes))
+	for _, et := range fact.EventTypes {
+		etParsed, err := domain.ParseEventType(et)
+		if err != nil {
+			return domain.VehicleEventFact{}, fmt.Errorf("invalid stored event for vehicle %s: %w", fact.DeviceID, err)
+		}
+		eventTypes = append(eventTypes, etParsed)
+	}
+	return domain.VehicleEventFact{
+		DeviceID:     fact.DeviceID,
+		ProviderID:   fact.Provider,
+		VehicleType:  vehicleType,
+		Timestamp:    fact.Timestamp,
+		VehicleState: vehicleState,
+		EventTypes:   eventTypes,
+		GeographyIDs: nilIfEmpty(fact.GeographyIDs),
+	}, nil
+}
+
+func metricsFactsArgs(params domain.MetricsFactsParams) pgx.NamedArgs {
+	return pgx.NamedArgs{
+		"from_time": params.Range.Start,
+		"to_time":   params.Range.End,
+		"providers": params.ProviderIDs,
+	}
+}
+
+//go:embed queries/list-trip-facts.sql
+var listTripFactsQuery string
+
+func (repo Repository) ListTripFacts(ctx context.Context, params domain.MetricsFactsParams) (facts []domain.TripFact, err error) {
+	rows, err := repo.Query(ctx, listTripFactsQuery, metricsFactsArgs(params))
+	if err != nil {
+		err = fmt.Errorf("failed to execute query: %w", err)
+		return
+	}
+
+	factDTOs, err := pgx.CollectRows(rows, pgx.RowToStructByName[TripFactDTO])
+	if err != nil {
+		err = fmt.Errorf("failed to map row to TripFactDTO: %w", err)
+		return
+	}
+	facts = make([]domain.TripFact, 0, len(factDTOs))
+	for _, dto := range factDTOs {
+		var fact domain.TripFact
+		fact, err = tripFactFromDTO(dto)
+		if err != nil {
+			return
+		}
+		facts = append(facts, fact)
+	}
+	return
+}
+
+//go:embed queries/list-vehicle-event-facts.sql
+var listVehicleEventFactsQuery string
+
+func (repo Repository) ListVehicleEventFacts(ctx context.Context, params domain.MetricsFactsParams) (facts []domain.VehicleEventFact, err error) {
+	rows, err := repo.Query(ctx, listVehicleEventFactsQuery, metricsFactsArgs(params))
+	if err != nil {
+		err = fmt.Errorf("failed to execute query: %w", err)
+		return
+	}
+
+	factDTOs, err := pgx.CollectRows(rows, pgx.RowToStructByName[VehicleEventFactDTO])
+	if err != nil {
+		err = fmt.Errorf("failed to map row to VehicleEventFactDTO: %w", err)
+		return
+	}
+	facts = make([]domain.VehicleEventFact, 0, len(factDTOs))
+	for _, dto := range factDTOs {
+		var fact domain.VehicleEventFact
+		fact, err = vehicleEventFactFromDTO(dto)
+		if err != nil {
+			return
+		}
+		facts = append(facts, fact)
+	}
+	return
+}
@@ -1,48 +1,381 @@
 package db
 
 import (
+	"bufio"
 	"context"
+	"database/sql"
 	"embed"
+	"errors"
 	"fmt"
-	"strconv"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+	"time"
 
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/pressly/goose/v3"
 )
 
 //go:embed migrations/*.sql
 var Migrations embed.FS
 
-func MigrateTo(ctx context.Context, connectionURL string, version string) (err error) {
+const migrationsDir = "migrations"
+
+// Migration is one of the migrations in Migrations.
+type Migration struct {
+	Version int64
+	// Name is the name of the migration's file.
+	Name string
+	// AppliedAt is when the migration was applied to the database, or nil if it's pending.
+	AppliedAt *time.Time
+}
+
+// Migrator applies the migrations in Migrations to a database and rolls them back.
+type Migrator struct {
+	db *sql.DB
+}
+
+func OpenMigrator(connectionURL string) (migrator *Migrator, err error) {
+	goose.SetBaseFS(Migrations)
+	err = goose.SetDialect("postgres")
+	if err != nil {
+		err = fmt.Errorf("failed to set dialect: %w", err)
+		return
+	}
 	db, err := goose.OpenDBWithDriver("pgx", connectionURL)
 	if err != nil {
-		return fmt.Errorf("failed to connect with database: %w", err)
+		err = fmt.Errorf("failed to connect with database: %w", err)
+		return
 	}
+	migrator = &Migrator{db: db}
+	return
+}
 
-	defer func() {
-		dbErr := db.Close()
-		if dbErr != nil {
-			if err == nil {
-				err = fmt.Errorf("failed to close database connection: %w", err)
-			} else {
-				err = fmt.Errorf("multiple errors occurred: %w, %s", err, dbErr)
-			}
+func (migrator *Migrator) Close() error {
+	err := migrator.db.Close()
+	if err != nil {
+		return fmt.Errorf("failed to close database connection: %w", err)
+	}
+	return nil
+}
+
+// Status returns every migration, oldest first, along with the current version of the database.
+func (migrator *Migrator) Status(ctx context.Context) (migrations []Migration, version int64, err error) {
+	names, err := migrationFiles()
+	if err != nil {
+		return
+	}
+	records, err := migrator.versionRecords(ctx)
+	if err != nil {
+		return
+	}
+	applied, version := appliedVersions(records)
+	for _, name := range names {
+		var migrationVersion int64
+		migrationVersion, err = goose.NumericComponent(name)
+		if err != nil {
+			err = fmt.Errorf("invalid migration %s: %w", name, err)
+			return
 		}
-	}()
+		migration := Migration{Version: migrationVersion, Name: name}
+		if appliedAt, ok := applied[migrationVersion]; ok {
+			migration.AppliedAt = &appliedAt
+		}
+		migrations = append(migrations, migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return
+}
 
-	goose.SetBaseFS(Migrations)
-	err = goose.SetDialect("postgres")
+// Version returns the version of the newest migration applied to the database, or 0 if none have
+// been.
+func (migrator *Migrator) Version(ctx context.Context) (version int64, err error) {
+	records, err := migrator.versionRecords(ctx)
+	if err != nil {
+		return
+	}
+	_, version = appliedVersions(records)
+	return
+}
+
+// PlanUp returns the migrations that Up would apply, in the order it would apply them.
+func (migrator *Migrator) PlanUp(ctx context.Context, target int64) (plan []Migration, err error) {
+	migrations, version, err := migrator.Status(ctx)
+	if err != nil {
+		return
+	}
+	err = checkTarget(migrations, target)
+	if err != nil {
+		return
+	}
+	for _, migration := range migrations {
+		if migration.Version > version && migration.Version <= target {
+			plan = append(plan, migration)
+		}
+	}
+	return
+}
+
+// PlanDown returns the migrations that Down would roll back, in the order it would roll them back.
+func (migrator *Migrator) PlanDown(ctx context.Context, target int64) (plan []Migration, err error) {
+	migrations, _, err := migrator.Status(ctx)
+	if err != nil {
+		return
+	}
+	if target != 0 {
+		err = checkTarget(migrations, target)
+		if err != nil {
+			return
+		}
+	}
+	for i := len(migrations) - 1; i >= 0; i-- {
+		if migrations[i].AppliedAt != nil && migrations[i].Version > target {
+			plan = append(plan, migrations[i])
+		}
+	}
+	return
+}
+
+// PlanRedo returns the migration that Redo would roll back and apply again, if any.
+func (migrator *Migrator) PlanRedo(ctx context.Context) (plan []Migration, err error) {
+	migrations, version, err := migrator.Status(ctx)
+	if err != nil || version == 0 {
+		return
+	}
+	for _, migration := range migrations {
+		if migration.Version == version {
+			plan = append(plan, migration)
+			return
+		}
+	}
+	err = fmt.Errorf("no migration file for current version %d", version)
+	return
+}
+
+// Up applies every pending migration up to and including target.
+func (migrator *Migrator) Up(ctx context.Context, target int64) error {
+	plan, err := migrator.PlanUp(ctx, target)
+	if err != nil {
+		return err
+	}
+	if len(plan) == 0 {
+		return nil
+	}
+	return goose.UpToContext(ctx, migrator.db, migrationsDir, target)
+}
+
+// Down rolls back every applied migration newer than target. Nothing is rolled back if any of
+// them can't be.
+func (migrator *Migrator) Down(ctx context.Context, target int64) error {
+	plan, err := migrator.PlanDown(ctx, target)
+	if err != nil {
+		return err
+	}
+	err = checkReversible(plan)
+	if err != nil {
+		return err
+	}
+	if len(plan) == 0 {
+		return nil
+	}
+	return goose.DownToContext(ctx, migrator.db, migrationsDir, target)
+}
+
+// Redo rolls back the newest applied migration and applies it again.
+func (migrator *Migrator) Redo(ctx context.Context) error {
+	plan, err := migrator.PlanRedo(ctx)
+	if err != nil {
+		return err
+	}
+	err = checkReversible(plan)
+	if err != nil {
+		return err
+	}
+	if len(plan) == 0 {
+		return nil
+	}
+	return goose.RedoContext(ctx, migrator.db, migrationsDir)
+}
+
+func checkTarget(migrations []Migration, target int64) error {
+	for _, migration := range migrations {
+		if migration.Version == target {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown migration version %d", target)
+}
+
+func checkReversible(plan []Migration) error {
+	for _, migration := range plan {
+		_, ok, err := MigrationSQL(migration, false)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("migration %s can't be rolled back because it has no down section", migration.Name)
+		}
+	}
+	return nil
+}
+
+// MigrationSQL returns the SQL that applies a migration or, if up is false, rolls it back. ok is
+// false if the migration has no section for that direction.
+func MigrationSQL(migration Migration, up bool) (sql string, ok bool, err error) {
+	file, err := Migrations.Open(path.Join(migrationsDir, migration.Name))
 	if err != nil {
-		return fmt.Errorf("failed to set dialect: %w", err)
+		err = fmt.Errorf("failed to read migration %s: %w", migration.Name, err)
+		return
 	}
-	if version == "latest" {
-		err = goose.UpContext(ctx, db, "migrations")
+	defer file.Close()
+	section := "-- +goose Down"
+	if up {
+		section = "-- +goose Up"
+	}
+	var builder strings.Builder
+	inSection := false
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "-- +goose Up" || trimmed == "-- +goose Down" {
+			inSection = trimmed == section
+			ok = ok || inSection
+			continue
+		}
+		if inSection {
+			builder.WriteString(line)
+			builder.WriteString("\n")
+		}
+	}
+	err = scanner.Err()
+	if err != nil {
+		err = fmt.Errorf("failed to read migration %s: %w", migration.Name, err)
 		return
 	}
-	versionInt, err := strconv.ParseInt(version, 10, 0)
+	sql = strings.TrimSpace(builder.String())
+	return
+}
+
+func migrationFiles() (names []string, err error) {
+	paths, err := fs.Glob(Migrations, path.Join(migrationsDir, "*.sql"))
 	if err != nil {
-		err = fmt.Errorf("failed to parse version: %w", err)
 		return
 	}
-	err = goose.UpToContext(ctx, db, "migrations", versionInt)
+	for _, migrationPath := range paths {
+		names = append(names, path.Base(migrationPath))
+	}
 	return
 }
+
+// LatestMigrationVersion returns the version of the newest migration in Migrations.
+func LatestMigrationVersion() (latest int64, err error) {
+	names, err := migrationFiles()
+	if err != nil {
+		return
+	}
+	for _, name := range names {
+		var version int64
+		version, err = goose.NumericComponent(name)
+		if err != nil {
+			err = fmt.Errorf("invalid migration %s: %w", name, err)
+			return
+		}
+		if version > latest {
+			latest = version
+		}
+	}
+	return
+}
+
+// versionRecord is a row of goose's version table, which logs every time a migration is applied or
+// rolled back.
+type versionRecord struct {
+	version   int64
+	isApplied bool
+	appliedAt time.Time
+}
+
+const versionRecordsQuery = "SELECT version_id, is_applied, tstamp FROM goose_db_version ORDER BY id DESC"
+
+// appliedVersions replays the records of goose's version table, newest first, the same way goose
+// does. It returns when every migration that is still applied was applied, and the current version.
+func appliedVersions(records []versionRecord) (applied map[int64]time.Time, current int64) {
+	applied = map[int64]time.Time{}
+	// The most recent record of a version tells whether it's applied or has been rolled back.
+	seen := map[int64]bool{}
+	for _, record := range records {
+		if seen[record.version] {
+			continue
+		}
+		seen[record.version] = true
+		// goose records version 0 when it creates the table.
+		if !record.isApplied || record.version == 0 {
+			continue
+		}
+		applied[record.version] = record.appliedAt
+		if current == 0 {
+			current = record.version
+		}
+	}
+	return
+}
+
+func (migrator *Migrator) versionRecords(ctx context.Context) (records []versionRecord, err error) {
+	rows, err := migrator.db.QueryContext(ctx, versionRecordsQuery)
+	if err != nil {
+		err = versionTableError(err)
+		return
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var record versionRecord
+		err = rows.Scan(&record.version, &record.isApplied, &record.appliedAt)
+		if err != nil {
+			err = fmt.Errorf("failed to read schema version: %w", err)
+			return
+		}
+		records = append(records, record)
+	}
+	err = versionTableError(rows.Err())
+	return
+}
+
+// SchemaVersion returns the version of the newest migration applied to the database, or 0 if none
+// have been. Unlike goose, it doesn't create the version table if it doesn't exist.
+func SchemaVersion(ctx context.Context, conn DBConnection) (version int64, err error) {
+	rows, err := conn.Query(ctx, versionRecordsQuery)
+	if err != nil {
+		err = versionTableError(err)
+		return
+	}
+	defer rows.Close()
+	var records []versionRecord
+	for rows.Next() {
+		var record versionRecord
+		err = rows.Scan(&record.version, &record.isApplied, &record.appliedAt)
+		if err != nil {
+			err = fmt.Errorf("failed to read schema version: %w", err)
+			return
+		}
+		records = append(records, record)
+	}
+	err = versionTableError(rows.Err())
+	if err != nil {
+		return
+	}
+	_, version = appliedVersions(records)
+	return
+}
+
+// versionTableError ignores errors caused by goose's version table not existing, which means that
+// no migrations have been applied.
+func versionTableError(err error) error {
+	var pgErr *pgconn.PgError
+	if err == nil || (errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UndefinedTable) {
+		return nil
+	}
+	return fmt.Errorf("failed to read schema version: %w", err)
+}
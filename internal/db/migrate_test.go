@@ -0,0 +1,49 @@
+package db
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Migrations", func() {
+	It("can all be rolled back", func() {
+		names, err := migrationFiles()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(names).NotTo(BeEmpty())
+		for _, name := range names {
+			up, ok, err := MigrationSQL(Migration{Name: name}, true)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ok).To(BeTrue(), "%s has no up section", name)
+			Expect(up).NotTo(BeEmpty(), "%s has an empty up section", name)
+			down, ok, err := MigrationSQL(Migration{Name: name}, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ok).To(BeTrue(), "%s has no down section", name)
+			Expect(down).NotTo(BeEmpty(), "%s has an empty down section", name)
+		}
+	})
+})
+
+var _ = Describe("appliedVersions", func() {
+	first := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	second := first.Add(time.Minute)
+
+	It("ignores migrations that have been rolled back", func() {
+		applied, current := appliedVersions([]versionRecord{
+			{version: 3, isApplied: false, appliedAt: second},
+			{version: 3, isApplied: true, appliedAt: first},
+			{version: 2, isApplied: true, appliedAt: first},
+			{version: 1, isApplied: true, appliedAt: first},
+			{version: 0, isApplied: true, appliedAt: first},
+		})
+		Expect(applied).To(Equal(map[int64]time.Time{1: first, 2: first}))
+		Expect(current).To(Equal(int64(2)))
+	})
+
+	It("reports version 0 when nothing has been applied", func() {
+		applied, current := appliedVersions([]versionRecord{{version: 0, isApplied: true, appliedAt: first}})
+		Expect(applied).To(BeEmpty())
+		Expect(current).To(BeZero())
+	})
+})
@@ -64,3 +64,9 @@ CREATE TABLE IF NOT EXISTS vehicle_propulsion_type (
     ) ON UPDATE CASCADE,
     PRIMARY KEY (vehicle, propulsion_type)
 );
+
+-- +goose Down
+DROP TABLE IF EXISTS vehicle_propulsion_type;
+DROP TABLE IF EXISTS vehicle;
+DROP TABLE IF EXISTS propulsion_type;
+DROP TABLE IF EXISTS vehicle_type;
@@ -110,3 +110,10 @@ CREATE OR REPLACE TRIGGER on_vehicle_denormalized_update
 INSTEAD OF UPDATE ON vehicle_denormalized
 FOR EACH ROW
 EXECUTE PROCEDURE UPDATE_DENORMALIZED_VEHICLE();
+
+-- +goose Down
+DROP TRIGGER IF EXISTS on_vehicle_denormalized_update ON vehicle_denormalized;
+DROP FUNCTION IF EXISTS UPDATE_DENORMALIZED_VEHICLE();
+DROP TRIGGER IF EXISTS on_vehicle_denormalized_insert ON vehicle_denormalized;
+DROP FUNCTION IF EXISTS INSERT_DENORMALIZED_VEHICLE();
+DROP VIEW IF EXISTS vehicle_denormalized;
@@ -0,0 +1,48 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS vehicle_state (
+    name TEXT PRIMARY KEY CHECK (name != '')
+);
+
+INSERT INTO
+vehicle_state (name)
+VALUES
+('removed'),
+('available'),
+('non_operational'),
+('reserved'),
+('on_trip'),
+('stopped'),
+('non_contactable'),
+('missing'),
+('elsewhere')
+ON CONFLICT DO NOTHING;
+
+CREATE TABLE IF NOT EXISTS event (
+    id UUID PRIMARY KEY CHECK (
+        id != '00000000-0000-0000-0000-000000000000'
+    ),
+    vehicle UUID NOT NULL REFERENCES vehicle (id),
+    provider UUID NOT NULL CHECK (
+        provider != '00000000-0000-0000-0000-000000000000'
+    ),
+    data_provider UUID NOT NULL,
+    vehicle_state TEXT NOT NULL REFERENCES vehicle_state (
+        name
+    ) ON UPDATE CASCADE,
+    event_types TEXT [] NOT NULL CHECK (cardinality(event_types) > 0),
+    timestamp TIMESTAMPTZ NOT NULL,
+    publication_time TIMESTAMPTZ,
+    location JSONB NOT NULL CHECK (jsonb_typeof(location) = 'object'),
+    event_geographies UUID [] NOT NULL DEFAULT '{}',
+    battery_percent INTEGER NOT NULL DEFAULT 0,
+    fuel_percent INTEGER NOT NULL DEFAULT 0,
+    trip_ids UUID [] NOT NULL DEFAULT '{}',
+    associated_ticket TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS event_vehicle_timestamp_idx
+ON event (vehicle, timestamp DESC);
+
+-- +goose Down
+DROP TABLE IF EXISTS event;
+DROP TABLE IF EXISTS vehicle_state;
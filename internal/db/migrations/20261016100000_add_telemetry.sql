@@ -0,0 +1,33 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS telemetry (
+    id UUID PRIMARY KEY CHECK (
+        id != '00000000-0000-0000-0000-000000000000'
+    ),
+    vehicle UUID NOT NULL REFERENCES vehicle (id),
+    provider UUID NOT NULL CHECK (
+        provider != '00000000-0000-0000-0000-000000000000'
+    ),
+    data_provider UUID NOT NULL,
+    timestamp TIMESTAMPTZ NOT NULL,
+    lat DOUBLE PRECISION NOT NULL CHECK (lat BETWEEN -90 AND 90),
+    lng DOUBLE PRECISION NOT NULL CHECK (lng BETWEEN -180 AND 180),
+    altitude DOUBLE PRECISION NOT NULL DEFAULT 0,
+    heading DOUBLE PRECISION NOT NULL DEFAULT 0,
+    speed DOUBLE PRECISION NOT NULL DEFAULT 0,
+    horizontal_accuracy DOUBLE PRECISION NOT NULL DEFAULT 0,
+    vertical_accuracy DOUBLE PRECISION NOT NULL DEFAULT 0,
+    satellites INTEGER NOT NULL DEFAULT 0,
+    battery_percent INTEGER NOT NULL DEFAULT 0,
+    fuel_percent INTEGER NOT NULL DEFAULT 0,
+    journey_id UUID NOT NULL,
+    trip_ids UUID [] NOT NULL DEFAULT '{}'
+);
+
+CREATE INDEX IF NOT EXISTS telemetry_vehicle_timestamp_idx
+ON telemetry (vehicle, timestamp DESC);
+
+CREATE INDEX IF NOT EXISTS telemetry_timestamp_idx
+ON telemetry (timestamp);
+
+-- +goose Down
+DROP TABLE IF EXISTS telemetry;
@@ -0,0 +1,65 @@
+-- +goose Up
+CREATE OR REPLACE VIEW vehicle_status AS
+SELECT
+    vehicle.id,
+    vehicle.provider,
+    vehicle.data_provider,
+    -- Vehicles that have never reported an event or telemetry should report
+    -- NULL rather than an object with all NULL fields.
+    CASE
+        WHEN last_event.id IS NULL THEN NULL
+        ELSE to_jsonb(last_event)
+    END AS last_event,
+    CASE
+        WHEN last_telemetry.id IS NULL THEN NULL
+        ELSE to_jsonb(last_telemetry)
+    END AS last_telemetry
+FROM vehicle AS vehicle
+LEFT JOIN LATERAL (
+    SELECT
+        event.id,
+        event.vehicle,
+        event.provider,
+        event.data_provider,
+        event.vehicle_state,
+        event.event_types,
+        event.timestamp,
+        event.publication_time,
+        event.location,
+        event.event_geographies,
+        event.battery_percent,
+        event.fuel_percent,
+        event.trip_ids,
+        event.associated_ticket
+    FROM event
+    WHERE event.vehicle = vehicle.id
+    ORDER BY event.timestamp DESC
+    LIMIT 1
+) AS last_event ON TRUE
+LEFT JOIN LATERAL (
+    SELECT
+        telemetry.id,
+        telemetry.vehicle,
+        telemetry.provider,
+        telemetry.data_provider,
+        telemetry.timestamp,
+        telemetry.lat,
+        telemetry.lng,
+        telemetry.altitude,
+        telemetry.heading,
+        telemetry.speed,
+        telemetry.horizontal_accuracy,
+        telemetry.vertical_accuracy,
+        telemetry.satellites,
+        telemetry.battery_percent,
+        telemetry.fuel_percent,
+        telemetry.journey_id,
+        telemetry.trip_ids
+    FROM telemetry
+    WHERE telemetry.vehicle = vehicle.id
+    ORDER BY telemetry.timestamp DESC
+    LIMIT 1
+) AS last_telemetry ON TRUE;
+
+-- +goose Down
+DROP VIEW IF EXISTS vehicle_status;
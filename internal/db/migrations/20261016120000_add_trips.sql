@@ -0,0 +1,34 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS trip (
+    id UUID PRIMARY KEY CHECK (
+        id != '00000000-0000-0000-0000-000000000000'
+    ),
+    vehicle UUID NOT NULL REFERENCES vehicle (id),
+    provider UUID NOT NULL CHECK (
+        provider != '00000000-0000-0000-0000-000000000000'
+    ),
+    data_provider UUID NOT NULL,
+    journey_id UUID NOT NULL,
+    start_time TIMESTAMPTZ NOT NULL,
+    end_time TIMESTAMPTZ NOT NULL CHECK (end_time >= start_time),
+    start_location JSONB NOT NULL CHECK (
+        jsonb_typeof(start_location) = 'object'
+    ),
+    end_location JSONB NOT NULL CHECK (jsonb_typeof(end_location) = 'object'),
+    duration INTEGER NOT NULL CHECK (duration >= 0),
+    distance INTEGER NOT NULL CHECK (distance >= 0),
+    fare JSONB CHECK (fare IS NULL OR jsonb_typeof(fare) = 'object'),
+    trip_attributes JSONB NOT NULL DEFAULT '{}' CHECK (
+        jsonb_typeof(trip_attributes) = 'object'
+    ),
+    publication_time TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS trip_vehicle_end_time_idx
+ON trip (vehicle, end_time DESC);
+
+CREATE INDEX IF NOT EXISTS trip_end_time_idx
+ON trip (end_time);
+
+-- +goose Down
+DROP TABLE IF EXISTS trip;
@@ -0,0 +1,36 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS stop (
+    id UUID PRIMARY KEY CHECK (
+        id != '00000000-0000-0000-0000-000000000000'
+    ),
+    name TEXT NOT NULL CHECK (name != ''),
+    last_reported TIMESTAMPTZ NOT NULL,
+    location JSONB NOT NULL CHECK (jsonb_typeof(location) = 'object'),
+    status JSONB NOT NULL CHECK (jsonb_typeof(status) = 'object'),
+    provider UUID NOT NULL,
+    data_provider UUID NOT NULL,
+    region_id TEXT NOT NULL DEFAULT '',
+    short_name TEXT NOT NULL DEFAULT '',
+    address TEXT NOT NULL DEFAULT '',
+    post_code TEXT NOT NULL DEFAULT '',
+    cross_street TEXT NOT NULL DEFAULT '',
+    rental_methods TEXT [] NOT NULL DEFAULT '{}',
+    num_vehicles_available JSONB NOT NULL DEFAULT '{}' CHECK (
+        jsonb_typeof(num_vehicles_available) = 'object'
+    ),
+    num_vehicles_disabled JSONB NOT NULL DEFAULT '{}' CHECK (
+        jsonb_typeof(num_vehicles_disabled) = 'object'
+    ),
+    num_places_available JSONB NOT NULL DEFAULT '{}' CHECK (
+        jsonb_typeof(num_places_available) = 'object'
+    ),
+    num_places_disabled JSONB NOT NULL DEFAULT '{}' CHECK (
+        jsonb_typeof(num_places_disabled) = 'object'
+    ),
+    parent_stop UUID REFERENCES stop (id),
+    devices UUID [] NOT NULL DEFAULT '{}',
+    wheelchair_boarding BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS stop;
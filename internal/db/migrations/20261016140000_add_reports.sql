@@ -0,0 +1,39 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS special_group_type (
+    name TEXT PRIMARY KEY CHECK (name != '')
+);
+
+INSERT INTO
+special_group_type (name)
+VALUES
+('all_riders'),
+('low_income'),
+('adaptive_scooter')
+ON CONFLICT DO NOTHING;
+
+CREATE TABLE IF NOT EXISTS report (
+    provider UUID NOT NULL CHECK (
+        provider != '00000000-0000-0000-0000-000000000000'
+    ),
+    start_date TIMESTAMPTZ NOT NULL,
+    duration TEXT NOT NULL CHECK (duration = 'P1M'),
+    special_group_type TEXT NOT NULL REFERENCES special_group_type (
+        name
+    ) ON UPDATE CASCADE,
+    geography_id UUID NOT NULL,
+    vehicle_type TEXT NOT NULL REFERENCES vehicle_type (name) ON UPDATE CASCADE,
+    trip_count INTEGER NOT NULL CHECK (trip_count >= -1),
+    rider_count INTEGER NOT NULL CHECK (rider_count >= -1),
+    PRIMARY KEY (
+        provider,
+        start_date,
+        duration,
+        special_group_type,
+        geography_id,
+        vehicle_type
+    )
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS report;
+DROP TABLE IF EXISTS special_group_type;
@@ -0,0 +1,31 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS policy (
+    id UUID PRIMARY KEY CHECK (
+        id != '00000000-0000-0000-0000-000000000000'
+    ),
+    name TEXT NOT NULL CHECK (name != ''),
+    description TEXT NOT NULL DEFAULT '',
+    provider_ids UUID [] NOT NULL DEFAULT '{}',
+    currency TEXT NOT NULL DEFAULT '',
+    start_date TIMESTAMPTZ NOT NULL,
+    end_date TIMESTAMPTZ CHECK (end_date > start_date),
+    published_date TIMESTAMPTZ,
+    prev_policies UUID [] NOT NULL DEFAULT '{}',
+    rules JSONB NOT NULL CHECK (jsonb_typeof(rules) = 'array')
+);
+
+CREATE INDEX IF NOT EXISTS policy_prev_policies_idx ON policy USING gin (
+    prev_policies
+);
+
+-- There is only ever a single requirements document, so the primary key is a constant.
+CREATE TABLE IF NOT EXISTS requirements (
+    id BOOLEAN PRIMARY KEY DEFAULT TRUE CHECK (id),
+    last_updated TIMESTAMPTZ NOT NULL,
+    metadata JSONB NOT NULL CHECK (jsonb_typeof(metadata) = 'object'),
+    requirements JSONB NOT NULL CHECK (jsonb_typeof(requirements) = 'array')
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS requirements;
+DROP TABLE IF EXISTS policy;
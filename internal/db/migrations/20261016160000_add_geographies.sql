@@ -0,0 +1,19 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS geography (
+    id UUID PRIMARY KEY CHECK (
+        id != '00000000-0000-0000-0000-000000000000'
+    ),
+    name TEXT NOT NULL CHECK (name != ''),
+    description TEXT NOT NULL DEFAULT '',
+    geography_type TEXT NOT NULL DEFAULT '',
+    geography_json JSONB NOT NULL CHECK (
+        geography_json ->> 'type' = 'FeatureCollection'
+    ),
+    effective_date TIMESTAMPTZ,
+    published_date TIMESTAMPTZ NOT NULL,
+    retire_date TIMESTAMPTZ CHECK (retire_date > effective_date),
+    prev_geographies UUID [] NOT NULL DEFAULT '{}'
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS geography;
@@ -0,0 +1,22 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS jurisdiction (
+    id UUID PRIMARY KEY CHECK (
+        id != '00000000-0000-0000-0000-000000000000'
+    )
+);
+
+-- Every change to a jurisdiction is recorded as a new version so that the jurisdictions in
+-- effect at any point in time can be reconstructed.
+CREATE TABLE IF NOT EXISTS jurisdiction_version (
+    jurisdiction UUID NOT NULL REFERENCES jurisdiction (id),
+    timestamp TIMESTAMPTZ NOT NULL,
+    agency_key TEXT NOT NULL CHECK (agency_key != ''),
+    agency_name TEXT NOT NULL CHECK (agency_name != ''),
+    geography_id UUID NOT NULL REFERENCES geography (id),
+    prev_jurisdictions UUID [] NOT NULL DEFAULT '{}',
+    PRIMARY KEY (jurisdiction, timestamp)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS jurisdiction_version;
+DROP TABLE IF EXISTS jurisdiction;
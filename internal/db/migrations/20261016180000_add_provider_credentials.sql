@@ -0,0 +1,15 @@
+-- +goose Up
+-- Client credentials used by providers to obtain access tokens from the built-in token endpoint.
+CREATE TABLE IF NOT EXISTS provider_credentials (
+    client_id TEXT PRIMARY KEY CHECK (client_id != ''),
+    secret_hash BYTEA NOT NULL,
+    provider_id UUID NOT NULL CHECK (
+        provider_id != '00000000-0000-0000-0000-000000000000'
+    ),
+    scopes TEXT [] NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    revoked_at TIMESTAMPTZ
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS provider_credentials;
@@ -0,0 +1,29 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS provider_status (
+    name TEXT PRIMARY KEY CHECK (name != '')
+);
+
+INSERT INTO
+provider_status (name)
+VALUES
+('active'),
+('suspended')
+ON CONFLICT DO NOTHING;
+
+-- Registered providers. Data already submitted for a provider isn't tied to this table, so that
+-- existing data is kept when a provider is offboarded.
+CREATE TABLE IF NOT EXISTS provider (
+    id UUID PRIMARY KEY CHECK (
+        id != '00000000-0000-0000-0000-000000000000'
+    ),
+    name TEXT NOT NULL CHECK (name != ''),
+    url TEXT NOT NULL DEFAULT '',
+    contact TEXT NOT NULL DEFAULT '',
+    status TEXT NOT NULL REFERENCES provider_status (name) ON UPDATE CASCADE,
+    vehicle_types TEXT [] NOT NULL DEFAULT '{}',
+    data_providers UUID [] NOT NULL DEFAULT '{}'
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS provider;
+DROP TABLE IF EXISTS provider_status;
@@ -1,6 +1,8 @@
 package db
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 	"github.com/technopolitica/open-transit/internal/domain"
 )
@@ -18,3 +20,180 @@ type VehicleDTO struct {
 	FuelCapacity            int32         `db:"fuel_capacity"`
 	MaximumSpeed            int32         `db:"maximum_speed"`
 }
+
+type EventDTO struct {
+	ID               uuid.UUID   `db:"id" json:"id"`
+	Vehicle          uuid.UUID   `db:"vehicle" json:"vehicle"`
+	Provider         uuid.UUID   `db:"provider" json:"provider"`
+	DataProvider     uuid.UUID   `db:"data_provider" json:"data_provider"`
+	VehicleState     string      `db:"vehicle_state" json:"vehicle_state"`
+	EventTypes       []string    `db:"event_types" json:"event_types"`
+	Timestamp        time.Time   `db:"timestamp" json:"timestamp"`
+	PublicationTime  *time.Time  `db:"publication_time" json:"publication_time"`
+	Location         domain.GPS  `db:"location" json:"location"`
+	EventGeographies []uuid.UUID `db:"event_geographies" json:"event_geographies"`
+	BatteryPercent   int32       `db:"battery_percent" json:"battery_percent"`
+	FuelPercent      int32       `db:"fuel_percent" json:"fuel_percent"`
+	TripIDs          []uuid.UUID `db:"trip_ids" json:"trip_ids"`
+	AssociatedTicket string      `db:"associated_ticket" json:"associated_ticket"`
+}
+
+type TelemetryDTO struct {
+	ID                 uuid.UUID   `db:"id" json:"id"`
+	Vehicle            uuid.UUID   `db:"vehicle" json:"vehicle"`
+	Provider           uuid.UUID   `db:"provider" json:"provider"`
+	DataProvider       uuid.UUID   `db:"data_provider" json:"data_provider"`
+	Timestamp          time.Time   `db:"timestamp" json:"timestamp"`
+	Lat                float64     `db:"lat" json:"lat"`
+	Lng                float64     `db:"lng" json:"lng"`
+	Altitude           float64     `db:"altitude" json:"altitude"`
+	Heading            float64     `db:"heading" json:"heading"`
+	Speed              float64     `db:"speed" json:"speed"`
+	HorizontalAccuracy float64     `db:"horizontal_accuracy" json:"horizontal_accuracy"`
+	VerticalAccuracy   float64     `db:"vertical_accuracy" json:"vertical_accuracy"`
+	Satellites         int32       `db:"satellites" json:"satellites"`
+	BatteryPercent     int32       `db:"battery_percent" json:"battery_percent"`
+	FuelPercent        int32       `db:"fuel_percent" json:"fuel_percent"`
+	JourneyID          uuid.UUID   `db:"journey_id" json:"journey_id"`
+	TripIDs            []uuid.UUID `db:"trip_ids" json:"trip_ids"`
+}
+
+type TripDTO struct {
+	ID              uuid.UUID     `db:"id"`
+	Vehicle         uuid.UUID     `db:"vehicle"`
+	Provider        uuid.UUID     `db:"provider"`
+	DataProvider    uuid.UUID     `db:"data_provider"`
+	JourneyID       uuid.UUID     `db:"journey_id"`
+	StartTime       time.Time     `db:"start_time"`
+	EndTime         time.Time     `db:"end_time"`
+	StartLocation   domain.GPS    `db:"start_location"`
+	EndLocation     domain.GPS    `db:"end_location"`
+	Duration        int32         `db:"duration"`
+	Distance        int32         `db:"distance"`
+	Fare            *domain.Fare  `db:"fare"`
+	TripAttributes  domain.Record `db:"trip_attributes"`
+	PublicationTime *time.Time    `db:"publication_time"`
+}
+
+type StopDTO struct {
+	ID                   uuid.UUID                `db:"id"`
+	Name                 string                   `db:"name"`
+	LastReported         time.Time                `db:"last_reported"`
+	Location             domain.GPS               `db:"location"`
+	Status               domain.StopStatus        `db:"status"`
+	Provider             uuid.UUID                `db:"provider"`
+	DataProvider         uuid.UUID                `db:"data_provider"`
+	RegionID             string                   `db:"region_id"`
+	ShortName            string                   `db:"short_name"`
+	Address              string                   `db:"address"`
+	PostCode             string                   `db:"post_code"`
+	CrossStreet          string                   `db:"cross_street"`
+	RentalMethods        []string                 `db:"rental_methods"`
+	NumVehiclesAvailable domain.VehicleTypeCounts `db:"num_vehicles_available"`
+	NumVehiclesDisabled  domain.VehicleTypeCounts `db:"num_vehicles_disabled"`
+	NumPlacesAvailable   domain.VehicleTypeCounts `db:"num_places_available"`
+	NumPlacesDisabled    domain.VehicleTypeCounts `db:"num_places_disabled"`
+	ParentStop           *uuid.UUID               `db:"parent_stop"`
+	Devices              []uuid.UUID              `db:"devices"`
+	WheelchairBoarding   bool                     `db:"wheelchair_boarding"`
+}
+
+type ReportDTO struct {
+	Provider         uuid.UUID `db:"provider"`
+	StartDate        time.Time `db:"start_date"`
+	Duration         string    `db:"duration"`
+	SpecialGroupType string    `db:"special_group_type"`
+	GeographyID      uuid.UUID `db:"geography_id"`
+	VehicleType      string    `db:"vehicle_type"`
+	TripCount        int32     `db:"trip_count"`
+	RiderCount       int32     `db:"rider_count"`
+}
+
+type PolicyDTO struct {
+	ID            uuid.UUID     `db:"id"`
+	Name          string        `db:"name"`
+	Description   string        `db:"description"`
+	ProviderIDs   []uuid.UUID   `db:"provider_ids"`
+	Currency      string        `db:"currency"`
+	StartDate     time.Time     `db:"start_date"`
+	EndDate       *time.Time    `db:"end_date"`
+	PublishedDate *time.Time    `db:"published_date"`
+	PrevPolicies  []uuid.UUID   `db:"prev_policies"`
+	Rules         []domain.Rule `db:"rules"`
+}
+
+type GeographyDTO struct {
+	ID              uuid.UUID                `db:"id"`
+	Name            string                   `db:"name"`
+	Description     string                   `db:"description"`
+	GeographyType   string                   `db:"geography_type"`
+	GeographyJSON   domain.FeatureCollection `db:"geography_json"`
+	EffectiveDate   *time.Time               `db:"effective_date"`
+	PublishedDate   time.Time                `db:"published_date"`
+	RetireDate      *time.Time               `db:"retire_date"`
+	PrevGeographies []uuid.UUID              `db:"prev_geographies"`
+}
+
+type JurisdictionVersionDTO struct {
+	Jurisdiction      uuid.UUID   `db:"jurisdiction"`
+	Timestamp         time.Time   `db:"timestamp"`
+	AgencyKey         string      `db:"agency_key"`
+	AgencyName        string      `db:"agency_name"`
+	GeographyID       uuid.UUID   `db:"geography_id"`
+	PrevJurisdictions []uuid.UUID `db:"prev_jurisdictions"`
+}
+
+type RequirementsDTO struct {
+	LastUpdated  time.Time       `db:"last_updated"`
+	Metadata     domain.Record   `db:"metadata"`
+	Requirements []domain.Record `db:"requirements"`
+}
+
+// VehicleStatusDTO embeds the vehicle's most recent event and telemetry as JSON objects
+// whose keys are the column names of the event and telemetry tables respectively.
+type VehicleStatusDTO struct {
+	ID            uuid.UUID     `db:"id"`
+	Provider      uuid.UUID     `db:"provider"`
+	DataProvider  uuid.UUID     `db:"data_provider"`
+	LastEvent     *EventDTO     `db:"last_event"`
+	LastTelemetry *TelemetryDTO `db:"last_telemetry"`
+}
+
+type TripFactDTO struct {
+	TripID       uuid.UUID   `db:"trip_id"`
+	Provider     uuid.UUID   `db:"provider"`
+	VehicleType  string      `db:"vehicle_type"`
+	StartTime    time.Time   `db:"start_time"`
+	Duration     int32       `db:"duration"`
+	Distance     int32       `db:"distance"`
+	GeographyIDs []uuid.UUID `db:"geography_ids"`
+}
+
+type VehicleEventFactDTO struct {
+	DeviceID     uuid.UUID   `db:"device_id"`
+	Provider     uuid.UUID   `db:"provider"`
+	VehicleType  string      `db:"vehicle_type"`
+	Timestamp    time.Time   `db:"timestamp"`
+	VehicleState string      `db:"vehicle_state"`
+	EventTypes   []string    `db:"event_types"`
+	GeographyIDs []uuid.UUID `db:"geography_ids"`
+}
+
+type ProviderCredentialDTO struct {
+	ClientID   string     `db:"client_id"`
+	SecretHash []byte     `db:"secret_hash"`
+	ProviderID uuid.UUID  `db:"provider_id"`
+	Scopes     []string   `db:"scopes"`
+	CreatedAt  time.Time  `db:"created_at"`
+	RevokedAt  *time.Time `db:"revoked_at"`
+}
+
+type ProviderDTO struct {
+	ID            uuid.UUID   `db:"id"`
+	Name          string      `db:"name"`
+	URL           string      `db:"url"`
+	Contact       string      `db:"contact"`
+	Status        string      `db:"status"`
+	VehicleTypes  []string    `db:"vehicle_types"`
+	DataProviders []uuid.UUID `db:"data_providers"`
+}
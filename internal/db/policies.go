@@ -0,0 +1,196 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	_ "embed"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/technopolitica/open-transit/internal/domain"
+)
+
+func dtoFromPolicy(domainPolicy domain.Policy) PolicyDTO {
+	return PolicyDTO{
+		ID:            domainPolicy.PolicyID,
+		Name:          domainPolicy.Name,
+		Description:   domainPolicy.Description,
+		ProviderIDs:   nonNil(domainPolicy.ProviderIDs),
+		Currency:      domainPolicy.Currency,
+		StartDate:     domainPolicy.StartDate.Time,
+		EndDate:       timeFromTimestamp(domainPolicy.EndDate),
+		PublishedDate: timeFromTimestamp(domainPolicy.PublishedDate),
+		PrevPolicies:  nonNil(domainPolicy.PrevPolicies),
+		Rules:         nonNil(domainPolicy.Rules),
+	}
+}
+
+func policyFromDTO(policy PolicyDTO) domain.Policy {
+	return domain.Policy{
+		PolicyID:      policy.ID,
+		Name:          policy.Name,
+		Description:   policy.Description,
+		ProviderIDs:   nilIfEmpty(policy.ProviderIDs),
+		Currency:      policy.Currency,
+		StartDate:     domain.NewTimestamp(policy.StartDate),
+		EndDate:       timestampFromTime(policy.EndDate),
+		PublishedDate: timestampFromTime(policy.PublishedDate),
+		PrevPolicies:  nilIfEmpty(policy.PrevPolicies),
+		Rules:         policy.Rules,
+	}
+}
+
+func policyNamedArgs(policyDTO PolicyDTO) pgx.NamedArgs {
+	return pgx.NamedArgs{
+		"id":            policyDTO.ID,
+		"name":          policyDTO.Name,
+		"description":   policyDTO.Description,
+		"provider_ids":  policyDTO.ProviderIDs,
+		"currency":      policyDTO.Currency,
+		"start_date":    policyDTO.StartDate,
+		"end_date":      policyDTO.EndDate,
+		"prev_policies": policyDTO.PrevPolicies,
+		"rules":         policyDTO.Rules,
+	}
+}
+
+//go:embed queries/list-policies.sql
+var listPoliciesQuery string
+
+func (repo Repository) ListPolicies(ctx context.Context, params domain.ListPoliciesParams) (policies []domain.Policy, err error) {
+	rows, err := repo.Query(ctx, listPoliciesQuery, pgx.NamedArgs{"start_date": params.Start, "end_date": params.End})
+	if err != nil {
+		err = fmt.Errorf("failed to execute query: %w", err)
+		return
+	}
+
+	policyDTOs, err := pgx.CollectRows(rows, pgx.RowToStructByName[PolicyDTO])
+	if err != nil {
+		err = fmt.Errorf("failed to map row to PolicyDTO: %w", err)
+		return
+	}
+	policies = make([]domain.Policy, 0, len(policyDTOs))
+	for _, dto := range policyDTOs {
+		policies = append(policies, policyFromDTO(dto))
+	}
+	return
+}
+
+//go:embed queries/fetch-policy.sql
+var fetchPolicyQuery string
+
+func (repo Repository) FetchPolicy(ctx context.Context, policyID uuid.UUID) (policy domain.Policy, err error) {
+	rows, err := repo.Query(ctx, fetchPolicyQuery, pgx.NamedArgs{"id": policyID})
+	if err != nil {
+		err = fmt.Errorf("failed to execute query: %w", err)
+		return
+	}
+
+	policyDTOs, err := pgx.CollectRows(rows, pgx.RowToStructByName[PolicyDTO])
+	if err != nil {
+		err = fmt.Errorf("failed to map row to PolicyDTO: %w", err)
+		return
+	}
+	if len(policyDTOs) == 0 {
+		err = ErrNotFound
+		return
+	}
+
+	policy = policyFromDTO(policyDTOs[0])
+	return
+}
+
+//go:embed queries/insert-policy.sql
+var insertPolicyQuery string
+
+func (repo Repository) InsertPolicy(ctx context.Context, policy domain.Policy) error {
+	_, err := repo.Exec(ctx, insertPolicyQuery, policyNamedArgs(dtoFromPolicy(policy)))
+
+	var pgErr *pgconn.PgError
+	if err != nil && errors.As(err, &pgErr) && pgErr.ConstraintName == "policy_pkey" && pgErr.Code == pgerrcode.UniqueViolation {
+		return ErrConflict
+	}
+
+	return err
+}
+
+// policyNotModified determines why an update to a policy didn't affect any rows.
+func (repo Repository) policyNotModified(ctx context.Context, policyID uuid.UUID) error {
+	_, err := repo.FetchPolicy(ctx, policyID)
+	if err != nil {
+		return err
+	}
+	return ErrImmutable
+}
+
+//go:embed queries/update-policy.sql
+var updatePolicyQuery string
+
+func (repo Repository) UpdatePolicy(ctx context.Context, policy domain.Policy) error {
+	res, err := repo.Exec(ctx, updatePolicyQuery, policyNamedArgs(dtoFromPolicy(policy)))
+
+	if err == nil && res.RowsAffected() == 0 {
+		return repo.policyNotModified(ctx, policy.PolicyID)
+	}
+
+	return err
+}
+
+//go:embed queries/publish-policy.sql
+var publishPolicyQuery string
+
+func (repo Repository) PublishPolicy(ctx context.Context, policyID uuid.UUID, publishedDate time.Time) error {
+	res, err := repo.Exec(ctx, publishPolicyQuery, pgx.NamedArgs{"id": policyID, "published_date": publishedDate})
+
+	if err == nil && res.RowsAffected() == 0 {
+		return repo.policyNotModified(ctx, policyID)
+	}
+
+	return err
+}
+
+//go:embed queries/fetch-requirements.sql
+var fetchRequirementsQuery string
+
+func (repo Repository) FetchRequirements(ctx context.Context) (requirements domain.Requirements, err error) {
+	rows, err := repo.Query(ctx, fetchRequirementsQuery)
+	if err != nil {
+		err = fmt.Errorf("failed to execute query: %w", err)
+		return
+	}
+
+	requirementsDTOs, err := pgx.CollectRows(rows, pgx.RowToStructByName[RequirementsDTO])
+	if err != nil {
+		err = fmt.Errorf("failed to map row to RequirementsDTO: %w", err)
+		return
+	}
+	if len(requirementsDTOs) == 0 {
+		err = ErrNotFound
+		return
+	}
+
+	dto := requirementsDTOs[0]
+	requirements = domain.Requirements{
+		LastUpdated:  domain.NewTimestamp(dto.LastUpdated),
+		Metadata:     dto.Metadata,
+		Requirements: dto.Requirements,
+	}
+	return
+}
+
+//go:embed queries/upsert-requirements.sql
+var upsertRequirementsQuery string
+
+func (repo Repository) UpsertRequirements(ctx context.Context, requirements domain.Requirements) error {
+	_, err := repo.Exec(ctx, upsertRequirementsQuery, pgx.NamedArgs{
+		"last_updated": requirements.LastUpdated.Time,
+		"metadata":     requirements.Metadata,
+		"requirements": nonNil(requirements.Requirements),
+	})
+	return err
+}
@@ -0,0 +1,155 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	_ "embed"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/technopolitica/open-transit/internal/domain"
+)
+
+func dtoFromProvider(domainProvider domain.Provider) ProviderDTO {
+	return ProviderDTO{
+		ID:            domainProvider.ProviderID,
+		Name:          domainProvider.ProviderName,
+		URL:           domainProvider.URL,
+		Contact:       domainProvider.Contact,
+		Status:        domainProvider.Status.String(),
+		VehicleTypes:  domain.Stringify(domainProvider.VehicleTypes),
+		DataProviders: nonNil(domainProvider.DataProviders),
+	}
+}
+
+func providerFromDTO(provider ProviderDTO) (domain.Provider, error) {
+	// The zero ProviderStatus is active, so an unknown status must not be ignored.
+	status, err := domain.ParseProviderStatus(provider.Status)
+	if err != nil {
+		return domain.Provider{}, fmt.Errorf("invalid stored provider %s: %w", provider.ID, err)
+	}
+	vehicleTypes := make([]domain.VehicleType, 0, len(provider.VehicleTypes))
+	for _, vt := range provider.VehicleTypes {
+		vtParsed, err := domain.ParseVehicleType(vt)
+		if err != nil {
+			return domain.Provider{}, fmt.Errorf("invalid stored provider %s: %w", provider.ID, err)
+		}
+		vehicleTypes = append(vehicleTypes, vtParsed)
+	}
+	return domain.Provider{
+		ProviderID:    provider.ID,
+		ProviderName:  provider.Name,
+		URL:           provider.URL,
+		Contact:       provider.Contact,
+		Status:        status,
+		VehicleTypes:  domain.NewSet(vehicleTypes...),
+		DataProviders: provider.DataProviders,
+	}, nil
+}
+
+func providerNamedArgs(providerDTO ProviderDTO) pgx.NamedArgs {
+	return pgx.NamedArgs{
+		"id":             providerDTO.ID,
+		"name":           providerDTO.Name,
+		"url":            providerDTO.URL,
+		"contact":        providerDTO.Contact,
+		"status":         providerDTO.Status,
+		"vehicle_types":  providerDTO.VehicleTypes,
+		"data_providers": providerDTO.DataProviders,
+	}
+}
+
+//go:embed queries/list-providers.sql
+var listProvidersQuery string
+
+func (repo Repository) ListProviders(ctx context.Context) (providers []domain.Provider, err error) {
+	rows, err := repo.Query(ctx, listProvidersQuery)
+	if err != nil {
+		err = fmt.Errorf("failed to execute query: %w", err)
+		return
+	}
+
+	providerDTOs, err := pgx.CollectRows(rows, pgx.RowToStructByName[ProviderDTO])
+	if err != nil {
+		err = fmt.Errorf("failed to map row to ProviderDTO: %w", err)
+		return
+	}
+	providers = make([]domain.Provider, 0, len(providerDTOs))
+	for _, dto := range providerDTOs {
+		var provider domain.Provider
+		provider, err = providerFromDTO(dto)
+		if err != nil {
+			return
+		}
+		providers = append(providers, provider)
+	}
+	return
+}
+
+//go:embed queries/fetch-provider.sql
+var fetchProviderQuery string
+
+func (repo Repository) FetchProvider(ctx context.Context, providerID uuid.UUID) (provider domain.Provider, err error) {
+	rows, err := repo.Query(ctx, fetchProviderQuery, pgx.NamedArgs{"id": providerID})
+	if err != nil {
+		err = fmt.Errorf("failed to execute query: %w", err)
+		return
+	}
+
+	providerDTOs, err := pgx.CollectRows(rows, pgx.RowToStructByName[ProviderDTO])
+	if err != nil {
+		err = fmt.Errorf("failed to map row to ProviderDTO: %w", err)
+		return
+	}
+	if len(providerDTOs) == 0 {
+		err = ErrNotFound
+		return
+	}
+
+	provider, err = providerFromDTO(providerDTOs[0])
+	return
+}
+
+//go:embed queries/insert-provider.sql
+var insertProviderQuery string
+
+func (repo Repository) InsertProvider(ctx context.Context, provider domain.Provider) error {
+	_, err := repo.Exec(ctx, insertProviderQuery, providerNamedArgs(dtoFromProvider(provider)))
+
+	var pgErr *pgconn.PgError
+	if err != nil && errors.As(err, &pgErr) && pgErr.ConstraintName == "provider_pkey" && pgErr.Code == pgerrcode.UniqueViolation {
+		return ErrConflict
+	}
+
+	return err
+}
+
+//go:embed queries/update-provider.sql
+var updateProviderQuery string
+
+func (repo Repository) UpdateProvider(ctx context.Context, provider domain.Provider) error {
+	res, err := repo.Exec(ctx, updateProviderQuery, providerNamedArgs(dtoFromProvider(provider)))
+
+	if err == nil && res.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return err
+}
+
+//go:embed queries/delete-provider.sql
+var deleteProviderQuery string
+
+func (repo Repository) DeleteProvider(ctx context.Context, providerID uuid.UUID) error {
+	res, err := repo.Exec(ctx, deleteProviderQuery, pgx.NamedArgs{"id": providerID})
+
+	if err == nil && res.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return err
+}
@@ -1,3 +1,3 @@
 SELECT COUNT(*)
 FROM vehicle_denormalized
-WHERE provider = @provider;
+WHERE (@provider::UUID IS NULL OR provider = @provider);
@@ -0,0 +1,2 @@
+DELETE FROM provider
+WHERE id = @id;
@@ -0,0 +1,17 @@
+SELECT
+    id,
+    vehicle,
+    provider,
+    data_provider,
+    vehicle_state,
+    event_types,
+    timestamp,
+    publication_time,
+    location,
+    event_geographies,
+    battery_percent,
+    fuel_percent,
+    trip_ids,
+    associated_ticket
+FROM event
+WHERE id = @id;
@@ -0,0 +1,11 @@
+SELECT
+    jurisdiction,
+    timestamp,
+    agency_key,
+    agency_name,
+    geography_id,
+    prev_jurisdictions
+FROM jurisdiction_version
+WHERE jurisdiction = @jurisdiction AND timestamp <= @effective
+ORDER BY timestamp DESC
+LIMIT 1;
@@ -0,0 +1,13 @@
+SELECT
+    id,
+    name,
+    description,
+    provider_ids,
+    currency,
+    start_date,
+    end_date,
+    published_date,
+    prev_policies,
+    rules
+FROM policy
+WHERE id = @id;
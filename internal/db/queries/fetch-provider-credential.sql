@@ -0,0 +1,9 @@
+SELECT
+    client_id,
+    secret_hash,
+    provider_id,
+    scopes,
+    created_at,
+    revoked_at
+FROM provider_credentials
+WHERE client_id = @client_id;
@@ -0,0 +1,10 @@
+SELECT
+    id,
+    name,
+    url,
+    contact,
+    status,
+    vehicle_types,
+    data_providers
+FROM provider
+WHERE id = @id;
@@ -0,0 +1,5 @@
+SELECT
+    last_updated,
+    metadata,
+    requirements
+FROM requirements;
@@ -0,0 +1,23 @@
+SELECT
+    id,
+    name,
+    last_reported,
+    location,
+    status,
+    provider,
+    data_provider,
+    region_id,
+    short_name,
+    address,
+    post_code,
+    cross_street,
+    rental_methods,
+    num_vehicles_available,
+    num_vehicles_disabled,
+    num_places_available,
+    num_places_disabled,
+    parent_stop,
+    devices,
+    wheelchair_boarding
+FROM stop
+WHERE id = @id;
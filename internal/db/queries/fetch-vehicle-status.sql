@@ -0,0 +1,8 @@
+SELECT
+    id,
+    provider,
+    data_provider,
+    last_event,
+    last_telemetry
+FROM vehicle_status
+WHERE id = @id AND (@provider::UUID IS NULL OR provider = @provider);
@@ -11,4 +11,4 @@ SELECT
     maximum_speed,
     propulsion_types
 FROM vehicle_denormalized
-WHERE id = @id AND provider = @provider;
+WHERE id = @id AND (@provider::UUID IS NULL OR provider = @provider);
@@ -0,0 +1,31 @@
+INSERT INTO event (
+    id,
+    vehicle,
+    provider,
+    data_provider,
+    vehicle_state,
+    event_types,
+    timestamp,
+    publication_time,
+    location,
+    event_geographies,
+    battery_percent,
+    fuel_percent,
+    trip_ids,
+    associated_ticket
+) VALUES (
+    @id,
+    @vehicle,
+    @provider,
+    @data_provider,
+    @vehicle_state,
+    @event_types,
+    @timestamp,
+    @publication_time,
+    @location,
+    @event_geographies,
+    @battery_percent,
+    @fuel_percent,
+    @trip_ids,
+    @associated_ticket
+);
@@ -0,0 +1,21 @@
+INSERT INTO geography (
+    id,
+    name,
+    description,
+    geography_type,
+    geography_json,
+    effective_date,
+    published_date,
+    retire_date,
+    prev_geographies
+) VALUES (
+    @id,
+    @name,
+    @description,
+    @geography_type,
+    @geography_json,
+    @effective_date,
+    @published_date,
+    @retire_date,
+    @prev_geographies
+);
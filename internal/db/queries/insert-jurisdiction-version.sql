@@ -0,0 +1,15 @@
+INSERT INTO jurisdiction_version (
+    jurisdiction,
+    timestamp,
+    agency_key,
+    agency_name,
+    geography_id,
+    prev_jurisdictions
+) VALUES (
+    @jurisdiction,
+    @timestamp,
+    @agency_key,
+    @agency_name,
+    @geography_id,
+    @prev_jurisdictions
+);
@@ -0,0 +1 @@
+INSERT INTO jurisdiction (id) VALUES (@jurisdiction);
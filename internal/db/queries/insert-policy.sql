@@ -0,0 +1,21 @@
+INSERT INTO policy (
+    id,
+    name,
+    description,
+    provider_ids,
+    currency,
+    start_date,
+    end_date,
+    prev_policies,
+    rules
+) VALUES (
+    @id,
+    @name,
+    @description,
+    @provider_ids,
+    @currency,
+    @start_date,
+    @end_date,
+    @prev_policies,
+    @rules
+);
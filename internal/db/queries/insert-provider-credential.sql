@@ -0,0 +1,13 @@
+INSERT INTO provider_credentials (
+    client_id,
+    secret_hash,
+    provider_id,
+    scopes,
+    created_at
+) VALUES (
+    @client_id,
+    @secret_hash,
+    @provider_id,
+    @scopes,
+    @created_at
+);
@@ -0,0 +1,17 @@
+INSERT INTO provider (
+    id,
+    name,
+    url,
+    contact,
+    status,
+    vehicle_types,
+    data_providers
+) VALUES (
+    @id,
+    @name,
+    @url,
+    @contact,
+    @status,
+    @vehicle_types,
+    @data_providers
+);
@@ -0,0 +1,19 @@
+INSERT INTO report (
+    provider,
+    start_date,
+    duration,
+    special_group_type,
+    geography_id,
+    vehicle_type,
+    trip_count,
+    rider_count
+) VALUES (
+    @provider,
+    @start_date,
+    @duration,
+    @special_group_type,
+    @geography_id,
+    @vehicle_type,
+    @trip_count,
+    @rider_count
+);
@@ -0,0 +1,37 @@
+INSERT INTO telemetry (
+    id,
+    vehicle,
+    provider,
+    data_provider,
+    timestamp,
+    lat,
+    lng,
+    altitude,
+    heading,
+    speed,
+    horizontal_accuracy,
+    vertical_accuracy,
+    satellites,
+    battery_percent,
+    fuel_percent,
+    journey_id,
+    trip_ids
+) VALUES (
+    @id,
+    @vehicle,
+    @provider,
+    @data_provider,
+    @timestamp,
+    @lat,
+    @lng,
+    @altitude,
+    @heading,
+    @speed,
+    @horizontal_accuracy,
+    @vertical_accuracy,
+    @satellites,
+    @battery_percent,
+    @fuel_percent,
+    @journey_id,
+    @trip_ids
+);
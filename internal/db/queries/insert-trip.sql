@@ -0,0 +1,31 @@
+INSERT INTO trip (
+    id,
+    vehicle,
+    provider,
+    data_provider,
+    journey_id,
+    start_time,
+    end_time,
+    start_location,
+    end_location,
+    duration,
+    distance,
+    fare,
+    trip_attributes,
+    publication_time
+) VALUES (
+    @id,
+    @vehicle,
+    @provider,
+    @data_provider,
+    @journey_id,
+    @start_time,
+    @end_time,
+    @start_location,
+    @end_location,
+    @duration,
+    @distance,
+    @fare,
+    @trip_attributes,
+    @publication_time
+);
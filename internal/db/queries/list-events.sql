@@ -0,0 +1,21 @@
+SELECT
+    id,
+    vehicle,
+    provider,
+    data_provider,
+    vehicle_state,
+    event_types,
+    timestamp,
+    publication_time,
+    location,
+    event_geographies,
+    battery_percent,
+    fuel_percent,
+    trip_ids,
+    associated_ticket
+FROM event
+WHERE
+    (@provider::UUID IS NULL OR provider = @provider)
+    AND timestamp >= @from_time
+    AND timestamp < @to_time
+ORDER BY timestamp, id;
@@ -0,0 +1,12 @@
+SELECT
+    id,
+    name,
+    description,
+    geography_type,
+    geography_json,
+    effective_date,
+    published_date,
+    retire_date,
+    prev_geographies
+FROM geography
+ORDER BY published_date, id;
@@ -0,0 +1,10 @@
+SELECT DISTINCT ON (jurisdiction)
+    jurisdiction,
+    timestamp,
+    agency_key,
+    agency_name,
+    geography_id,
+    prev_jurisdictions
+FROM jurisdiction_version
+WHERE timestamp <= @effective
+ORDER BY jurisdiction, timestamp DESC;
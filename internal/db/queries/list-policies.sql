@@ -0,0 +1,28 @@
+-- A published policy stops being in effect at its end_date, or when a published policy that
+-- lists it in prev_policies starts, whichever comes first.
+SELECT
+    policy.id,
+    policy.name,
+    policy.description,
+    policy.provider_ids,
+    policy.currency,
+    policy.start_date,
+    policy.end_date,
+    policy.published_date,
+    policy.prev_policies,
+    policy.rules
+FROM policy
+LEFT JOIN LATERAL (
+    SELECT min(successor.start_date) AS superseded_date
+    FROM policy AS successor
+    WHERE
+        successor.published_date IS NOT NULL
+        AND policy.id = any(successor.prev_policies)
+) AS supersession ON TRUE
+WHERE
+    policy.published_date IS NOT NULL
+    AND (@end_date::TIMESTAMPTZ IS NULL OR policy.start_date < @end_date)
+    AND coalesce(
+        least(policy.end_date, supersession.superseded_date), 'infinity'
+    ) > @start_date
+ORDER BY policy.start_date, policy.id;
@@ -0,0 +1,12 @@
+SELECT
+    provider,
+    start_date,
+    duration,
+    special_group_type,
+    geography_id,
+    vehicle_type,
+    trip_count,
+    rider_count
+FROM report
+WHERE (@provider::UUID IS NULL OR provider = @provider)
+ORDER BY start_date, special_group_type, geography_id, vehicle_type;
@@ -0,0 +1,24 @@
+SELECT
+    id,
+    vehicle,
+    provider,
+    data_provider,
+    timestamp,
+    lat,
+    lng,
+    altitude,
+    heading,
+    speed,
+    horizontal_accuracy,
+    vertical_accuracy,
+    satellites,
+    battery_percent,
+    fuel_percent,
+    journey_id,
+    trip_ids
+FROM telemetry
+WHERE
+    (@provider::UUID IS NULL OR provider = @provider)
+    AND timestamp >= @from_time
+    AND timestamp < @to_time
+ORDER BY timestamp, id;
@@ -0,0 +1,21 @@
+SELECT
+    trip.id AS trip_id,
+    trip.provider,
+    vehicle.vehicle_type,
+    trip.start_time,
+    trip.duration,
+    trip.distance,
+    coalesce((
+        SELECT array_agg(DISTINCT geography.id)
+        FROM event, unnest(event.event_geographies) AS geography (id)
+        WHERE
+            trip.id = any(event.trip_ids)
+            AND 'trip_start' = any(event.event_types)
+    ), '{}') AS geography_ids
+FROM trip
+INNER JOIN vehicle ON trip.vehicle = vehicle.id
+WHERE
+    trip.start_time >= @from_time
+    AND trip.start_time < @to_time
+    AND (@providers::UUID [] IS NULL OR trip.provider = any(@providers))
+ORDER BY trip.start_time, trip.id;
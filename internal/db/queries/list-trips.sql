@@ -0,0 +1,21 @@
+SELECT
+    id,
+    vehicle,
+    provider,
+    data_provider,
+    journey_id,
+    start_time,
+    end_time,
+    start_location,
+    end_location,
+    duration,
+    distance,
+    fare,
+    trip_attributes,
+    publication_time
+FROM trip
+WHERE
+    (@provider::UUID IS NULL OR provider = @provider)
+    AND end_time >= @from_time
+    AND end_time < @to_time
+ORDER BY end_time, id;
@@ -0,0 +1,45 @@
+-- Includes the last event before the range for each vehicle so that its state at the start of
+-- the range is known.
+WITH vehicle_event AS (
+    (
+        SELECT DISTINCT ON (vehicle)
+            vehicle,
+            provider,
+            timestamp,
+            vehicle_state,
+            event_types,
+            event_geographies
+        FROM event
+        WHERE
+            timestamp < @from_time
+            AND (@providers::UUID [] IS NULL OR provider = any(@providers))
+        ORDER BY vehicle, timestamp DESC
+    )
+    UNION ALL
+    (
+        SELECT
+            vehicle,
+            provider,
+            timestamp,
+            vehicle_state,
+            event_types,
+            event_geographies
+        FROM event
+        WHERE
+            timestamp >= @from_time
+            AND timestamp < @to_time
+            AND (@providers::UUID [] IS NULL OR provider = any(@providers))
+    )
+)
+
+SELECT
+    vehicle_event.vehicle AS device_id,
+    vehicle_event.provider,
+    vehicle.vehicle_type,
+    vehicle_event.timestamp,
+    vehicle_event.vehicle_state,
+    vehicle_event.event_types,
+    vehicle_event.event_geographies AS geography_ids
+FROM vehicle_event
+INNER JOIN vehicle ON vehicle_event.vehicle = vehicle.id
+ORDER BY vehicle_event.vehicle, vehicle_event.timestamp;
@@ -0,0 +1,10 @@
+SELECT
+    id,
+    provider,
+    data_provider,
+    last_event,
+    last_telemetry
+FROM vehicle_status
+WHERE (@provider::UUID IS NULL OR provider = @provider)
+ORDER BY id
+LIMIT @limit OFFSET @offset;
@@ -11,5 +11,5 @@ SELECT
     maximum_speed,
     propulsion_types
 FROM vehicle_denormalized
-WHERE provider = @provider
+WHERE (@provider::UUID IS NULL OR provider = @provider)
 LIMIT @limit OFFSET @offset;
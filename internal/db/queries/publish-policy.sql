@@ -0,0 +1,3 @@
+UPDATE policy SET
+    published_date = @published_date
+WHERE id = @id AND published_date IS NULL;
@@ -0,0 +1,3 @@
+UPDATE provider_credentials
+SET revoked_at = coalesce(revoked_at, now())
+WHERE client_id = @client_id;
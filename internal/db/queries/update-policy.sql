@@ -0,0 +1,10 @@
+UPDATE policy SET
+    name = @name,
+    description = @description,
+    provider_ids = @provider_ids,
+    currency = @currency,
+    start_date = @start_date,
+    end_date = @end_date,
+    prev_policies = @prev_policies,
+    rules = @rules
+WHERE id = @id AND published_date IS NULL;
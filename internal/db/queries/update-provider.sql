@@ -0,0 +1,9 @@
+UPDATE provider
+SET
+    name = @name,
+    url = @url,
+    contact = @contact,
+    status = @status,
+    vehicle_types = @vehicle_types,
+    data_providers = @data_providers
+WHERE id = @id;
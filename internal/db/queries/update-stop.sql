@@ -0,0 +1,22 @@
+UPDATE stop SET
+    name = @name,
+    last_reported = @last_reported,
+    location = @location,
+    status = @status,
+    provider = @provider,
+    data_provider = @data_provider,
+    region_id = @region_id,
+    short_name = @short_name,
+    address = @address,
+    post_code = @post_code,
+    cross_street = @cross_street,
+    rental_methods = @rental_methods,
+    num_vehicles_available = @num_vehicles_available,
+    num_vehicles_disabled = @num_vehicles_disabled,
+    num_places_available = @num_places_available,
+    num_places_disabled = @num_places_disabled,
+    parent_stop = @parent_stop,
+    devices = @devices,
+    wheelchair_boarding = @wheelchair_boarding
+WHERE id = @id
+RETURNING id;
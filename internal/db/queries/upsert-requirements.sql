@@ -0,0 +1,13 @@
+INSERT INTO requirements (
+    last_updated,
+    metadata,
+    requirements
+) VALUES (
+    @last_updated,
+    @metadata,
+    @requirements
+)
+ON CONFLICT (id) DO UPDATE SET
+    last_updated = excluded.last_updated,
+    metadata = excluded.metadata,
+    requirements = excluded.requirements;
@@ -0,0 +1,100 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	_ "embed"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/technopolitica/open-transit/internal/domain"
+)
+
+func dtoFromReport(domainReport domain.MonthlyReport) ReportDTO {
+	return ReportDTO{
+		Provider:         domainReport.ProviderID,
+		StartDate:        domainReport.StartDate.Time,
+		Duration:         domainReport.Duration,
+		SpecialGroupType: domainReport.SpecialGroupType.String(),
+		GeographyID:      domainReport.GeographyID,
+		VehicleType:      domainReport.VehicleType.String(),
+		TripCount:        int32(domainReport.TripCount),
+		RiderCount:       int32(domainReport.RiderCount),
+	}
+}
+
+func reportFromDTO(report ReportDTO) (domain.MonthlyReport, error) {
+	specialGroupType, err := domain.ParseSpecialGroupType(report.SpecialGroupType)
+	if err != nil {
+		return domain.MonthlyReport{}, fmt.Errorf("invalid stored report: %w", err)
+	}
+	vehicleType, err := domain.ParseVehicleType(report.VehicleType)
+	if err != nil {
+		return domain.MonthlyReport{}, fmt.Errorf("invalid stored report: %w", err)
+	}
+	return domain.MonthlyReport{
+		ProviderID:       report.Provider,
+		StartDate:        domain.NewTimestamp(report.StartDate),
+		Duration:         report.Duration,
+		SpecialGroupType: specialGroupType,
+		GeographyID:      report.GeographyID,
+		VehicleType:      vehicleType,
+		TripCount:        int(report.TripCount),
+		RiderCount:       int(report.RiderCount),
+	}, nil
+}
+
+//go:embed queries/list-reports.sql
+var listReportsQuery string
+
+func (repo Repository) ListReports(ctx context.Context, providerID *uuid.UUID) (reports []domain.MonthlyReport, err error) {
+	rows, err := repo.Query(ctx, listReportsQuery, pgx.NamedArgs{"provider": providerID})
+	if err != nil {
+		err = fmt.Errorf("failed to execute query: %w", err)
+		return
+	}
+
+	reportDTOs, err := pgx.CollectRows(rows, pgx.RowToStructByName[ReportDTO])
+	if err != nil {
+		err = fmt.Errorf("failed to map row to ReportDTO: %w", err)
+		return
+	}
+	reports = make([]domain.MonthlyReport, 0, len(reportDTOs))
+	for _, dto := range reportDTOs {
+		var report domain.MonthlyReport
+		report, err = reportFromDTO(dto)
+		if err != nil {
+			return
+		}
+		reports = append(reports, report)
+	}
+	return
+}
+
+//go:embed queries/insert-report.sql
+var insertReportQuery string
+
+func (repo Repository) InsertReport(ctx context.Context, report domain.MonthlyReport) error {
+	reportDTO := dtoFromReport(report)
+	_, err := repo.Exec(ctx, insertReportQuery, pgx.NamedArgs{
+		"provider":           reportDTO.Provider,
+		"start_date":         reportDTO.StartDate,
+		"duration":           reportDTO.Duration,
+		"special_group_type": reportDTO.SpecialGroupType,
+		"geography_id":       reportDTO.GeographyID,
+		"vehicle_type":       reportDTO.VehicleType,
+		"trip_count":         reportDTO.TripCount,
+		"rider_count":        reportDTO.RiderCount,
+	})
+
+	var pgErr *pgconn.PgError
+	if err != nil && errors.As(err, &pgErr) && pgErr.ConstraintName == "report_pkey" && pgErr.Code == pgerrcode.UniqueViolation {
+		return ErrConflict
+	}
+
+	return err
+}
@@ -3,14 +3,18 @@ package db
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/technopolitica/open-transit/internal/domain"
 )
 
 var ErrNotFound = errors.New("not found")
 var ErrConflict = errors.New("already exists")
+var ErrInvalidReference = errors.New("references a non-existent record")
+var ErrImmutable = errors.New("cannot be modified")
 
 type DBConnection interface {
 	Begin(ctx context.Context) (pgx.Tx, error)
@@ -36,6 +40,36 @@ func registerTypes(ctx context.Context, conn DBConnection, typeNames []string) e
 	return nil
 }
 
+// nonNil ensures that empty slices are stored as empty arrays rather than NULL.
+func nonNil[T any](items []T) []T {
+	if items == nil {
+		return []T{}
+	}
+	return items
+}
+
+func nilIfEmpty[T any](items []T) []T {
+	if len(items) == 0 {
+		return nil
+	}
+	return items
+}
+
+func timeFromTimestamp(ts *domain.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+	return &ts.Time
+}
+
+func timestampFromTime(t *time.Time) *domain.Timestamp {
+	if t == nil {
+		return nil
+	}
+	ts := domain.NewTimestamp(*t)
+	return &ts
+}
+
 func NewRepository(ctx context.Context, conn DBConnection) (Repository, error) {
 	return Repository{conn}, nil
 }
@@ -0,0 +1,188 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	_ "embed"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/technopolitica/open-transit/internal/domain"
+)
+
+func nonNilCounts(counts domain.VehicleTypeCounts) domain.VehicleTypeCounts {
+	if counts == nil {
+		return domain.VehicleTypeCounts{}
+	}
+	return counts
+}
+
+func nilIfEmptyCounts(counts domain.VehicleTypeCounts) domain.VehicleTypeCounts {
+	if len(counts) == 0 {
+		return nil
+	}
+	return counts
+}
+
+func dtoFromStop(domainStop domain.Stop) StopDTO {
+	return StopDTO{
+		ID:                   domainStop.StopID,
+		Name:                 domainStop.Name,
+		LastReported:         domainStop.LastReported.Time,
+		Location:             domainStop.Location,
+		Status:               domainStop.Status,
+		Provider:             domainStop.ProviderID,
+		DataProvider:         domainStop.DataProviderID,
+		RegionID:             domainStop.RegionID,
+		ShortName:            domainStop.ShortName,
+		Address:              domainStop.Address,
+		PostCode:             domainStop.PostCode,
+		CrossStreet:          domainStop.CrossStreet,
+		RentalMethods:        nonNil(domainStop.RentalMethods),
+		NumVehiclesAvailable: nonNilCounts(domainStop.NumVehiclesAvailable),
+		NumVehiclesDisabled:  nonNilCounts(domainStop.NumVehiclesDisabled),
+		NumPlacesAvailable:   nonNilCounts(domainStop.NumPlacesAvailable),
+		NumPlacesDisabled:    nonNilCounts(domainStop.NumPlacesDisabled),
+		ParentStop:           domainStop.ParentStop,
+		Devices:              nonNil(domainStop.Devices),
+		WheelchairBoarding:   domainStop.WheelchairBoarding,
+	}
+}
+
+func stopFromDTO(stop StopDTO) domain.Stop {
+	return domain.Stop{
+		StopID:               stop.ID,
+		Name:                 stop.Name,
+		LastReported:         domain.NewTimestamp(stop.LastReported),
+		Location:             stop.Location,
+		Status:               stop.Status,
+		ProviderID:           stop.Provider,
+		DataProviderID:       stop.DataProvider,
+		RegionID:             stop.RegionID,
+		ShortName:            stop.ShortName,
+		Address:              stop.Address,
+		PostCode:             stop.PostCode,
+		CrossStreet:          stop.CrossStreet,
+		RentalMethods:        nilIfEmpty(stop.RentalMethods),
+		NumVehiclesAvailable: stop.NumVehiclesAvailable,
+		NumVehiclesDisabled:  nilIfEmptyCounts(stop.NumVehiclesDisabled),
+		NumPlacesAvailable:   nilIfEmptyCounts(stop.NumPlacesAvailable),
+		NumPlacesDisabled:    nilIfEmptyCounts(stop.NumPlacesDisabled),
+		ParentStop:           stop.ParentStop,
+		Devices:              nilIfEmpty(stop.Devices),
+		WheelchairBoarding:   stop.WheelchairBoarding,
+	}
+}
+
+func stopNamedArgs(stopDTO StopDTO) pgx.NamedArgs {
+	return pgx.NamedArgs{
+		"id":                     stopDTO.ID,
+		"name":                   stopDTO.Name,
+		"last_reported":          stopDTO.LastReported,
+		"location":               stopDTO.Location,
+		"status":                 stopDTO.Status,
+		"provider":               stopDTO.Provider,
+		"data_provider":          stopDTO.DataProvider,
+		"region_id":              stopDTO.RegionID,
+		"short_name":             stopDTO.ShortName,
+		"address":                stopDTO.Address,
+		"post_code":              stopDTO.PostCode,
+		"cross_street":           stopDTO.CrossStreet,
+		"rental_methods":         stopDTO.RentalMethods,
+		"num_vehicles_available": stopDTO.NumVehiclesAvailable,
+		"num_vehicles_disabled":  stopDTO.NumVehiclesDisabled,
+		"num_places_available":   stopDTO.NumPlacesAvailable,
+		"num_places_disabled":    stopDTO.NumPlacesDisabled,
+		"parent_stop":            stopDTO.ParentStop,
+		"devices":                stopDTO.Devices,
+		"wheelchair_boarding":    stopDTO.WheelchairBoarding,
+	}
+}
+
+func isInvalidParentStop(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.ConstraintName == "stop_parent_stop_fkey" && pgErr.Code == pgerrcode.ForeignKeyViolation
+}
+
+//go:embed queries/fetch-stop.sql
+var fetchStopQuery string
+
+func (repo Repository) FetchStop(ctx context.Context, stopID uuid.UUID) (stop domain.Stop, err error) {
+	rows, err := repo.Query(ctx, fetchStopQuery, pgx.NamedArgs{"id": stopID})
+	if err != nil {
+		err = fmt.Errorf("failed to execute query: %w", err)
+		return
+	}
+
+	stopDTOs, err := pgx.CollectRows(rows, pgx.RowToStructByName[StopDTO])
+	if err != nil {
+		err = fmt.Errorf("failed to map row to StopDTO: %w", err)
+		return
+	}
+	if len(stopDTOs) == 0 {
+		err = ErrNotFound
+		return
+	}
+
+	stop = stopFromDTO(stopDTOs[0])
+	return
+}
+
+//go:embed queries/list-stops.sql
+var listStopsQuery string
+
+func (repo Repository) ListStops(ctx context.Context) (stops []domain.Stop, err error) {
+	rows, err := repo.Query(ctx, listStopsQuery)
+	if err != nil {
+		err = fmt.Errorf("failed to execute query: %w", err)
+		return
+	}
+
+	stopDTOs, err := pgx.CollectRows(rows, pgx.RowToStructByName[StopDTO])
+	if err != nil {
+		err = fmt.Errorf("failed to map row to StopDTO: %w", err)
+		return
+	}
+	stops = make([]domain.Stop, 0, len(stopDTOs))
+	for _, dto := range stopDTOs {
+		stops = append(stops, stopFromDTO(dto))
+	}
+	return
+}
+
+//go:embed queries/insert-stop.sql
+var insertStopQuery string
+
+func (repo Repository) InsertStop(ctx context.Context, stop domain.Stop) error {
+	_, err := repo.Exec(ctx, insertStopQuery, stopNamedArgs(dtoFromStop(stop)))
+
+	var pgErr *pgconn.PgError
+	if err != nil && errors.As(err, &pgErr) && pgErr.ConstraintName == "stop_pkey" && pgErr.Code == pgerrcode.UniqueViolation {
+		return ErrConflict
+	}
+	if err != nil && isInvalidParentStop(err) {
+		return ErrInvalidReference
+	}
+
+	return err
+}
+
+//go:embed queries/update-stop.sql
+var updateStopQuery string
+
+func (repo Repository) UpdateStop(ctx context.Context, stop domain.Stop) error {
+	res, err := repo.Exec(ctx, updateStopQuery, stopNamedArgs(dtoFromStop(stop)))
+
+	if err != nil && isInvalidParentStop(err) {
+		return ErrInvalidReference
+	}
+	if err == nil && res.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return err
+}
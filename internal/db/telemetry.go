@@ -0,0 +1,115 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	_ "embed"
+
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/technopolitica/open-transit/internal/domain"
+)
+
+func dtoFromTelemetry(domainTelemetry domain.Telemetry) TelemetryDTO {
+	return TelemetryDTO{
+		ID:                 domainTelemetry.TelemetryID,
+		Vehicle:            domainTelemetry.DeviceID,
+		Provider:           domainTelemetry.ProviderID,
+		DataProvider:       domainTelemetry.DataProviderID,
+		Timestamp:          domainTelemetry.Timestamp.Time,
+		Lat:                domainTelemetry.Location.Lat,
+		Lng:                domainTelemetry.Location.Lng,
+		Altitude:           domainTelemetry.Location.Altitude,
+		Heading:            domainTelemetry.Location.Heading,
+		Speed:              domainTelemetry.Location.Speed,
+		HorizontalAccuracy: domainTelemetry.Location.HorizontalAccuracy,
+		VerticalAccuracy:   domainTelemetry.Location.VerticalAccuracy,
+		Satellites:         int32(domainTelemetry.Location.Satellites),
+		BatteryPercent:     int32(domainTelemetry.BatteryPercent),
+		FuelPercent:        int32(domainTelemetry.FuelPercent),
+		JourneyID:          domainTelemetry.JourneyID,
+		TripIDs:            nonNil(domainTelemetry.TripIDs),
+	}
+}
+
+func telemetryFromDTO(telemetry TelemetryDTO) domain.Telemetry {
+	return domain.Telemetry{
+		TelemetryID:    telemetry.ID,
+		DeviceID:       telemetry.Vehicle,
+		ProviderID:     telemetry.Provider,
+		DataProviderID: telemetry.DataProvider,
+		Timestamp:      domain.NewTimestamp(telemetry.Timestamp),
+		Location: domain.GPS{
+			Lat:                telemetry.Lat,
+			Lng:                telemetry.Lng,
+			Altitude:           telemetry.Altitude,
+			Heading:            telemetry.Heading,
+			Speed:              telemetry.Speed,
+			HorizontalAccuracy: telemetry.HorizontalAccuracy,
+			VerticalAccuracy:   telemetry.VerticalAccuracy,
+			Satellites:         int(telemetry.Satellites),
+		},
+		BatteryPercent: int(telemetry.BatteryPercent),
+		FuelPercent:    int(telemetry.FuelPercent),
+		JourneyID:      telemetry.JourneyID,
+		TripIDs:        nilIfEmpty(telemetry.TripIDs),
+	}
+}
+
+//go:embed queries/list-telemetry.sql
+var listTelemetryQuery string
+
+func (repo Repository) ListTelemetry(ctx context.Context, params domain.ListTelemetryParams) (telemetry []domain.Telemetry, err error) {
+	rows, err := repo.Query(ctx, listTelemetryQuery, pgx.NamedArgs{"provider": params.ProviderID, "from_time": params.Timestamp.Start, "to_time": params.Timestamp.End})
+	if err != nil {
+		err = fmt.Errorf("failed to execute query: %w", err)
+		return
+	}
+
+	telemetryDTOs, err := pgx.CollectRows(rows, pgx.RowToStructByName[TelemetryDTO])
+	if err != nil {
+		err = fmt.Errorf("failed to map row to TelemetryDTO: %w", err)
+		return
+	}
+	telemetry = make([]domain.Telemetry, 0, len(telemetryDTOs))
+	for _, dto := range telemetryDTOs {
+		telemetry = append(telemetry, telemetryFromDTO(dto))
+	}
+	return
+}
+
+//go:embed queries/insert-telemetry.sql
+var insertTelemetryQuery string
+
+func (repo Repository) InsertTelemetry(ctx context.Context, telemetry domain.Telemetry) error {
+	telemetryDTO := dtoFromTelemetry(telemetry)
+	_, err := repo.Exec(ctx, insertTelemetryQuery, pgx.NamedArgs{
+		"id":                  telemetryDTO.ID,
+		"vehicle":             telemetryDTO.Vehicle,
+		"provider":            telemetryDTO.Provider,
+		"data_provider":       telemetryDTO.DataProvider,
+		"timestamp":           telemetryDTO.Timestamp,
+		"lat":                 telemetryDTO.Lat,
+		"lng":                 telemetryDTO.Lng,
+		"altitude":            telemetryDTO.Altitude,
+		"heading":             telemetryDTO.Heading,
+		"speed":               telemetryDTO.Speed,
+		"horizontal_accuracy": telemetryDTO.HorizontalAccuracy,
+		"vertical_accuracy":   telemetryDTO.VerticalAccuracy,
+		"satellites":          telemetryDTO.Satellites,
+		"battery_percent":     telemetryDTO.BatteryPercent,
+		"fuel_percent":        telemetryDTO.FuelPercent,
+		"journey_id":          telemetryDTO.JourneyID,
+		"trip_ids":            telemetryDTO.TripIDs,
+	})
+
+	var pgErr *pgconn.PgError
+	if err != nil && errors.As(err, &pgErr) && pgErr.ConstraintName == "telemetry_pkey" && pgErr.Code == pgerrcode.UniqueViolation {
+		return ErrConflict
+	}
+
+	return err
+}
@@ -0,0 +1,104 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	_ "embed"
+
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/technopolitica/open-transit/internal/domain"
+)
+
+func dtoFromTrip(domainTrip domain.Trip) TripDTO {
+	return TripDTO{
+		ID:              domainTrip.TripID,
+		Vehicle:         domainTrip.DeviceID,
+		Provider:        domainTrip.ProviderID,
+		DataProvider:    domainTrip.DataProviderID,
+		JourneyID:       domainTrip.JourneyID,
+		StartTime:       domainTrip.StartTime.Time,
+		EndTime:         domainTrip.EndTime.Time,
+		StartLocation:   domainTrip.StartLocation,
+		EndLocation:     domainTrip.EndLocation,
+		Duration:        int32(domainTrip.Duration),
+		Distance:        int32(domainTrip.Distance),
+		Fare:            domainTrip.Fare,
+		TripAttributes:  domainTrip.TripAttributes,
+		PublicationTime: timeFromTimestamp(domainTrip.PublicationTime),
+	}
+}
+
+func tripFromDTO(trip TripDTO) domain.Trip {
+	return domain.Trip{
+		TripID:          trip.ID,
+		DeviceID:        trip.Vehicle,
+		ProviderID:      trip.Provider,
+		DataProviderID:  trip.DataProvider,
+		JourneyID:       trip.JourneyID,
+		StartTime:       domain.NewTimestamp(trip.StartTime),
+		EndTime:         domain.NewTimestamp(trip.EndTime),
+		StartLocation:   trip.StartLocation,
+		EndLocation:     trip.EndLocation,
+		Duration:        int(trip.Duration),
+		Distance:        int(trip.Distance),
+		Fare:            trip.Fare,
+		TripAttributes:  trip.TripAttributes,
+		PublicationTime: timestampFromTime(trip.PublicationTime),
+	}
+}
+
+//go:embed queries/list-trips.sql
+var listTripsQuery string
+
+func (repo Repository) ListTrips(ctx context.Context, params domain.ListTripsParams) (trips []domain.Trip, err error) {
+	rows, err := repo.Query(ctx, listTripsQuery, pgx.NamedArgs{"provider": params.ProviderID, "from_time": params.EndTime.Start, "to_time": params.EndTime.End})
+	if err != nil {
+		err = fmt.Errorf("failed to execute query: %w", err)
+		return
+	}
+
+	tripDTOs, err := pgx.CollectRows(rows, pgx.RowToStructByName[TripDTO])
+	if err != nil {
+		err = fmt.Errorf("failed to map row to TripDTO: %w", err)
+		return
+	}
+	trips = make([]domain.Trip, 0, len(tripDTOs))
+	for _, dto := range tripDTOs {
+		trips = append(trips, tripFromDTO(dto))
+	}
+	return
+}
+
+//go:embed queries/insert-trip.sql
+var insertTripQuery string
+
+func (repo Repository) InsertTrip(ctx context.Context, trip domain.Trip) error {
+	tripDTO := dtoFromTrip(trip)
+	_, err := repo.Exec(ctx, insertTripQuery, pgx.NamedArgs{
+		"id":               tripDTO.ID,
+		"vehicle":          tripDTO.Vehicle,
+		"provider":         tripDTO.Provider,
+		"data_provider":    tripDTO.DataProvider,
+		"journey_id":       tripDTO.JourneyID,
+		"start_time":       tripDTO.StartTime,
+		"end_time":         tripDTO.EndTime,
+		"start_location":   tripDTO.StartLocation,
+		"end_location":     tripDTO.EndLocation,
+		"duration":         tripDTO.Duration,
+		"distance":         tripDTO.Distance,
+		"fare":             tripDTO.Fare,
+		"trip_attributes":  tripDTO.TripAttributes,
+		"publication_time": tripDTO.PublicationTime,
+	})
+
+	var pgErr *pgconn.PgError
+	if err != nil && errors.As(err, &pgErr) && pgErr.ConstraintName == "trip_pkey" && pgErr.Code == pgerrcode.UniqueViolation {
+		return ErrConflict
+	}
+
+	return err
+}
@@ -0,0 +1,88 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	_ "embed"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/technopolitica/open-transit/internal/domain"
+)
+
+func vehicleStatusFromDTO(status VehicleStatusDTO) (domain.VehicleStatus, error) {
+	var lastEvent *domain.Event
+	if status.LastEvent != nil {
+		event, err := eventFromDTO(*status.LastEvent)
+		if err != nil {
+			return domain.VehicleStatus{}, err
+		}
+		lastEvent = &event
+	}
+	var lastTelemetry *domain.Telemetry
+	if status.LastTelemetry != nil {
+		telemetry := telemetryFromDTO(*status.LastTelemetry)
+		lastTelemetry = &telemetry
+	}
+	return domain.VehicleStatus{
+		DeviceID:       status.ID,
+		ProviderID:     status.Provider,
+		DataProviderID: status.DataProvider,
+		LastEvent:      lastEvent,
+		LastTelemetry:  lastTelemetry,
+	}, nil
+}
+
+//go:embed queries/fetch-vehicle-status.sql
+var fetchVehicleStatusQuery string
+
+func (repo Repository) FetchVehicleStatus(ctx context.Context, params domain.FetchVehicleParams) (status domain.VehicleStatus, err error) {
+	rows, err := repo.Query(ctx, fetchVehicleStatusQuery, pgx.NamedArgs{"id": params.VehicleID, "provider": params.ProviderID})
+	if err != nil {
+		err = fmt.Errorf("failed to execute query: %w", err)
+		return
+	}
+
+	statusDTOs, err := pgx.CollectRows(rows, pgx.RowToStructByName[VehicleStatusDTO])
+	if err != nil {
+		err = fmt.Errorf("failed to map row to VehicleStatusDTO: %w", err)
+		return
+	}
+	if len(statusDTOs) == 0 {
+		err = ErrNotFound
+		return
+	}
+
+	status, err = vehicleStatusFromDTO(statusDTOs[0])
+	return
+}
+
+//go:embed queries/list-vehicle-statuses.sql
+var listVehicleStatusesQuery string
+
+func (repo Repository) ListVehicleStatuses(ctx context.Context, arg domain.ListVehiclesParams) (page domain.Page[domain.VehicleStatus], err error) {
+	err = repo.WithinTransaction(ctx, func(tx pgx.Tx) (err error) {
+		rows, err := tx.Query(ctx, listVehicleStatusesQuery, pgx.NamedArgs{"provider": arg.ProviderID, "limit": arg.Limit, "offset": arg.Offset})
+		if err != nil {
+			return
+		}
+		statusDTOs, err := pgx.CollectRows(rows, pgx.RowToStructByName[VehicleStatusDTO])
+		if err != nil {
+			return
+		}
+		page.Items = make([]domain.VehicleStatus, 0, len(statusDTOs))
+		for _, dto := range statusDTOs {
+			var status domain.VehicleStatus
+			status, err = vehicleStatusFromDTO(dto)
+			if err != nil {
+				return
+			}
+			page.Items = append(page.Items, status)
+		}
+
+		row := tx.QueryRow(ctx, countVehiclesQuery, pgx.NamedArgs{"provider": arg.ProviderID})
+		err = row.Scan(&page.Total)
+		return
+	})
+	return
+}
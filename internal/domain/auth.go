@@ -1,7 +1,156 @@
+//go:generate go run github.com/abice/go-enum@v0.5.6 --marshal --sql
+
 package domain
 
-import "github.com/google/uuid"
+import (
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// ENUM(none, provider, agency, admin, data_provider)
+type Role int
+
+type Scope string
+
+const (
+	ScopeVehiclesRead       Scope = "vehicles:read"
+	ScopeVehiclesWrite      Scope = "vehicles:write"
+	ScopeEventsRead         Scope = "events:read"
+	ScopeEventsWrite        Scope = "events:write"
+	ScopeTelemetryRead      Scope = "telemetry:read"
+	ScopeTelemetryWrite     Scope = "telemetry:write"
+	ScopeTripsRead          Scope = "trips:read"
+	ScopeTripsWrite         Scope = "trips:write"
+	ScopeStopsRead          Scope = "stops:read"
+	ScopeStopsWrite         Scope = "stops:write"
+	ScopeReportsRead        Scope = "reports:read"
+	ScopeReportsWrite       Scope = "reports:write"
+	ScopePoliciesRead       Scope = "policies:read"
+	ScopePoliciesWrite      Scope = "policies:write"
+	ScopePoliciesPublish    Scope = "policies:publish"
+	ScopeGeographiesRead    Scope = "geographies:read"
+	ScopeGeographiesWrite   Scope = "geographies:write"
+	ScopeJurisdictionsRead  Scope = "jurisdictions:read"
+	ScopeJurisdictionsWrite Scope = "jurisdictions:write"
+	ScopeMetricsRead        Scope = "metrics:read"
+	ScopeProvidersRead      Scope = "providers:read"
+	ScopeProvidersWrite     Scope = "providers:write"
+)
+
+var agencyScopes = []Scope{
+	ScopeVehiclesRead,
+	ScopeEventsRead,
+	ScopeTelemetryRead,
+	ScopeTripsRead,
+	ScopeStopsRead,
+	ScopeStopsWrite,
+	ScopeReportsRead,
+	ScopePoliciesRead,
+	ScopePoliciesWrite,
+	ScopeGeographiesRead,
+	ScopeGeographiesWrite,
+	ScopeJurisdictionsRead,
+	ScopeJurisdictionsWrite,
+	ScopeMetricsRead,
+	ScopeProvidersRead,
+}
+
+// roleScopes are the scopes granted to each role. Tokens may narrow these down with a scope claim,
+// but never widen them.
+var roleScopes = map[Role]Set[Scope]{
+	RoleProvider: NewSet(
+		ScopeVehiclesRead,
+		ScopeVehiclesWrite,
+		ScopeEventsRead,
+		ScopeEventsWrite,
+		ScopeTelemetryRead,
+		ScopeTelemetryWrite,
+		ScopeTripsRead,
+		ScopeTripsWrite,
+		ScopeStopsRead,
+		ScopeReportsRead,
+		ScopeReportsWrite,
+		ScopePoliciesRead,
+		ScopeGeographiesRead,
+		ScopeJurisdictionsRead,
+		ScopeMetricsRead,
+	),
+	// Data providers submit data on behalf of the providers that have delegated to them, so only
+	// get the write scopes that delegation covers.
+	RoleDataProvider: NewSet(
+		ScopeVehiclesWrite,
+		ScopeEventsWrite,
+		ScopeTelemetryWrite,
+		ScopeTripsWrite,
+	),
+	RoleAgency: NewSet(agencyScopes...),
+	RoleAdmin:  NewSet(append(agencyScopes, ScopePoliciesPublish, ScopeProvidersWrite)...),
+}
+
+// ScopesForRole returns every scope granted to role.
+func ScopesForRole(role Role) []Scope {
+	return roleScopes[role]
+}
+
+// ParseScopes splits a space-delimited list of scopes.
+func ParseScopes(scope string) []Scope {
+	fields := strings.Fields(scope)
+	scopes := make([]Scope, 0, len(fields))
+	for _, field := range fields {
+		scopes = append(scopes, Scope(field))
+	}
+	return scopes
+}
+
+// FormatScopes joins scopes into a space-delimited list.
+func FormatScopes(scopes []Scope) string {
+	strs := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		strs = append(strs, string(scope))
+	}
+	return strings.Join(strs, " ")
+}
 
 type AuthInfo struct {
+	// ProviderID identifies the provider, or for the data provider role the data provider, that
+	// the token was issued to.
 	ProviderID uuid.UUID `json:"provider_id"`
+	Role       Role      `json:"role"`
+	// Scope is a space-delimited list of scopes, as in the scope claim of RFC 9068 access tokens.
+	// When absent every scope granted to the role is allowed.
+	Scope string `json:"scope,omitempty"`
+}
+
+// WithDefaultRole assigns the provider role to tokens that only carry a provider_id claim, as
+// issued before roles were introduced.
+func (auth AuthInfo) WithDefaultRole() AuthInfo {
+	if auth.Role == RoleNone && auth.ProviderID != uuid.Nil {
+		auth.Role = RoleProvider
+	}
+	return auth
+}
+
+// IsProvider reports whether the caller acts on behalf of a single provider, and so may only
+// access that provider's data.
+func (auth AuthInfo) IsProvider() bool {
+	return auth.Role == RoleProvider
+}
+
+func (auth AuthInfo) HasScope(scope Scope) bool {
+	if (auth.Role == RoleProvider || auth.Role == RoleDataProvider) && auth.ProviderID == uuid.Nil {
+		return false
+	}
+	if !roleScopes[auth.Role].Contains(scope) {
+		return false
+	}
+	if auth.Scope == "" {
+		return true
+	}
+	for _, granted := range ParseScopes(auth.Scope) {
+		if granted == scope {
+			return true
+		}
+	}
+	return false
 }
@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"encoding/json"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AuthInfo", func() {
+	providerID := uuid.MustParse("3c95765d-4da6-41c6-b61e-1954472ec6c9")
+
+	It("parses roles and scopes from token claims", func() {
+		var auth AuthInfo
+		Expect(json.Unmarshal([]byte(`{"role": "agency", "scope": "vehicles:read trips:read"}`), &auth)).To(Succeed())
+		Expect(auth).To(Equal(AuthInfo{Role: RoleAgency, Scope: "vehicles:read trips:read"}))
+	})
+
+	It("treats tokens with only a provider_id as provider tokens", func() {
+		auth := AuthInfo{ProviderID: providerID}.WithDefaultRole()
+		Expect(auth.IsProvider()).To(BeTrue())
+		Expect(auth.HasScope(ScopeVehiclesWrite)).To(BeTrue())
+	})
+
+	It("grants no scopes to tokens without a role", func() {
+		auth := AuthInfo{}.WithDefaultRole()
+		Expect(auth.HasScope(ScopeVehiclesRead)).To(BeFalse())
+		Expect(auth.HasScope(ScopePoliciesRead)).To(BeFalse())
+	})
+
+	It("grants no scopes to provider tokens without a provider_id", func() {
+		Expect(AuthInfo{Role: RoleProvider}.HasScope(ScopeVehiclesRead)).To(BeFalse())
+	})
+
+	It("only allows admins to manage providers", func() {
+		Expect(AuthInfo{ProviderID: providerID, Role: RoleProvider}.HasScope(ScopeProvidersRead)).To(BeFalse())
+		Expect(AuthInfo{Role: RoleAgency}.HasScope(ScopeProvidersRead)).To(BeTrue())
+		Expect(AuthInfo{Role: RoleAgency}.HasScope(ScopeProvidersWrite)).To(BeFalse())
+		Expect(AuthInfo{Role: RoleAdmin}.HasScope(ScopeProvidersWrite)).To(BeTrue())
+	})
+
+	It("only allows admins to publish policies", func() {
+		Expect(AuthInfo{ProviderID: providerID, Role: RoleProvider}.HasScope(ScopePoliciesPublish)).To(BeFalse())
+		Expect(AuthInfo{Role: RoleAgency}.HasScope(ScopePoliciesPublish)).To(BeFalse())
+		Expect(AuthInfo{Role: RoleAdmin}.HasScope(ScopePoliciesPublish)).To(BeTrue())
+	})
+
+	It("does not allow agency staff to submit provider data", func() {
+		Expect(AuthInfo{Role: RoleAgency}.HasScope(ScopeVehiclesWrite)).To(BeFalse())
+		Expect(AuthInfo{Role: RoleAgency}.HasScope(ScopeVehiclesRead)).To(BeTrue())
+	})
+
+	It("only allows data providers to write the data covered by delegation", func() {
+		auth := AuthInfo{ProviderID: providerID, Role: RoleDataProvider}
+		Expect(auth.HasScope(ScopeTelemetryWrite)).To(BeTrue())
+		Expect(auth.HasScope(ScopeReportsWrite)).To(BeFalse())
+		Expect(auth.HasScope(ScopeVehiclesRead)).To(BeFalse())
+		Expect(AuthInfo{Role: RoleDataProvider}.HasScope(ScopeTelemetryWrite)).To(BeFalse())
+	})
+
+	It("narrows the role's scopes to those in the scope claim", func() {
+		auth := AuthInfo{Role: RoleAgency, Scope: "vehicles:read policies:publish"}
+		Expect(auth.HasScope(ScopeVehiclesRead)).To(BeTrue())
+		Expect(auth.HasScope(ScopeTripsRead)).To(BeFalse())
+		Expect(auth.HasScope(ScopePoliciesPublish)).To(BeFalse())
+	})
+})
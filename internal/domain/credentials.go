@@ -0,0 +1,157 @@
+//go:generate go run github.com/abice/go-enum@v0.5.6 --marshal --sql
+
+package domain
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// clientSecretSize is the number of random bytes in a client secret.
+const clientSecretSize = 32
+
+// ProviderCredential lets a provider obtain access tokens from the built-in token endpoint with the
+// OAuth 2.0 client credentials grant. Only a hash of the client secret is stored.
+type ProviderCredential struct {
+	ClientID   string
+	SecretHash []byte
+	ProviderID uuid.UUID
+	Scopes     []Scope
+	CreatedAt  time.Time
+	RevokedAt  *time.Time
+}
+
+// NewProviderCredential generates a client ID and secret for providerID. The secret can't be
+// recovered from the credential, so it must be handed to the provider right away.
+func NewProviderCredential(providerID uuid.UUID, scopes []Scope) (credential ProviderCredential, secret string, err error) {
+	secretBytes := make([]byte, clientSecretSize)
+	_, err = rand.Read(secretBytes)
+	if err != nil {
+		err = fmt.Errorf("failed to generate client secret: %w", err)
+		return
+	}
+	secret = base64.RawURLEncoding.EncodeToString(secretBytes)
+	credential = ProviderCredential{
+		ClientID:   uuid.NewString(),
+		SecretHash: HashClientSecret(secret),
+		ProviderID: providerID,
+		Scopes:     NewSet(scopes...),
+		CreatedAt:  time.Now(),
+	}
+	return
+}
+
+// NewCertificateCredential binds a TLS client certificate subject to providerID, so that the
+// provider can authenticate requests with its certificate instead of a bearer token. The subject is
+// used as the client ID. Certificate-bound credentials have no secret, so they can't be used to
+// obtain tokens.
+func NewCertificateCredential(subject string, providerID uuid.UUID, scopes []Scope) ProviderCredential {
+	return ProviderCredential{
+		ClientID:   subject,
+		SecretHash: []byte{},
+		ProviderID: providerID,
+		Scopes:     NewSet(scopes...),
+		CreatedAt:  time.Now(),
+	}
+}
+
+// HashClientSecret hashes a client secret for storage. Client secrets are long random strings
+// rather than user-chosen passwords, so a fast hash doesn't make them any easier to guess.
+func HashClientSecret(secret string) []byte {
+	hash := sha256.Sum256([]byte(secret))
+	return hash[:]
+}
+
+func (credential ProviderCredential) CheckSecret(secret string) bool {
+	return !credential.IsCertificateBound() && subtle.ConstantTimeCompare(credential.SecretHash, HashClientSecret(secret)) == 1
+}
+
+// IsCertificateBound reports whether the credential authenticates a TLS client certificate rather
+// than a client secret.
+func (credential ProviderCredential) IsCertificateBound() bool {
+	return len(credential.SecretHash) == 0
+}
+
+// AuthInfo returns the identity of requests authenticated with the credential.
+func (credential ProviderCredential) AuthInfo() AuthInfo {
+	return AuthInfo{
+		ProviderID: credential.ProviderID,
+		Role:       RoleProvider,
+		Scope:      FormatScopes(credential.Scopes),
+	}
+}
+
+func (credential ProviderCredential) IsRevoked() bool {
+	return credential.RevokedAt != nil
+}
+
+// GrantScopes returns the scopes to issue a token with. requested narrows the credential's scopes
+// down and may be empty to request all of them.
+func (credential ProviderCredential) GrantScopes(requested []Scope) ([]Scope, error) {
+	if len(requested) == 0 {
+		return credential.Scopes, nil
+	}
+	granted := Set[Scope](credential.Scopes)
+	for _, scope := range requested {
+		if !granted.Contains(scope) {
+			return nil, fmt.Errorf("scope %s is not granted to this client", scope)
+		}
+	}
+	return NewSet(requested...), nil
+}
+
+func ValidateProviderCredential(value any) []string {
+	var errs []string
+	switch c := value.(type) {
+	case ProviderCredential:
+		if c.ProviderID == (uuid.UUID{}) {
+			errs = append(errs, "provider_id: null UUID is not allowed")
+		}
+		if c.ClientID == "" {
+			errs = append(errs, "client_id: missing required field")
+		}
+		if len(c.Scopes) == 0 {
+			errs = append(errs, "scopes: at least one scope is required")
+		}
+		providerScopes := Set[Scope](ScopesForRole(RoleProvider))
+		for _, scope := range c.Scopes {
+			if !providerScopes.Contains(scope) {
+				errs = append(errs, fmt.Sprintf("scopes: %s is not granted to providers", scope))
+			}
+		}
+	default:
+		panic("cannot validate unknown type")
+	}
+	return errs
+}
+
+// ENUM(invalid_request, invalid_client, invalid_grant, unauthorized_client, unsupported_grant_type, invalid_scope)
+type OAuthErrorCode int
+
+// OAuthError is an error response from the token endpoint, as described in RFC 6749 section 5.2.
+type OAuthError struct {
+	Code        OAuthErrorCode `json:"error"`
+	Description string         `json:"error_description,omitempty"`
+}
+
+// TokenResponse is a successful response from the token endpoint, as described in RFC 6749
+// section 5.1.
+type TokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int    `json:"expires_in"`
+	Scope       string `json:"scope"`
+}
+
+type ProviderCredentialRepository interface {
+	InsertProviderCredential(ctx context.Context, credential ProviderCredential) error
+	FetchProviderCredential(ctx context.Context, clientID string) (ProviderCredential, error)
+	RevokeProviderCredential(ctx context.Context, clientID string) error
+}
@@ -0,0 +1,162 @@
+// Code generated by go-enum DO NOT EDIT.
+// Version:
+// Revision:
+// Build Date:
+// Built By:
+
+package domain
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+)
+
+const (
+	// OAuthErrorCodeInvalidRequest is a OAuthErrorCode of type Invalid_request.
+	OAuthErrorCodeInvalidRequest OAuthErrorCode = iota
+	// OAuthErrorCodeInvalidClient is a OAuthErrorCode of type Invalid_client.
+	OAuthErrorCodeInvalidClient
+	// OAuthErrorCodeInvalidGrant is a OAuthErrorCode of type Invalid_grant.
+	OAuthErrorCodeInvalidGrant
+	// OAuthErrorCodeUnauthorizedClient is a OAuthErrorCode of type Unauthorized_client.
+	OAuthErrorCodeUnauthorizedClient
+	// OAuthErrorCodeUnsupportedGrantType is a OAuthErrorCode of type Unsupported_grant_type.
+	OAuthErrorCodeUnsupportedGrantType
+	// OAuthErrorCodeInvalidScope is a OAuthErrorCode of type Invalid_scope.
+	OAuthErrorCodeInvalidScope
+)
+
+var ErrInvalidOAuthErrorCode = errors.New("not a valid OAuthErrorCode")
+
+const _OAuthErrorCodeName = "invalid_requestinvalid_clientinvalid_grantunauthorized_clientunsupported_grant_typeinvalid_scope"
+
+var _OAuthErrorCodeMap = map[OAuthErrorCode]string{
+	OAuthErrorCodeInvalidRequest:       _OAuthErrorCodeName[0:15],
+	OAuthErrorCodeInvalidClient:        _OAuthErrorCodeName[15:29],
+	OAuthErrorCodeInvalidGrant:         _OAuthErrorCodeName[29:42],
+	OAuthErrorCodeUnauthorizedClient:   _OAuthErrorCodeName[42:61],
+	OAuthErrorCodeUnsupportedGrantType: _OAuthErrorCodeName[61:83],
+	OAuthErrorCodeInvalidScope:         _OAuthErrorCodeName[83:96],
+}
+
+// String implements the Stringer interface.
+func (x OAuthErrorCode) String() string {
+	if str, ok := _OAuthErrorCodeMap[x]; ok {
+		return str
+	}
+	return fmt.Sprintf("OAuthErrorCode(%d)", x)
+}
+
+// IsValid provides a quick way to determine if the typed value is
+// part of the allowed enumerated values
+func (x OAuthErrorCode) IsValid() bool {
+	_, ok := _OAuthErrorCodeMap[x]
+	return ok
+}
+
+var _OAuthErrorCodeValue = map[string]OAuthErrorCode{
+	_OAuthErrorCodeName[0:15]:  OAuthErrorCodeInvalidRequest,
+	_OAuthErrorCodeName[15:29]: OAuthErrorCodeInvalidClient,
+	_OAuthErrorCodeName[29:42]: OAuthErrorCodeInvalidGrant,
+	_OAuthErrorCodeName[42:61]: OAuthErrorCodeUnauthorizedClient,
+	_OAuthErrorCodeName[61:83]: OAuthErrorCodeUnsupportedGrantType,
+	_OAuthErrorCodeName[83:96]: OAuthErrorCodeInvalidScope,
+}
+
+// ParseOAuthErrorCode attempts to convert a string to a OAuthErrorCode.
+func ParseOAuthErrorCode(name string) (OAuthErrorCode, error) {
+	if x, ok := _OAuthErrorCodeValue[name]; ok {
+		return x, nil
+	}
+	return OAuthErrorCode(0), fmt.Errorf("%s is %w", name, ErrInvalidOAuthErrorCode)
+}
+
+// MarshalText implements the text marshaller method.
+func (x OAuthErrorCode) MarshalText() ([]byte, error) {
+	return []byte(x.String()), nil
+}
+
+// UnmarshalText implements the text unmarshaller method.
+func (x *OAuthErrorCode) UnmarshalText(text []byte) error {
+	name := string(text)
+	tmp, err := ParseOAuthErrorCode(name)
+	if err != nil {
+		return err
+	}
+	*x = tmp
+	return nil
+}
+
+var errOAuthErrorCodeNilPtr = errors.New("value pointer is nil") // one per type for package clashes
+
+// Scan implements the Scanner interface.
+func (x *OAuthErrorCode) Scan(value interface{}) (err error) {
+	if value == nil {
+		*x = OAuthErrorCode(0)
+		return
+	}
+
+	// A wider range of scannable types.
+	// driver.Value values at the top of the list for expediency
+	switch v := value.(type) {
+	case int64:
+		*x = OAuthErrorCode(v)
+	case string:
+		*x, err = ParseOAuthErrorCode(v)
+	case []byte:
+		*x, err = ParseOAuthErrorCode(string(v))
+	case OAuthErrorCode:
+		*x = v
+	case int:
+		*x = OAuthErrorCode(v)
+	case *OAuthErrorCode:
+		if v == nil {
+			return errOAuthErrorCodeNilPtr
+		}
+		*x = *v
+	case uint:
+		*x = OAuthErrorCode(v)
+	case uint64:
+		*x = OAuthErrorCode(v)
+	case *int:
+		if v == nil {
+			return errOAuthErrorCodeNilPtr
+		}
+		*x = OAuthErrorCode(*v)
+	case *int64:
+		if v == nil {
+			return errOAuthErrorCodeNilPtr
+		}
+		*x = OAuthErrorCode(*v)
+	case float64: // json marshals everything as a float64 if it's a number
+		*x = OAuthErrorCode(v)
+	case *float64: // json marshals everything as a float64 if it's a number
+		if v == nil {
+			return errOAuthErrorCodeNilPtr
+		}
+		*x = OAuthErrorCode(*v)
+	case *uint:
+		if v == nil {
+			return errOAuthErrorCodeNilPtr
+		}
+		*x = OAuthErrorCode(*v)
+	case *uint64:
+		if v == nil {
+			return errOAuthErrorCodeNilPtr
+		}
+		*x = OAuthErrorCode(*v)
+	case *string:
+		if v == nil {
+			return errOAuthErrorCodeNilPtr
+		}
+		*x, err = ParseOAuthErrorCode(*v)
+	}
+
+	return
+}
+
+// Value implements the driver Valuer interface.
+func (x OAuthErrorCode) Value() (driver.Value, error) {
+	return x.String(), nil
+}
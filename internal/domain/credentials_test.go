@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ProviderCredential", func() {
+	providerID := uuid.MustParse("3c95765d-4da6-41c6-b61e-1954472ec6c9")
+
+	It("only accepts the secret it was created with", func() {
+		credential, secret, err := NewProviderCredential(providerID, []Scope{ScopeVehiclesRead})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(credential.SecretHash).NotTo(ContainSubstring(secret))
+		Expect(credential.CheckSecret(secret)).To(BeTrue())
+		Expect(credential.CheckSecret(secret + "x")).To(BeFalse())
+		Expect(credential.CheckSecret("")).To(BeFalse())
+	})
+
+	It("generates a different client for every credential", func() {
+		first, firstSecret, err := NewProviderCredential(providerID, []Scope{ScopeVehiclesRead})
+		Expect(err).NotTo(HaveOccurred())
+		second, secondSecret, err := NewProviderCredential(providerID, []Scope{ScopeVehiclesRead})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(first.ClientID).NotTo(Equal(second.ClientID))
+		Expect(firstSecret).NotTo(Equal(secondSecret))
+	})
+
+	It("grants every scope of the credential by default", func() {
+		credential := ProviderCredential{Scopes: NewSet(ScopeVehiclesRead, ScopeTripsWrite)}
+		Expect(credential.GrantScopes(nil)).To(ConsistOf(ScopeVehiclesRead, ScopeTripsWrite))
+	})
+
+	It("can narrow down the granted scopes", func() {
+		credential := ProviderCredential{Scopes: NewSet(ScopeVehiclesRead, ScopeTripsWrite)}
+		Expect(credential.GrantScopes([]Scope{ScopeTripsWrite})).To(ConsistOf(ScopeTripsWrite))
+	})
+
+	It("never widens the granted scopes", func() {
+		credential := ProviderCredential{Scopes: NewSet(ScopeVehiclesRead)}
+		_, err := credential.GrantScopes([]Scope{ScopeVehiclesRead, ScopeVehiclesWrite})
+		Expect(err).To(MatchError("scope vehicles:write is not granted to this client"))
+	})
+
+	It("only allows scopes granted to providers", func() {
+		credential, _, err := NewProviderCredential(providerID, []Scope{ScopeVehiclesRead, ScopePoliciesPublish})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ValidateProviderCredential(credential)).To(ConsistOf("scopes: policies:publish is not granted to providers"))
+	})
+
+	It("requires a provider", func() {
+		credential, _, err := NewProviderCredential(uuid.UUID{}, []Scope{ScopeVehiclesRead})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ValidateProviderCredential(credential)).To(ConsistOf("provider_id: null UUID is not allowed"))
+	})
+
+	It("never accepts a secret for a certificate-bound credential", func() {
+		credential := NewCertificateCredential("CN=scoot", providerID, []Scope{ScopeVehiclesRead})
+		Expect(credential.IsCertificateBound()).To(BeTrue())
+		Expect(credential.CheckSecret("")).To(BeFalse())
+		Expect(ValidateProviderCredential(credential)).To(BeEmpty())
+	})
+
+	It("authenticates as the provider w/ the credential's scopes", func() {
+		credential := NewCertificateCredential("CN=scoot", providerID, []Scope{ScopeVehiclesRead})
+		auth := credential.AuthInfo()
+		Expect(auth.IsProvider()).To(BeTrue())
+		Expect(auth.ProviderID).To(Equal(providerID))
+		Expect(auth.HasScope(ScopeVehiclesRead)).To(BeTrue())
+		Expect(auth.HasScope(ScopeVehiclesWrite)).To(BeFalse())
+	})
+
+	It("requires a certificate subject", func() {
+		credential := NewCertificateCredential("", providerID, []Scope{ScopeVehiclesRead})
+		Expect(ValidateProviderCredential(credential)).To(ConsistOf("client_id: missing required field"))
+	})
+})
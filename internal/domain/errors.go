@@ -8,7 +8,7 @@ import (
 	"strings"
 )
 
-// ENUM(unknown, bad_param, missing_param, already_registered, unregistered)
+// ENUM(unknown, bad_param, missing_param, already_registered, unregistered, forbidden)
 type ApiErrorType int
 
 type ApiError struct {
@@ -26,6 +26,8 @@ func (res ApiError) Description() string {
 		return "A vehicle with device_id is already registered"
 	case ApiErrorTypeUnregistered:
 		return "This device_id is unregistered"
+	case ApiErrorTypeForbidden:
+		return "The credentials provided do not permit this request"
 	default:
 		return "An unknown error occurred"
 	}
@@ -23,11 +23,13 @@ const (
 	ApiErrorTypeAlreadyRegistered
 	// ApiErrorTypeUnregistered is a ApiErrorType of type Unregistered.
 	ApiErrorTypeUnregistered
+	// ApiErrorTypeForbidden is a ApiErrorType of type Forbidden.
+	ApiErrorTypeForbidden
 )
 
 var ErrInvalidApiErrorType = errors.New("not a valid ApiErrorType")
 
-const _ApiErrorTypeName = "unknownbad_parammissing_paramalready_registeredunregistered"
+const _ApiErrorTypeName = "unknownbad_parammissing_paramalready_registeredunregisteredforbidden"
 
 var _ApiErrorTypeMap = map[ApiErrorType]string{
 	ApiErrorTypeUnknown:           _ApiErrorTypeName[0:7],
@@ -35,6 +37,7 @@ var _ApiErrorTypeMap = map[ApiErrorType]string{
 	ApiErrorTypeMissingParam:      _ApiErrorTypeName[16:29],
 	ApiErrorTypeAlreadyRegistered: _ApiErrorTypeName[29:47],
 	ApiErrorTypeUnregistered:      _ApiErrorTypeName[47:59],
+	ApiErrorTypeForbidden:         _ApiErrorTypeName[59:68],
 }
 
 // String implements the Stringer interface.
@@ -58,6 +61,7 @@ var _ApiErrorTypeValue = map[string]ApiErrorType{
 	_ApiErrorTypeName[16:29]: ApiErrorTypeMissingParam,
 	_ApiErrorTypeName[29:47]: ApiErrorTypeAlreadyRegistered,
 	_ApiErrorTypeName[47:59]: ApiErrorTypeUnregistered,
+	_ApiErrorTypeName[59:68]: ApiErrorTypeForbidden,
 }
 
 // ParseApiErrorType attempts to convert a string to a ApiErrorType.
@@ -0,0 +1,264 @@
+//go:generate go run github.com/abice/go-enum@v0.5.6 --marshal --sql
+
+package domain
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ENUM(unknown, removed, available, non_operational, reserved, on_trip, stopped, non_contactable, missing, elsewhere)
+type VehicleState int
+
+// ENUM(unspecified, agency_drop_off, agency_pick_up, battery_charged, battery_low, changed_geographies, charging_start, charging_end, comms_lost, comms_restored, compliance_pick_up, decommissioned, located, maintenance, maintenance_end, maintenance_pick_up, missing, off_hours, on_hours, provider_drop_off, rebalance_pick_up, recommission, reservation_cancel, reservation_start, trip_cancel, trip_end, trip_enter_jurisdiction, trip_leave_jurisdiction, trip_pause, trip_resume, trip_start)
+type EventType int
+
+// InitialVehicleState is the state a vehicle is considered to be in after it has been registered
+// but before any events have been reported for it.
+const InitialVehicleState = VehicleStateRemoved
+
+type Event struct {
+	EventID          uuid.UUID    `json:"event_id"`
+	DeviceID         uuid.UUID    `json:"device_id"`
+	ProviderID       uuid.UUID    `json:"provider_id"`
+	DataProviderID   uuid.UUID    `json:"data_provider_id,omitempty"`
+	VehicleState     VehicleState `json:"vehicle_state"`
+	EventTypes       []EventType  `json:"event_types"`
+	Timestamp        Timestamp    `json:"timestamp"`
+	PublicationTime  *Timestamp   `json:"publication_time,omitempty"`
+	Location         GPS          `json:"location"`
+	EventGeographies []uuid.UUID  `json:"event_geographies,omitempty"`
+	BatteryPercent   int          `json:"battery_percent,omitempty"`
+	FuelPercent      int          `json:"fuel_percent,omitempty"`
+	TripIDs          []uuid.UUID  `json:"trip_ids,omitempty"`
+	AssociatedTicket string       `json:"associated_ticket,omitempty"`
+}
+
+var tripEventTypes = NewSet(
+	EventTypeTripStart,
+	EventTypeTripEnd,
+	EventTypeTripPause,
+	EventTypeTripResume,
+	EventTypeTripCancel,
+	EventTypeTripEnterJurisdiction,
+	EventTypeTripLeaveJurisdiction,
+)
+
+func ValidateEvent(value any) []string {
+	var errs []string
+	switch e := value.(type) {
+	case Event:
+		if e.EventID == (uuid.UUID{}) {
+			errs = append(errs, "event_id: null UUID is not allowed")
+		}
+		if e.DeviceID == (uuid.UUID{}) {
+			errs = append(errs, "device_id: null UUID is not allowed")
+		}
+		if e.VehicleState == VehicleStateUnknown {
+			errs = append(errs, "vehicle_state: missing required field")
+		}
+		if len(e.EventTypes) == 0 {
+			errs = append(errs, "event_types: must contain at least one event type")
+		}
+		if e.Timestamp.IsZero() {
+			errs = append(errs, "timestamp: missing required field")
+		}
+		if e.BatteryPercent < 0 || e.BatteryPercent > 100 {
+			errs = append(errs, "battery_percent: must be between 0 and 100")
+		}
+		if e.FuelPercent < 0 || e.FuelPercent > 100 {
+			errs = append(errs, "fuel_percent: must be between 0 and 100")
+		}
+		hasTripEvent := false
+		for _, eventType := range e.EventTypes {
+			if tripEventTypes.Contains(eventType) {
+				hasTripEvent = true
+			}
+		}
+		if hasTripEvent && len(e.TripIDs) == 0 {
+			errs = append(errs, "trip_ids: required for trip events")
+		}
+		errs = append(errs, validateGPS("location", e.Location)...)
+	default:
+		panic("cannot validate unknown type")
+	}
+	return errs
+}
+
+type vehicleStateTransition struct {
+	From VehicleState
+	To   VehicleState
+}
+
+var pickUpEventTypes = []EventType{
+	EventTypeAgencyPickUp,
+	EventTypeCompliancePickUp,
+	EventTypeDecommissioned,
+	EventTypeMaintenancePickUp,
+	EventTypeRebalancePickUp,
+	EventTypeUnspecified,
+}
+
+// vehicleStateTransitions is the state machine for micromobility vehicles described in the MDS
+// vehicle states documentation. Transitions that are valid from (or to) every state, such as
+// losing communication with a vehicle, are handled in IsValidVehicleStateTransition instead.
+var vehicleStateTransitions = map[vehicleStateTransition]Set[EventType]{
+	{VehicleStateAvailable, VehicleStateAvailable}: NewSet(
+		EventTypeBatteryCharged,
+		EventTypeChargingStart,
+		EventTypeChargingEnd,
+		EventTypeOnHours,
+		EventTypeProviderDropOff,
+		EventTypeAgencyDropOff,
+		EventTypeMaintenanceEnd,
+		EventTypeReservationCancel,
+		EventTypeUnspecified,
+	),
+	{VehicleStateAvailable, VehicleStateReserved}: NewSet(EventTypeReservationStart),
+	{VehicleStateAvailable, VehicleStateOnTrip}:   NewSet(EventTypeTripStart),
+	{VehicleStateAvailable, VehicleStateNonOperational}: NewSet(
+		EventTypeBatteryLow,
+		EventTypeMaintenance,
+		EventTypeOffHours,
+		EventTypeUnspecified,
+	),
+	{VehicleStateAvailable, VehicleStateRemoved}: NewSet(pickUpEventTypes...),
+
+	{VehicleStateReserved, VehicleStateAvailable}: NewSet(EventTypeReservationCancel, EventTypeTripCancel),
+	{VehicleStateReserved, VehicleStateOnTrip}:    NewSet(EventTypeTripStart),
+
+	{VehicleStateOnTrip, VehicleStateAvailable}:      NewSet(EventTypeTripEnd, EventTypeTripCancel),
+	{VehicleStateOnTrip, VehicleStateNonOperational}: NewSet(EventTypeTripEnd, EventTypeTripCancel),
+	{VehicleStateOnTrip, VehicleStateStopped}:        NewSet(EventTypeTripPause),
+	{VehicleStateOnTrip, VehicleStateElsewhere}:      NewSet(EventTypeTripLeaveJurisdiction),
+
+	{VehicleStateStopped, VehicleStateOnTrip}:         NewSet(EventTypeTripResume),
+	{VehicleStateStopped, VehicleStateAvailable}:      NewSet(EventTypeTripEnd, EventTypeTripCancel),
+	{VehicleStateStopped, VehicleStateNonOperational}: NewSet(EventTypeTripEnd, EventTypeTripCancel),
+
+	{VehicleStateElsewhere, VehicleStateOnTrip}:    NewSet(EventTypeTripEnterJurisdiction),
+	{VehicleStateElsewhere, VehicleStateAvailable}: NewSet(EventTypeProviderDropOff, EventTypeAgencyDropOff),
+	{VehicleStateElsewhere, VehicleStateRemoved}:   NewSet(pickUpEventTypes...),
+
+	{VehicleStateNonOperational, VehicleStateAvailable}: NewSet(
+		EventTypeBatteryCharged,
+		EventTypeMaintenanceEnd,
+		EventTypeOnHours,
+		EventTypeUnspecified,
+	),
+	{VehicleStateNonOperational, VehicleStateNonOperational}: NewSet(
+		EventTypeBatteryLow,
+		EventTypeChargingStart,
+		EventTypeChargingEnd,
+		EventTypeMaintenance,
+		EventTypeOffHours,
+		EventTypeUnspecified,
+	),
+	{VehicleStateNonOperational, VehicleStateRemoved}: NewSet(pickUpEventTypes...),
+
+	{VehicleStateRemoved, VehicleStateAvailable}: NewSet(
+		EventTypeAgencyDropOff,
+		EventTypeProviderDropOff,
+		EventTypeUnspecified,
+	),
+	{VehicleStateRemoved, VehicleStateNonOperational}: NewSet(
+		EventTypeAgencyDropOff,
+		EventTypeProviderDropOff,
+		EventTypeUnspecified,
+	),
+	{VehicleStateRemoved, VehicleStateRemoved}: NewSet(
+		EventTypeChargingStart,
+		EventTypeChargingEnd,
+		EventTypeDecommissioned,
+		EventTypeMaintenance,
+		EventTypeMaintenanceEnd,
+		EventTypeRecommission,
+		EventTypeUnspecified,
+	),
+}
+
+func IsValidVehicleStateTransition(from VehicleState, to VehicleState, eventType EventType) bool {
+	switch {
+	// Communication with a vehicle can be lost in any state, and once it's restored the vehicle
+	// may be in any state.
+	case eventType == EventTypeCommsLost:
+		return to == VehicleStateNonContactable
+	case from == VehicleStateNonContactable:
+		return eventType == EventTypeCommsRestored
+	// Likewise a vehicle may go missing at any point, and be located in any state.
+	case eventType == EventTypeMissing:
+		return to == VehicleStateMissing
+	case from == VehicleStateMissing:
+		return eventType == EventTypeLocated
+	// Geography changes never affect the state of a vehicle.
+	case eventType == EventTypeChangedGeographies:
+		return from == to
+	}
+	return vehicleStateTransitions[vehicleStateTransition{from, to}].Contains(eventType)
+}
+
+// ValidateVehicleStateTransition checks that at least one of the event's event types
+// accounts for the transition from the vehicle's current state to the event's vehicle_state.
+func ValidateVehicleStateTransition(from VehicleState, event Event) []string {
+	for _, eventType := range event.EventTypes {
+		if IsValidVehicleStateTransition(from, event.VehicleState, eventType) {
+			return nil
+		}
+	}
+	return []string{
+		fmt.Sprintf(
+			"vehicle_state: invalid transition from %s to %s via event_types %v",
+			from,
+			event.VehicleState,
+			Stringify(event.EventTypes),
+		),
+	}
+}
+
+// ValidateNextVehicleStateTransition checks that the vehicle's next reported event is still a valid
+// transition from the event's vehicle_state, for events submitted out of order.
+func ValidateNextVehicleStateTransition(event Event, next Event) []string {
+	if len(ValidateVehicleStateTransition(event.VehicleState, next)) == 0 {
+		return nil
+	}
+	return []string{
+		fmt.Sprintf(
+			"vehicle_state: the vehicle's next event %s is an invalid transition from %s to %s via event_types %v",
+			next.EventID,
+			event.VehicleState,
+			next.VehicleState,
+			Stringify(next.EventTypes),
+		),
+	}
+}
+
+// FetchAdjacentEventParams identifies the events reported for a vehicle just before and just after
+// an event. Events are ordered by timestamp, and events with the same timestamp by event_id, so
+// that the order is the same every time.
+type FetchAdjacentEventParams struct {
+	VehicleID  uuid.UUID
+	ProviderID uuid.UUID
+	Timestamp  time.Time
+	EventID    uuid.UUID
+}
+
+type EventsResponse struct {
+	Version string  `json:"version"`
+	Events  []Event `json:"events"`
+}
+
+type ListEventsParams struct {
+	ProviderID *uuid.UUID
+	Timestamp  TimeRange
+}
+
+type EventRepository interface {
+	ListEvents(ctx context.Context, params ListEventsParams) ([]Event, error)
+	FetchEvent(ctx context.Context, eventID uuid.UUID) (Event, error)
+	FetchPreviousEvent(ctx context.Context, params FetchAdjacentEventParams) (Event, error)
+	FetchNextEvent(ctx context.Context, params FetchAdjacentEventParams) (Event, error)
+	InsertEvent(ctx context.Context, event Event) error
+}
@@ -0,0 +1,427 @@
+// Code generated by go-enum DO NOT EDIT.
+// Version:
+// Revision:
+// Build Date:
+// Built By:
+
+package domain
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+)
+
+const (
+	// EventTypeUnspecified is a EventType of type Unspecified.
+	EventTypeUnspecified EventType = iota
+	// EventTypeAgencyDropOff is a EventType of type Agency_drop_off.
+	EventTypeAgencyDropOff
+	// EventTypeAgencyPickUp is a EventType of type Agency_pick_up.
+	EventTypeAgencyPickUp
+	// EventTypeBatteryCharged is a EventType of type Battery_charged.
+	EventTypeBatteryCharged
+	// EventTypeBatteryLow is a EventType of type Battery_low.
+	EventTypeBatteryLow
+	// EventTypeChangedGeographies is a EventType of type Changed_geographies.
+	EventTypeChangedGeographies
+	// EventTypeChargingStart is a EventType of type Charging_start.
+	EventTypeChargingStart
+	// EventTypeChargingEnd is a EventType of type Charging_end.
+	EventTypeChargingEnd
+	// EventTypeCommsLost is a EventType of type Comms_lost.
+	EventTypeCommsLost
+	// EventTypeCommsRestored is a EventType of type Comms_restored.
+	EventTypeCommsRestored
+	// EventTypeCompliancePickUp is a EventType of type Compliance_pick_up.
+	EventTypeCompliancePickUp
+	// EventTypeDecommissioned is a EventType of type Decommissioned.
+	EventTypeDecommissioned
+	// EventTypeLocated is a EventType of type Located.
+	EventTypeLocated
+	// EventTypeMaintenance is a EventType of type Maintenance.
+	EventTypeMaintenance
+	// EventTypeMaintenanceEnd is a EventType of type Maintenance_end.
+	EventTypeMaintenanceEnd
+	// EventTypeMaintenancePickUp is a EventType of type Maintenance_pick_up.
+	EventTypeMaintenancePickUp
+	// EventTypeMissing is a EventType of type Missing.
+	EventTypeMissing
+	// EventTypeOffHours is a EventType of type Off_hours.
+	EventTypeOffHours
+	// EventTypeOnHours is a EventType of type On_hours.
+	EventTypeOnHours
+	// EventTypeProviderDropOff is a EventType of type Provider_drop_off.
+	EventTypeProviderDropOff
+	// EventTypeRebalancePickUp is a EventType of type Rebalance_pick_up.
+	EventTypeRebalancePickUp
+	// EventTypeRecommission is a EventType of type Recommission.
+	EventTypeRecommission
+	// EventTypeReservationCancel is a EventType of type Reservation_cancel.
+	EventTypeReservationCancel
+	// EventTypeReservationStart is a EventType of type Reservation_start.
+	EventTypeReservationStart
+	// EventTypeTripCancel is a EventType of type Trip_cancel.
+	EventTypeTripCancel
+	// EventTypeTripEnd is a EventType of type Trip_end.
+	EventTypeTripEnd
+	// EventTypeTripEnterJurisdiction is a EventType of type Trip_enter_jurisdiction.
+	EventTypeTripEnterJurisdiction
+	// EventTypeTripLeaveJurisdiction is a EventType of type Trip_leave_jurisdiction.
+	EventTypeTripLeaveJurisdiction
+	// EventTypeTripPause is a EventType of type Trip_pause.
+	EventTypeTripPause
+	// EventTypeTripResume is a EventType of type Trip_resume.
+	EventTypeTripResume
+	// EventTypeTripStart is a EventType of type Trip_start.
+	EventTypeTripStart
+)
+
+var ErrInvalidEventType = errors.New("not a valid EventType")
+
+const _EventTypeName = "unspecifiedagency_drop_offagency_pick_upbattery_chargedbattery_lowchanged_geographiescharging_startcharging_endcomms_lostcomms_restoredcompliance_pick_updecommissionedlocatedmaintenancemaintenance_endmaintenance_pick_upmissingoff_hourson_hoursprovider_drop_offrebalance_pick_uprecommissionreservation_cancelreservation_starttrip_canceltrip_endtrip_enter_jurisdictiontrip_leave_jurisdictiontrip_pausetrip_resumetrip_start"
+
+var _EventTypeMap = map[EventType]string{
+	EventTypeUnspecified:           _EventTypeName[0:11],
+	EventTypeAgencyDropOff:         _EventTypeName[11:26],
+	EventTypeAgencyPickUp:          _EventTypeName[26:40],
+	EventTypeBatteryCharged:        _EventTypeName[40:55],
+	EventTypeBatteryLow:            _EventTypeName[55:66],
+	EventTypeChangedGeographies:    _EventTypeName[66:85],
+	EventTypeChargingStart:         _EventTypeName[85:99],
+	EventTypeChargingEnd:           _EventTypeName[99:111],
+	EventTypeCommsLost:             _EventTypeName[111:121],
+	EventTypeCommsRestored:         _EventTypeName[121:135],
+	EventTypeCompliancePickUp:      _EventTypeName[135:153],
+	EventTypeDecommissioned:        _EventTypeName[153:167],
+	EventTypeLocated:               _EventTypeName[167:174],
+	EventTypeMaintenance:           _EventTypeName[174:185],
+	EventTypeMaintenanceEnd:        _EventTypeName[185:200],
+	EventTypeMaintenancePickUp:     _EventTypeName[200:219],
+	EventTypeMissing:               _EventTypeName[219:226],
+	EventTypeOffHours:              _EventTypeName[226:235],
+	EventTypeOnHours:               _EventTypeName[235:243],
+	EventTypeProviderDropOff:       _EventTypeName[243:260],
+	EventTypeRebalancePickUp:       _EventTypeName[260:277],
+	EventTypeRecommission:          _EventTypeName[277:289],
+	EventTypeReservationCancel:     _EventTypeName[289:307],
+	EventTypeReservationStart:      _EventTypeName[307:324],
+	EventTypeTripCancel:            _EventTypeName[324:335],
+	EventTypeTripEnd:               _EventTypeName[335:343],
+	EventTypeTripEnterJurisdiction: _EventTypeName[343:366],
+	EventTypeTripLeaveJurisdiction: _EventTypeName[366:389],
+	EventTypeTripPause:             _EventTypeName[389:399],
+	EventTypeTripResume:            _EventTypeName[399:410],
+	EventTypeTripStart:             _EventTypeName[410:420],
+}
+
+// String implements the Stringer interface.
+func (x EventType) String() string {
+	if str, ok := _EventTypeMap[x]; ok {
+		return str
+	}
+	return fmt.Sprintf("EventType(%d)", x)
+}
+
+// IsValid provides a quick way to determine if the typed value is
+// part of the allowed enumerated values
+func (x EventType) IsValid() bool {
+	_, ok := _EventTypeMap[x]
+	return ok
+}
+
+var _EventTypeValue = map[string]EventType{
+	_EventTypeName[0:11]:    EventTypeUnspecified,
+	_EventTypeName[11:26]:   EventTypeAgencyDropOff,
+	_EventTypeName[26:40]:   EventTypeAgencyPickUp,
+	_EventTypeName[40:55]:   EventTypeBatteryCharged,
+	_EventTypeName[55:66]:   EventTypeBatteryLow,
+	_EventTypeName[66:85]:   EventTypeChangedGeographies,
+	_EventTypeName[85:99]:   EventTypeChargingStart,
+	_EventTypeName[99:111]:  EventTypeChargingEnd,
+	_EventTypeName[111:121]: EventTypeCommsLost,
+	_EventTypeName[121:135]: EventTypeCommsRestored,
+	_EventTypeName[135:153]: EventTypeCompliancePickUp,
+	_EventTypeName[153:167]: EventTypeDecommissioned,
+	_EventTypeName[167:174]: EventTypeLocated,
+	_EventTypeName[174:185]: EventTypeMaintenance,
+	_EventTypeName[185:200]: EventTypeMaintenanceEnd,
+	_EventTypeName[200:219]: EventTypeMaintenancePickUp,
+	_EventTypeName[219:226]: EventTypeMissing,
+	_EventTypeName[226:235]: EventTypeOffHours,
+	_EventTypeName[235:243]: EventTypeOnHours,
+	_EventTypeName[243:260]: EventTypeProviderDropOff,
+	_EventTypeName[260:277]: EventTypeRebalancePickUp,
+	_EventTypeName[277:289]: EventTypeRecommission,
+	_EventTypeName[289:307]: EventTypeReservationCancel,
+	_EventTypeName[307:324]: EventTypeReservationStart,
+	_EventTypeName[324:335]: EventTypeTripCancel,
+	_EventTypeName[335:343]: EventTypeTripEnd,
+	_EventTypeName[343:366]: EventTypeTripEnterJurisdiction,
+	_EventTypeName[366:389]: EventTypeTripLeaveJurisdiction,
+	_EventTypeName[389:399]: EventTypeTripPause,
+	_EventTypeName[399:410]: EventTypeTripResume,
+	_EventTypeName[410:420]: EventTypeTripStart,
+}
+
+// ParseEventType attempts to convert a string to a EventType.
+func ParseEventType(name string) (EventType, error) {
+	if x, ok := _EventTypeValue[name]; ok {
+		return x, nil
+	}
+	return EventType(0), fmt.Errorf("%s is %w", name, ErrInvalidEventType)
+}
+
+// MarshalText implements the text marshaller method.
+func (x EventType) MarshalText() ([]byte, error) {
+	return []byte(x.String()), nil
+}
+
+// UnmarshalText implements the text unmarshaller method.
+func (x *EventType) UnmarshalText(text []byte) error {
+	name := string(text)
+	tmp, err := ParseEventType(name)
+	if err != nil {
+		return err
+	}
+	*x = tmp
+	return nil
+}
+
+var errEventTypeNilPtr = errors.New("value pointer is nil") // one per type for package clashes
+
+// Scan implements the Scanner interface.
+func (x *EventType) Scan(value interface{}) (err error) {
+	if value == nil {
+		*x = EventType(0)
+		return
+	}
+
+	// A wider range of scannable types.
+	// driver.Value values at the top of the list for expediency
+	switch v := value.(type) {
+	case int64:
+		*x = EventType(v)
+	case string:
+		*x, err = ParseEventType(v)
+	case []byte:
+		*x, err = ParseEventType(string(v))
+	case EventType:
+		*x = v
+	case int:
+		*x = EventType(v)
+	case *EventType:
+		if v == nil {
+			return errEventTypeNilPtr
+		}
+		*x = *v
+	case uint:
+		*x = EventType(v)
+	case uint64:
+		*x = EventType(v)
+	case *int:
+		if v == nil {
+			return errEventTypeNilPtr
+		}
+		*x = EventType(*v)
+	case *int64:
+		if v == nil {
+			return errEventTypeNilPtr
+		}
+		*x = EventType(*v)
+	case float64: // json marshals everything as a float64 if it's a number
+		*x = EventType(v)
+	case *float64: // json marshals everything as a float64 if it's a number
+		if v == nil {
+			return errEventTypeNilPtr
+		}
+		*x = EventType(*v)
+	case *uint:
+		if v == nil {
+			return errEventTypeNilPtr
+		}
+		*x = EventType(*v)
+	case *uint64:
+		if v == nil {
+			return errEventTypeNilPtr
+		}
+		*x = EventType(*v)
+	case *string:
+		if v == nil {
+			return errEventTypeNilPtr
+		}
+		*x, err = ParseEventType(*v)
+	}
+
+	return
+}
+
+// Value implements the driver Valuer interface.
+func (x EventType) Value() (driver.Value, error) {
+	return x.String(), nil
+}
+
+const (
+	// VehicleStateUnknown is a VehicleState of type Unknown.
+	VehicleStateUnknown VehicleState = iota
+	// VehicleStateRemoved is a VehicleState of type Removed.
+	VehicleStateRemoved
+	// VehicleStateAvailable is a VehicleState of type Available.
+	VehicleStateAvailable
+	// VehicleStateNonOperational is a VehicleState of type Non_operational.
+	VehicleStateNonOperational
+	// VehicleStateReserved is a VehicleState of type Reserved.
+	VehicleStateReserved
+	// VehicleStateOnTrip is a VehicleState of type On_trip.
+	VehicleStateOnTrip
+	// VehicleStateStopped is a VehicleState of type Stopped.
+	VehicleStateStopped
+	// VehicleStateNonContactable is a VehicleState of type Non_contactable.
+	VehicleStateNonContactable
+	// VehicleStateMissing is a VehicleState of type Missing.
+	VehicleStateMissing
+	// VehicleStateElsewhere is a VehicleState of type Elsewhere.
+	VehicleStateElsewhere
+)
+
+var ErrInvalidVehicleState = errors.New("not a valid VehicleState")
+
+const _VehicleStateName = "unknownremovedavailablenon_operationalreservedon_tripstoppednon_contactablemissingelsewhere"
+
+var _VehicleStateMap = map[VehicleState]string{
+	VehicleStateUnknown:        _VehicleStateName[0:7],
+	VehicleStateRemoved:        _VehicleStateName[7:14],
+	VehicleStateAvailable:      _VehicleStateName[14:23],
+	VehicleStateNonOperational: _VehicleStateName[23:38],
+	VehicleStateReserved:       _VehicleStateName[38:46],
+	VehicleStateOnTrip:         _VehicleStateName[46:53],
+	VehicleStateStopped:        _VehicleStateName[53:60],
+	VehicleStateNonContactable: _VehicleStateName[60:75],
+	VehicleStateMissing:        _VehicleStateName[75:82],
+	VehicleStateElsewhere:      _VehicleStateName[82:91],
+}
+
+// String implements the Stringer interface.
+func (x VehicleState) String() string {
+	if str, ok := _VehicleStateMap[x]; ok {
+		return str
+	}
+	return fmt.Sprintf("VehicleState(%d)", x)
+}
+
+// IsValid provides a quick way to determine if the typed value is
+// part of the allowed enumerated values
+func (x VehicleState) IsValid() bool {
+	_, ok := _VehicleStateMap[x]
+	return ok
+}
+
+var _VehicleStateValue = map[string]VehicleState{
+	_VehicleStateName[0:7]:   VehicleStateUnknown,
+	_VehicleStateName[7:14]:  VehicleStateRemoved,
+	_VehicleStateName[14:23]: VehicleStateAvailable,
+	_VehicleStateName[23:38]: VehicleStateNonOperational,
+	_VehicleStateName[38:46]: VehicleStateReserved,
+	_VehicleStateName[46:53]: VehicleStateOnTrip,
+	_VehicleStateName[53:60]: VehicleStateStopped,
+	_VehicleStateName[60:75]: VehicleStateNonContactable,
+	_VehicleStateName[75:82]: VehicleStateMissing,
+	_VehicleStateName[82:91]: VehicleStateElsewhere,
+}
+
+// ParseVehicleState attempts to convert a string to a VehicleState.
+func ParseVehicleState(name string) (VehicleState, error) {
+	if x, ok := _VehicleStateValue[name]; ok {
+		return x, nil
+	}
+	return VehicleState(0), fmt.Errorf("%s is %w", name, ErrInvalidVehicleState)
+}
+
+// MarshalText implements the text marshaller method.
+func (x VehicleState) MarshalText() ([]byte, error) {
+	return []byte(x.String()), nil
+}
+
+// UnmarshalText implements the text unmarshaller method.
+func (x *VehicleState) UnmarshalText(text []byte) error {
+	name := string(text)
+	tmp, err := ParseVehicleState(name)
+	if err != nil {
+		return err
+	}
+	*x = tmp
+	return nil
+}
+
+var errVehicleStateNilPtr = errors.New("value pointer is nil") // one per type for package clashes
+
+// Scan implements the Scanner interface.
+func (x *VehicleState) Scan(value interface{}) (err error) {
+	if value == nil {
+		*x = VehicleState(0)
+		return
+	}
+
+	// A wider range of scannable types.
+	// driver.Value values at the top of the list for expediency
+	switch v := value.(type) {
+	case int64:
+		*x = VehicleState(v)
+	case string:
+		*x, err = ParseVehicleState(v)
+	case []byte:
+		*x, err = ParseVehicleState(string(v))
+	case VehicleState:
+		*x = v
+	case int:
+		*x = VehicleState(v)
+	case *VehicleState:
+		if v == nil {
+			return errVehicleStateNilPtr
+		}
+		*x = *v
+	case uint:
+		*x = VehicleState(v)
+	case uint64:
+		*x = VehicleState(v)
+	case *int:
+		if v == nil {
+			return errVehicleStateNilPtr
+		}
+		*x = VehicleState(*v)
+	case *int64:
+		if v == nil {
+			return errVehicleStateNilPtr
+		}
+		*x = VehicleState(*v)
+	case float64: // json marshals everything as a float64 if it's a number
+		*x = VehicleState(v)
+	case *float64: // json marshals everything as a float64 if it's a number
+		if v == nil {
+			return errVehicleStateNilPtr
+		}
+		*x = VehicleState(*v)
+	case *uint:
+		if v == nil {
+			return errVehicleStateNilPtr
+		}
+		*x = VehicleState(*v)
+	case *uint64:
+		if v == nil {
+			return errVehicleStateNilPtr
+		}
+		*x = VehicleState(*v)
+	case *string:
+		if v == nil {
+			return errVehicleStateNilPtr
+		}
+		*x, err = ParseVehicleState(*v)
+	}
+
+	return
+}
+
+// Value implements the driver Valuer interface.
+func (x VehicleState) Value() (driver.Value, error) {
+	return x.String(), nil
+}
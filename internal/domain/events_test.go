@@ -0,0 +1,144 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func makeValidEvent() Event {
+	return Event{
+		EventID:      uuid.MustParse("8e6d5d43-5a3b-4cd8-a3e8-3a2ae1b7d2a2"),
+		DeviceID:     uuid.MustParse("1443963e-7d93-469c-b8e1-a262715c3b49"),
+		ProviderID:   uuid.MustParse("a2b1c9a4-5a8d-4d1e-9c8e-2f0b7a1d3c4e"),
+		VehicleState: VehicleStateAvailable,
+		EventTypes:   []EventType{EventTypeProviderDropOff},
+		Timestamp:    NewTimestamp(time.UnixMilli(1690000000000)),
+		Location:     GPS{Lat: 45.5, Lng: -122.6},
+	}
+}
+
+var _ = Describe("Timestamp", func() {
+	It("marshals to milliseconds since the Unix epoch", func() {
+		Expect(json.Marshal(NewTimestamp(time.UnixMilli(1690000000123)))).To(MatchJSON(`1690000000123`))
+	})
+
+	It("unmarshals from milliseconds since the Unix epoch", func() {
+		var ts Timestamp
+		Expect(json.Unmarshal([]byte(`1690000000123`), &ts)).To(Succeed())
+		Expect(ts).To(Equal(NewTimestamp(time.UnixMilli(1690000000123))))
+	})
+})
+
+var _ = Describe("Event", func() {
+	It("round trips through JSON", func() {
+		event := makeValidEvent()
+		data, err := json.Marshal(event)
+		Expect(err).NotTo(HaveOccurred())
+
+		var output Event
+		Expect(json.Unmarshal(data, &output)).To(Succeed())
+		Expect(output).To(Equal(event))
+	})
+
+	It("is valid w/ all required fields", func() {
+		Expect(ValidateEvent(makeValidEvent())).To(BeEmpty())
+	})
+
+	It("requires a location", func() {
+		event := makeValidEvent()
+		event.Location = GPS{}
+		Expect(ValidateEvent(event)).To(ConsistOf("location: missing required field"))
+	})
+
+	It("requires at least one event type", func() {
+		event := makeValidEvent()
+		event.EventTypes = nil
+		Expect(ValidateEvent(event)).To(ConsistOf("event_types: must contain at least one event type"))
+	})
+
+	It("requires trip_ids for trip events", func() {
+		event := makeValidEvent()
+		event.VehicleState = VehicleStateOnTrip
+		event.EventTypes = []EventType{EventTypeTripStart}
+		Expect(ValidateEvent(event)).To(ConsistOf("trip_ids: required for trip events"))
+	})
+
+	It("rejects out of range coordinates", func() {
+		event := makeValidEvent()
+		event.Location.Lat = 91
+		Expect(ValidateEvent(event)).To(ConsistOf("location.lat: must be between -90 and 90"))
+	})
+})
+
+var _ = Describe("VehicleState transitions", func() {
+	DescribeTable("valid transitions",
+		func(from VehicleState, to VehicleState, eventType EventType) {
+			Expect(IsValidVehicleStateTransition(from, to, eventType)).To(BeTrue())
+		},
+		Entry(nil, VehicleStateRemoved, VehicleStateAvailable, EventTypeProviderDropOff),
+		Entry(nil, VehicleStateAvailable, VehicleStateReserved, EventTypeReservationStart),
+		Entry(nil, VehicleStateAvailable, VehicleStateOnTrip, EventTypeTripStart),
+		Entry(nil, VehicleStateOnTrip, VehicleStateStopped, EventTypeTripPause),
+		Entry(nil, VehicleStateStopped, VehicleStateOnTrip, EventTypeTripResume),
+		Entry(nil, VehicleStateOnTrip, VehicleStateElsewhere, EventTypeTripLeaveJurisdiction),
+		Entry(nil, VehicleStateOnTrip, VehicleStateAvailable, EventTypeTripEnd),
+		Entry(nil, VehicleStateAvailable, VehicleStateNonContactable, EventTypeCommsLost),
+		Entry(nil, VehicleStateNonContactable, VehicleStateOnTrip, EventTypeCommsRestored),
+		Entry(nil, VehicleStateReserved, VehicleStateMissing, EventTypeMissing),
+		Entry(nil, VehicleStateMissing, VehicleStateRemoved, EventTypeLocated),
+		Entry(nil, VehicleStateAvailable, VehicleStateAvailable, EventTypeChangedGeographies),
+	)
+
+	DescribeTable("invalid transitions",
+		func(from VehicleState, to VehicleState, eventType EventType) {
+			Expect(IsValidVehicleStateTransition(from, to, eventType)).To(BeFalse())
+		},
+		Entry(nil, VehicleStateRemoved, VehicleStateOnTrip, EventTypeTripStart),
+		Entry(nil, VehicleStateAvailable, VehicleStateOnTrip, EventTypeTripEnd),
+		Entry(nil, VehicleStateOnTrip, VehicleStateReserved, EventTypeReservationStart),
+		Entry(nil, VehicleStateNonContactable, VehicleStateAvailable, EventTypeProviderDropOff),
+		Entry(nil, VehicleStateAvailable, VehicleStateNonContactable, EventTypeBatteryLow),
+		Entry(nil, VehicleStateAvailable, VehicleStateRemoved, EventTypeChangedGeographies),
+	)
+
+	It("accepts an event if any of its event types accounts for the transition", func() {
+		event := makeValidEvent()
+		event.EventTypes = []EventType{EventTypeChangedGeographies, EventTypeProviderDropOff}
+		Expect(ValidateVehicleStateTransition(VehicleStateRemoved, event)).To(BeEmpty())
+	})
+
+	It("describes invalid transitions", func() {
+		event := makeValidEvent()
+		event.EventTypes = []EventType{EventTypeTripEnd}
+		Expect(ValidateVehicleStateTransition(VehicleStateRemoved, event)).To(ConsistOf(
+			"vehicle_state: invalid transition from removed to available via event_types [trip_end]",
+		))
+	})
+})
+
+var _ = Describe("ValidateNextVehicleStateTransition", func() {
+	It("accepts events that the next event can follow", func() {
+		event := makeValidEvent()
+		next := makeValidEvent()
+		next.VehicleState = VehicleStateOnTrip
+		next.EventTypes = []EventType{EventTypeTripStart}
+		Expect(ValidateNextVehicleStateTransition(event, next)).To(BeEmpty())
+	})
+
+	It("describes events that the next event can't follow", func() {
+		event := makeValidEvent()
+		event.VehicleState = VehicleStateNonOperational
+		event.EventTypes = []EventType{EventTypeBatteryLow}
+		next := makeValidEvent()
+		next.VehicleState = VehicleStateOnTrip
+		next.EventTypes = []EventType{EventTypeTripStart}
+		Expect(ValidateNextVehicleStateTransition(event, next)).To(ConsistOf(
+			fmt.Sprintf("vehicle_state: the vehicle's next event %s is an invalid transition from non_operational to on_trip via event_types [trip_start]", next.EventID),
+		))
+	})
+})
@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type Geography struct {
+	GeographyID     uuid.UUID         `json:"geography_id"`
+	Name            string            `json:"name"`
+	Description     string            `json:"description,omitempty"`
+	GeographyType   string            `json:"geography_type,omitempty"`
+	GeographyJSON   FeatureCollection `json:"geography_json"`
+	EffectiveDate   *Timestamp        `json:"effective_date,omitempty"`
+	PublishedDate   Timestamp         `json:"published_date"`
+	RetireDate      *Timestamp        `json:"retire_date,omitempty"`
+	PrevGeographies []uuid.UUID       `json:"prev_geographies,omitempty"`
+}
+
+func ValidateGeography(value any) []string {
+	var errs []string
+	switch g := value.(type) {
+	case Geography:
+		if g.GeographyID == (uuid.UUID{}) {
+			errs = append(errs, "geography_id: null UUID is not allowed")
+		}
+		if g.Name == "" {
+			errs = append(errs, "name: missing required field")
+		}
+		if g.EffectiveDate != nil && g.RetireDate != nil && !g.RetireDate.After(g.EffectiveDate.Time) {
+			errs = append(errs, "retire_date: must be after effective_date")
+		}
+		for _, prevGeography := range g.PrevGeographies {
+			if prevGeography == g.GeographyID {
+				errs = append(errs, "prev_geographies: a geography cannot replace itself")
+			}
+		}
+		errs = append(errs, ValidateFeatureCollection("geography_json", g.GeographyJSON)...)
+	default:
+		panic("cannot validate unknown type")
+	}
+	return errs
+}
+
+type GeographiesResponse struct {
+	Version     string      `json:"version"`
+	Geographies []Geography `json:"geographies"`
+}
+
+type GeographyResponse struct {
+	Version   string    `json:"version"`
+	Geography Geography `json:"geography"`
+}
+
+type GeographyRepository interface {
+	ListGeographies(ctx context.Context) ([]Geography, error)
+	FetchGeography(ctx context.Context, geographyID uuid.UUID) (Geography, error)
+	InsertGeography(ctx context.Context, geography Geography) error
+}
@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func makeGeometry(geometryType string, coordinates string) Geometry {
+	return Geometry{Type: geometryType, Coordinates: json.RawMessage(coordinates)}
+}
+
+func makeValidGeography() Geography {
+	polygon := makeGeometry("Polygon", `[[[-122.68, 45.51], [-122.66, 45.51], [-122.66, 45.53], [-122.68, 45.51]]]`)
+	return Geography{
+		GeographyID:   uuid.MustParse("0d5c4b3a-2918-4f7e-a6d5-c4b3a2918f7e"),
+		Name:          "Downtown",
+		PublishedDate: NewTimestamp(time.UnixMilli(1690000000000)),
+		GeographyJSON: FeatureCollection{
+			Type: "FeatureCollection",
+			Features: []Feature{
+				{Type: "Feature", Geometry: &polygon},
+			},
+		},
+	}
+}
+
+var _ = Describe("Geography", func() {
+	It("is valid w/ all required fields", func() {
+		Expect(ValidateGeography(makeValidGeography())).To(BeEmpty())
+	})
+
+	It("requires geography_json to be a FeatureCollection", func() {
+		geography := makeValidGeography()
+		geography.GeographyJSON.Type = "Feature"
+		Expect(ValidateGeography(geography)).To(ConsistOf("geography_json.type: must be FeatureCollection"))
+	})
+
+	It("requires features to have a geometry", func() {
+		geography := makeValidGeography()
+		geography.GeographyJSON.Features[0].Geometry = nil
+		Expect(ValidateGeography(geography)).To(ConsistOf("geography_json.features[0].geometry: missing required field"))
+	})
+
+	It("requires retire_date to be after effective_date", func() {
+		geography := makeValidGeography()
+		effectiveDate := NewTimestamp(time.UnixMilli(1690000000000))
+		geography.EffectiveDate = &effectiveDate
+		geography.RetireDate = &effectiveDate
+		Expect(ValidateGeography(geography)).To(ConsistOf("retire_date: must be after effective_date"))
+	})
+})
+
+var _ = Describe("ValidateGeometry", func() {
+	DescribeTable("valid geometries",
+		func(geometry Geometry) {
+			Expect(ValidateGeometry("geometry", geometry)).To(BeEmpty())
+		},
+		Entry("Point", makeGeometry("Point", `[-122.68, 45.51]`)),
+		Entry("Point w/ altitude", makeGeometry("Point", `[-122.68, 45.51, 12.5]`)),
+		Entry("MultiPoint", makeGeometry("MultiPoint", `[[-122.68, 45.51], [-122.66, 45.53]]`)),
+		Entry("LineString", makeGeometry("LineString", `[[-122.68, 45.51], [-122.66, 45.53]]`)),
+		Entry("MultiLineString", makeGeometry("MultiLineString", `[[[-122.68, 45.51], [-122.66, 45.53]]]`)),
+		Entry("Polygon", makeGeometry("Polygon", `[[[0, 0], [1, 0], [1, 1], [0, 0]]]`)),
+		Entry("MultiPolygon", makeGeometry("MultiPolygon", `[[[[0, 0], [1, 0], [1, 1], [0, 0]]], [[[2, 2], [3, 2], [3, 3], [2, 2]]]]`)),
+		Entry("GeometryCollection", Geometry{Type: "GeometryCollection", Geometries: []Geometry{makeGeometry("Point", `[0, 0]`)}}),
+	)
+
+	DescribeTable("invalid geometries",
+		func(geometry Geometry, expectedError string) {
+			Expect(ValidateGeometry("geometry", geometry)).To(ConsistOf(expectedError))
+		},
+		Entry("unknown type", makeGeometry("Circle", `[0, 0]`), `geometry.type: unsupported geometry type "Circle"`),
+		Entry("malformed coordinates", makeGeometry("Polygon", `[0, 0]`), "geometry.coordinates: malformed coordinates"),
+		Entry("position w/o latitude", makeGeometry("Point", `[0]`), "geometry.coordinates: positions must have 2 or 3 elements"),
+		Entry("out of range latitude", makeGeometry("Point", `[0, 91]`), "geometry.coordinates: latitude must be between -90 and 90"),
+		Entry("out of range longitude", makeGeometry("Point", `[181, 0]`), "geometry.coordinates: longitude must be between -180 and 180"),
+		Entry("LineString w/ a single position", makeGeometry("LineString", `[[0, 0]]`), "geometry.coordinates: must contain at least 2 positions"),
+		Entry("Polygon w/o rings", makeGeometry("Polygon", `[]`), "geometry.coordinates: must contain at least one linear ring"),
+		Entry("Polygon w/ unclosed ring", makeGeometry("Polygon", `[[[0, 0], [1, 0], [1, 1], [0, 1]]]`), "geometry.coordinates[0]: first and last positions must be identical"),
+		Entry("Polygon w/ too few positions", makeGeometry("Polygon", `[[[0, 0], [1, 0], [0, 0]]]`), "geometry.coordinates[0]: must contain at least 4 positions"),
+		Entry("nested invalid geometry", Geometry{Type: "GeometryCollection", Geometries: []Geometry{makeGeometry("Point", `[0, 91]`)}}, "geometry.geometries[0].coordinates: latitude must be between -90 and 90"),
+	)
+})
@@ -0,0 +1,163 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Geometry is a GeoJSON geometry object (RFC 7946 section 3.1). Coordinates are kept in their
+// serialized form since their structure depends on the geometry type; use ValidateGeometry to
+// check that they're well formed.
+type Geometry struct {
+	Type        string          `json:"type"`
+	Coordinates json.RawMessage `json:"coordinates,omitempty"`
+	Geometries  []Geometry      `json:"geometries,omitempty"`
+}
+
+type Feature struct {
+	Type       string    `json:"type"`
+	ID         any       `json:"id,omitempty"`
+	Geometry   *Geometry `json:"geometry"`
+	Properties Record    `json:"properties"`
+}
+
+type FeatureCollection struct {
+	Type     string    `json:"type"`
+	Features []Feature `json:"features"`
+}
+
+type position []float64
+
+func validatePosition(field string, pos position) []string {
+	if len(pos) < 2 || len(pos) > 3 {
+		return []string{fmt.Sprintf("%s: positions must have 2 or 3 elements", field)}
+	}
+	var errs []string
+	if pos[0] < -180 || pos[0] > 180 {
+		errs = append(errs, fmt.Sprintf("%s: longitude must be between -180 and 180", field))
+	}
+	if pos[1] < -90 || pos[1] > 90 {
+		errs = append(errs, fmt.Sprintf("%s: latitude must be between -90 and 90", field))
+	}
+	return errs
+}
+
+func validatePositions(field string, positions []position, minLength int) []string {
+	if len(positions) < minLength {
+		return []string{fmt.Sprintf("%s: must contain at least %d positions", field, minLength)}
+	}
+	var errs []string
+	for i, pos := range positions {
+		errs = append(errs, validatePosition(fmt.Sprintf("%s[%d]", field, i), pos)...)
+	}
+	return errs
+}
+
+func validateLinearRing(field string, ring []position) []string {
+	errs := validatePositions(field, ring, 4)
+	if len(errs) > 0 {
+		return errs
+	}
+	first, last := ring[0], ring[len(ring)-1]
+	if len(first) != len(last) {
+		return []string{fmt.Sprintf("%s: first and last positions must be identical", field)}
+	}
+	for i := range first {
+		if first[i] != last[i] {
+			return []string{fmt.Sprintf("%s: first and last positions must be identical", field)}
+		}
+	}
+	return nil
+}
+
+func validatePolygon(field string, rings [][]position) []string {
+	if len(rings) == 0 {
+		return []string{fmt.Sprintf("%s: must contain at least one linear ring", field)}
+	}
+	var errs []string
+	for i, ring := range rings {
+		errs = append(errs, validateLinearRing(fmt.Sprintf("%s[%d]", field, i), ring)...)
+	}
+	return errs
+}
+
+// decodeCoordinates unmarshals the coordinates of a geometry into the structure required by its type.
+func decodeCoordinates[T any](field string, data json.RawMessage) (coordinates T, errs []string) {
+	err := json.Unmarshal(data, &coordinates)
+	if err != nil {
+		errs = append(errs, fmt.Sprintf("%s: malformed coordinates", field))
+	}
+	return
+}
+
+func ValidateGeometry(field string, geometry Geometry) []string {
+	coordinatesField := field + ".coordinates"
+	switch geometry.Type {
+	case "Point":
+		pos, errs := decodeCoordinates[position](coordinatesField, geometry.Coordinates)
+		if len(errs) > 0 {
+			return errs
+		}
+		return validatePosition(coordinatesField, pos)
+	case "MultiPoint":
+		positions, errs := decodeCoordinates[[]position](coordinatesField, geometry.Coordinates)
+		if len(errs) > 0 {
+			return errs
+		}
+		return validatePositions(coordinatesField, positions, 0)
+	case "LineString":
+		positions, errs := decodeCoordinates[[]position](coordinatesField, geometry.Coordinates)
+		if len(errs) > 0 {
+			return errs
+		}
+		return validatePositions(coordinatesField, positions, 2)
+	case "MultiLineString":
+		lines, errs := decodeCoordinates[[][]position](coordinatesField, geometry.Coordinates)
+		for i, line := range lines {
+			errs = append(errs, validatePositions(fmt.Sprintf("%s[%d]", coordinatesField, i), line, 2)...)
+		}
+		return errs
+	case "Polygon":
+		rings, errs := decodeCoordinates[[][]position](coordinatesField, geometry.Coordinates)
+		if len(errs) > 0 {
+			return errs
+		}
+		return validatePolygon(coordinatesField, rings)
+	case "MultiPolygon":
+		polygons, errs := decodeCoordinates[[][][]position](coordinatesField, geometry.Coordinates)
+		for i, rings := range polygons {
+			errs = append(errs, validatePolygon(fmt.Sprintf("%s[%d]", coordinatesField, i), rings)...)
+		}
+		return errs
+	case "GeometryCollection":
+		var errs []string
+		for i, child := range geometry.Geometries {
+			errs = append(errs, ValidateGeometry(fmt.Sprintf("%s.geometries[%d]", field, i), child)...)
+		}
+		return errs
+	default:
+		return []string{fmt.Sprintf("%s.type: unsupported geometry type %q", field, geometry.Type)}
+	}
+}
+
+func ValidateFeatureCollection(field string, collection FeatureCollection) []string {
+	if collection.Type != "FeatureCollection" {
+		return []string{fmt.Sprintf("%s.type: must be FeatureCollection", field)}
+	}
+	var errs []string
+	if len(collection.Features) == 0 {
+		errs = append(errs, fmt.Sprintf("%s.features: must contain at least one feature", field))
+	}
+	for i, feature := range collection.Features {
+		featureField := fmt.Sprintf("%s.features[%d]", field, i)
+		if feature.Type != "Feature" {
+			errs = append(errs, fmt.Sprintf("%s.type: must be Feature", featureField))
+		}
+		if feature.Geometry == nil {
+			errs = append(errs, fmt.Sprintf("%s.geometry: missing required field", featureField))
+			continue
+		}
+		errs = append(errs, ValidateGeometry(featureField+".geometry", *feature.Geometry)...)
+	}
+	return errs
+}
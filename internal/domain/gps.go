@@ -0,0 +1,49 @@
+package domain
+
+import "fmt"
+
+type GPS struct {
+	Lat                float64 `json:"lat"`
+	Lng                float64 `json:"lng"`
+	Altitude           float64 `json:"altitude,omitempty"`
+	Heading            float64 `json:"heading,omitempty"`
+	Speed              float64 `json:"speed,omitempty"`
+	HorizontalAccuracy float64 `json:"horizontal_accuracy,omitempty"`
+	VerticalAccuracy   float64 `json:"vertical_accuracy,omitempty"`
+	Satellites         int     `json:"satellites,omitempty"`
+}
+
+// IsZero reports whether gps is the zero value, which is what a missing location decodes to. The
+// point at lat 0 / lng 0 (Null Island) isn't a plausible vehicle location, so it's treated as missing.
+func (gps GPS) IsZero() bool {
+	return gps == GPS{}
+}
+
+func validateGPS(field string, gps GPS) []string {
+	if gps.IsZero() {
+		return []string{fmt.Sprintf("%s: missing required field", field)}
+	}
+	var errs []string
+	if gps.Lat < -90 || gps.Lat > 90 {
+		errs = append(errs, fmt.Sprintf("%s.lat: must be between -90 and 90", field))
+	}
+	if gps.Lng < -180 || gps.Lng > 180 {
+		errs = append(errs, fmt.Sprintf("%s.lng: must be between -180 and 180", field))
+	}
+	if gps.Heading < 0 || gps.Heading >= 360 {
+		errs = append(errs, fmt.Sprintf("%s.heading: must be between 0 and 360", field))
+	}
+	if gps.Speed < 0 {
+		errs = append(errs, fmt.Sprintf("%s.speed: must be non-negative", field))
+	}
+	if gps.HorizontalAccuracy < 0 {
+		errs = append(errs, fmt.Sprintf("%s.horizontal_accuracy: must be non-negative", field))
+	}
+	if gps.VerticalAccuracy < 0 {
+		errs = append(errs, fmt.Sprintf("%s.vertical_accuracy: must be non-negative", field))
+	}
+	if gps.Satellites < 0 {
+		errs = append(errs, fmt.Sprintf("%s.satellites: must be non-negative", field))
+	}
+	return errs
+}
@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Jurisdiction struct {
+	JurisdictionID    uuid.UUID   `json:"jurisdiction_id"`
+	AgencyKey         string      `json:"agency_key"`
+	AgencyName        string      `json:"agency_name"`
+	GeographyID       uuid.UUID   `json:"geography_id"`
+	Timestamp         Timestamp   `json:"timestamp"`
+	PrevJurisdictions []uuid.UUID `json:"prev_jurisdictions,omitempty"`
+}
+
+func ValidateJurisdiction(value any) []string {
+	var errs []string
+	switch j := value.(type) {
+	case Jurisdiction:
+		if j.JurisdictionID == (uuid.UUID{}) {
+			errs = append(errs, "jurisdiction_id: null UUID is not allowed")
+		}
+		if j.AgencyKey == "" {
+			errs = append(errs, "agency_key: missing required field")
+		}
+		if j.AgencyName == "" {
+			errs = append(errs, "agency_name: missing required field")
+		}
+		if j.GeographyID == (uuid.UUID{}) {
+			errs = append(errs, "geography_id: null UUID is not allowed")
+		}
+		for _, prevJurisdiction := range j.PrevJurisdictions {
+			if prevJurisdiction == j.JurisdictionID {
+				errs = append(errs, "prev_jurisdictions: a jurisdiction cannot replace itself")
+			}
+		}
+	default:
+		panic("cannot validate unknown type")
+	}
+	return errs
+}
+
+type JurisdictionsResponse struct {
+	Version       string         `json:"version"`
+	Jurisdictions []Jurisdiction `json:"jurisdictions"`
+}
+
+type JurisdictionResponse struct {
+	Version      string       `json:"version"`
+	Jurisdiction Jurisdiction `json:"jurisdiction"`
+}
+
+// Jurisdictions are versioned: every change is recorded as a new version with the time it was made,
+// and reads return the latest version as of the Effective time.
+type ListJurisdictionsParams struct {
+	Effective time.Time
+}
+
+type FetchJurisdictionParams struct {
+	JurisdictionID uuid.UUID
+	Effective      time.Time
+}
+
+type JurisdictionRepository interface {
+	ListJurisdictions(ctx context.Context, params ListJurisdictionsParams) ([]Jurisdiction, error)
+	FetchJurisdiction(ctx context.Context, params FetchJurisdictionParams) (Jurisdiction, error)
+	InsertJurisdiction(ctx context.Context, jurisdiction Jurisdiction) error
+	InsertJurisdictionVersion(ctx context.Context, jurisdiction Jurisdiction) error
+}
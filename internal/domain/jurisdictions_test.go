@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Jurisdiction", func() {
+	var jurisdiction Jurisdiction
+	BeforeEach(func() {
+		jurisdiction = Jurisdiction{
+			JurisdictionID: uuid.MustParse("5e4d3c2b-1a09-4f8e-b7d6-c5b4a3928170"),
+			AgencyKey:      "pdx",
+			AgencyName:     "City of Portland",
+			GeographyID:    uuid.MustParse("0d5c4b3a-2918-4f7e-a6d5-c4b3a2918f7e"),
+		}
+	})
+
+	It("is valid w/ all required fields", func() {
+		Expect(ValidateJurisdiction(jurisdiction)).To(BeEmpty())
+	})
+
+	It("requires agency_key and agency_name", func() {
+		jurisdiction.AgencyKey = ""
+		jurisdiction.AgencyName = ""
+		Expect(ValidateJurisdiction(jurisdiction)).To(ConsistOf(
+			"agency_key: missing required field",
+			"agency_name: missing required field",
+		))
+	})
+
+	It("does not allow a jurisdiction to replace itself", func() {
+		jurisdiction.PrevJurisdictions = []uuid.UUID{jurisdiction.JurisdictionID}
+		Expect(ValidateJurisdiction(jurisdiction)).To(ConsistOf("prev_jurisdictions: a jurisdiction cannot replace itself"))
+	})
+})
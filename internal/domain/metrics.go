@@ -0,0 +1,529 @@
+//go:generate go run github.com/abice/go-enum@v0.5.6 --marshal --sql
+
+package domain
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/exp/constraints"
+)
+
+// ENUM(provider_id, vehicle_type, geography_id)
+type MetricDimension int
+
+const (
+	MeasureVehiclesDeployed    = "vehicles.deployed"
+	MeasureVehiclesAvailable   = "vehicles.available"
+	MeasureVehiclesUnavailable = "vehicles.unavailable"
+	MeasureVehiclesReserved    = "vehicles.reserved"
+	MeasureVehiclesDead        = "vehicles.dead"
+	MeasureTripsCount          = "trips.count"
+	MeasureTripsDuration       = "trips.duration"
+	MeasureTripsDistance       = "trips.distance"
+)
+
+type MetricDefinition struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	DataType    string `json:"data_type"`
+}
+
+var MetricDefinitions = []MetricDefinition{
+	{MeasureVehiclesDeployed, "Number of vehicles in the public right of way at any point during the interval", "integer"},
+	{MeasureVehiclesAvailable, "Number of vehicles available for rent at any point during the interval", "integer"},
+	{MeasureVehiclesUnavailable, "Number of vehicles that were non-operational at any point during the interval", "integer"},
+	{MeasureVehiclesReserved, "Number of vehicles reserved at any point during the interval", "integer"},
+	{MeasureVehiclesDead, "Number of deployed vehicles that did not start a trip during the interval", "integer"},
+	{MeasureTripsCount, "Number of trips that started during the interval", "integer"},
+	{MeasureTripsDuration, "Total duration in seconds of trips that started during the interval", "integer"},
+	{MeasureTripsDistance, "Total distance in meters of trips that started during the interval", "integer"},
+}
+
+var tripMeasures = NewSet(MeasureTripsCount, MeasureTripsDuration, MeasureTripsDistance)
+
+var vehicleMeasures = NewSet(
+	MeasureVehiclesDeployed,
+	MeasureVehiclesAvailable,
+	MeasureVehiclesUnavailable,
+	MeasureVehiclesReserved,
+	MeasureVehiclesDead,
+)
+
+// deployedVehicleStates are the states in which a vehicle is in the public right of way.
+var deployedVehicleStates = NewSet(
+	VehicleStateAvailable,
+	VehicleStateNonOperational,
+	VehicleStateReserved,
+	VehicleStateOnTrip,
+	VehicleStateStopped,
+)
+
+type MetricInterval struct {
+	Name     string
+	Duration time.Duration
+}
+
+// MetricIntervals are the ISO 8601 durations metrics can be aggregated over. Calendar intervals
+// such as months are not supported since their length varies.
+var MetricIntervals = []MetricInterval{
+	{"PT15M", 15 * time.Minute},
+	{"PT1H", time.Hour},
+	{"P1D", 24 * time.Hour},
+	{"P1W", 7 * 24 * time.Hour},
+}
+
+// maxMetricIntervals limits the number of intervals a single query may span.
+const maxMetricIntervals = 2000
+
+func parseMetricInterval(name string) (time.Duration, bool) {
+	for _, interval := range MetricIntervals {
+		if interval.Name == name {
+			return interval.Duration, true
+		}
+	}
+	return 0, false
+}
+
+type MetricsFilter struct {
+	Name   MetricDimension `json:"name"`
+	Values []string        `json:"values"`
+}
+
+type MetricsQuery struct {
+	Measures   []string          `json:"measures"`
+	Interval   string            `json:"interval"`
+	StartDate  Timestamp         `json:"start_date"`
+	EndDate    Timestamp         `json:"end_date"`
+	Dimensions []MetricDimension `json:"dimensions"`
+	Filters    []MetricsFilter   `json:"filters"`
+}
+
+func validateMetricsFilterValue(dimension MetricDimension, value string) error {
+	var err error
+	switch dimension {
+	case MetricDimensionProviderId, MetricDimensionGeographyId:
+		_, err = uuid.Parse(value)
+	case MetricDimensionVehicleType:
+		_, err = ParseVehicleType(value)
+	}
+	return err
+}
+
+func ValidateMetricsQuery(value any) []string {
+	var errs []string
+	switch q := value.(type) {
+	case MetricsQuery:
+		if len(q.Measures) == 0 {
+			errs = append(errs, "measures: must contain at least one measure")
+		}
+		for _, measure := range q.Measures {
+			if !tripMeasures.Contains(measure) && !vehicleMeasures.Contains(measure) {
+				errs = append(errs, fmt.Sprintf("measures: unsupported measure %q", measure))
+			}
+		}
+		interval, ok := parseMetricInterval(q.Interval)
+		if !ok {
+			errs = append(errs, fmt.Sprintf("interval: unsupported interval %q", q.Interval))
+		}
+		if q.StartDate.IsZero() {
+			errs = append(errs, "start_date: missing required field")
+		}
+		if q.EndDate.IsZero() {
+			errs = append(errs, "end_date: missing required field")
+		}
+		if !q.EndDate.After(q.StartDate.Time) {
+			errs = append(errs, "end_date: must be after start_date")
+		} else if ok && q.EndDate.Sub(q.StartDate.Time)/interval > maxMetricIntervals {
+			errs = append(errs, fmt.Sprintf("end_date: queries may span at most %d intervals", maxMetricIntervals))
+		}
+		for _, filter := range q.Filters {
+			for _, value := range filter.Values {
+				if validateMetricsFilterValue(filter.Name, value) != nil {
+					errs = append(errs, fmt.Sprintf("filters: invalid %s %q", filter.Name, value))
+				}
+			}
+		}
+	default:
+		panic("cannot validate unknown type")
+	}
+	return errs
+}
+
+// RestrictedToProvider limits the query to a single provider's data, replacing any filters
+// on provider_id.
+func (q MetricsQuery) RestrictedToProvider(providerID uuid.UUID) MetricsQuery {
+	filters := []MetricsFilter{{Name: MetricDimensionProviderId, Values: []string{providerID.String()}}}
+	for _, filter := range q.Filters {
+		if filter.Name != MetricDimensionProviderId {
+			filters = append(filters, filter)
+		}
+	}
+	q.Filters = filters
+	return q
+}
+
+func (q MetricsQuery) hasMeasureIn(measures Set[string]) bool {
+	for _, measure := range q.Measures {
+		if measures.Contains(measure) {
+			return true
+		}
+	}
+	return false
+}
+
+func (q MetricsQuery) NeedsTrips() bool {
+	return q.hasMeasureIn(tripMeasures)
+}
+
+func (q MetricsQuery) NeedsVehicleEvents() bool {
+	return q.hasMeasureIn(vehicleMeasures)
+}
+
+// ProviderIDs returns the providers the query is filtered to, or nil if it covers all providers.
+func (q MetricsQuery) ProviderIDs() []uuid.UUID {
+	var providerIDs []uuid.UUID
+	for _, filter := range q.Filters {
+		if filter.Name != MetricDimensionProviderId {
+			continue
+		}
+		// Filters on the same dimension are combined, so an empty filter selects nothing.
+		providerIDs = []uuid.UUID{}
+		for _, value := range filter.Values {
+			providerIDs = append(providerIDs, uuid.MustParse(value))
+		}
+	}
+	return providerIDs
+}
+
+// TripFact is the subset of a trip that trip metrics are computed from. A trip is located in the
+// geographies of the trip_start event reported for it.
+type TripFact struct {
+	TripID       uuid.UUID
+	ProviderID   uuid.UUID
+	VehicleType  VehicleType
+	StartTime    time.Time
+	Duration     int
+	Distance     int
+	GeographyIDs []uuid.UUID
+}
+
+// VehicleEventFact is the subset of an event that vehicle metrics are computed from.
+type VehicleEventFact struct {
+	DeviceID     uuid.UUID
+	ProviderID   uuid.UUID
+	VehicleType  VehicleType
+	Timestamp    time.Time
+	VehicleState VehicleState
+	EventTypes   []EventType
+	GeographyIDs []uuid.UUID
+}
+
+type MetricsColumn struct {
+	Name       string `json:"name"`
+	ColumnType string `json:"column_type"`
+	DataType   string `json:"data_type"`
+}
+
+type MetricsResult struct {
+	Version string `json:"version"`
+	MetricsQuery
+	Columns []MetricsColumn `json:"columns"`
+	Rows    [][]any         `json:"rows"`
+}
+
+type MetricsDiscoveryResponse struct {
+	Version    string             `json:"version"`
+	Metrics    []MetricDefinition `json:"metrics"`
+	Intervals  []string           `json:"intervals"`
+	Dimensions []string           `json:"dimensions"`
+}
+
+func NewMetricsDiscoveryResponse() MetricsDiscoveryResponse {
+	intervals := make([]string, 0, len(MetricIntervals))
+	for _, interval := range MetricIntervals {
+		intervals = append(intervals, interval.Name)
+	}
+	return MetricsDiscoveryResponse{
+		Version:    "2.0.0",
+		Metrics:    MetricDefinitions,
+		Intervals:  intervals,
+		Dimensions: Stringify([]MetricDimension{MetricDimensionProviderId, MetricDimensionVehicleType, MetricDimensionGeographyId}),
+	}
+}
+
+// dimensionValues holds a value for each MetricDimension, indexed by the dimension.
+type dimensionValues [3]string
+
+type metricsKey struct {
+	interval   int
+	dimensions dimensionValues
+}
+
+type metricsAccumulator struct {
+	query      MetricsQuery
+	start      time.Time
+	interval   time.Duration
+	nIntervals int
+	dimensions Set[MetricDimension]
+	filters    map[MetricDimension]Set[string]
+	values     map[metricsKey]map[string]int
+}
+
+func newMetricsAccumulator(query MetricsQuery) *metricsAccumulator {
+	interval, _ := parseMetricInterval(query.Interval)
+	span := query.EndDate.Sub(query.StartDate.Time)
+	nIntervals := int(span / interval)
+	if span%interval != 0 {
+		nIntervals += 1
+	}
+	filters := make(map[MetricDimension]Set[string])
+	for _, filter := range query.Filters {
+		values := NewSet(filter.Values...)
+		if existing, ok := filters[filter.Name]; ok {
+			values = intersect(existing, values)
+		}
+		filters[filter.Name] = values
+	}
+	return &metricsAccumulator{
+		query:      query,
+		start:      query.StartDate.Time,
+		interval:   interval,
+		nIntervals: nIntervals,
+		dimensions: NewSet(query.Dimensions...),
+		filters:    filters,
+		values:     make(map[metricsKey]map[string]int),
+	}
+}
+
+func intersect[T constraints.Ordered](a Set[T], b Set[T]) Set[T] {
+	items := Set[T]{}
+	for _, item := range a {
+		if b.Contains(item) {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func (acc *metricsAccumulator) matches(dimension MetricDimension, value string) bool {
+	values, ok := acc.filters[dimension]
+	return !ok || values.Contains(value)
+}
+
+func (acc *metricsAccumulator) intervalIndex(t time.Time) (int, bool) {
+	if t.Before(acc.start) {
+		return 0, false
+	}
+	i := int(t.Sub(acc.start) / acc.interval)
+	return i, i < acc.nIntervals
+}
+
+func (acc *metricsAccumulator) intervalBounds(i int) (time.Time, time.Time) {
+	start := acc.start.Add(time.Duration(i) * acc.interval)
+	end := start.Add(acc.interval)
+	if end.After(acc.query.EndDate.Time) {
+		end = acc.query.EndDate.Time
+	}
+	return start, end
+}
+
+// dimensionKeys returns the combinations of requested dimension values that a fact contributes
+// to, or nothing if the fact is excluded by the query's filters. A fact located in several
+// geographies contributes to each of them when grouping by geography_id.
+func (acc *metricsAccumulator) dimensionKeys(providerID uuid.UUID, vehicleType VehicleType, geographyIDs []uuid.UUID) []dimensionValues {
+	var values dimensionValues
+	values[MetricDimensionProviderId] = providerID.String()
+	values[MetricDimensionVehicleType] = vehicleType.String()
+	if !acc.matches(MetricDimensionProviderId, values[MetricDimensionProviderId]) || !acc.matches(MetricDimensionVehicleType, values[MetricDimensionVehicleType]) {
+		return nil
+	}
+	var geographies []string
+	for _, geographyID := range geographyIDs {
+		if acc.matches(MetricDimensionGeographyId, geographyID.String()) {
+			geographies = append(geographies, geographyID.String())
+		}
+	}
+	geographies = NewSet(geographies...)
+	if _, filtered := acc.filters[MetricDimensionGeographyId]; filtered && len(geographies) == 0 {
+		return nil
+	}
+
+	for _, dimension := range []MetricDimension{MetricDimensionProviderId, MetricDimensionVehicleType} {
+		if !acc.dimensions.Contains(dimension) {
+			values[dimension] = ""
+		}
+	}
+	if !acc.dimensions.Contains(MetricDimensionGeographyId) {
+		return []dimensionValues{values}
+	}
+	keys := make([]dimensionValues, 0, len(geographies))
+	for _, geography := range geographies {
+		key := values
+		key[MetricDimensionGeographyId] = geography
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func (acc *metricsAccumulator) add(interval int, dimensions dimensionValues, measure string, value int) {
+	key := metricsKey{interval, dimensions}
+	measures, ok := acc.values[key]
+	if !ok {
+		measures = make(map[string]int)
+		acc.values[key] = measures
+	}
+	measures[measure] += value
+}
+
+func (acc *metricsAccumulator) addTrip(trip TripFact) {
+	i, ok := acc.intervalIndex(trip.StartTime)
+	if !ok {
+		return
+	}
+	for _, key := range acc.dimensionKeys(trip.ProviderID, trip.VehicleType, trip.GeographyIDs) {
+		acc.add(i, key, MeasureTripsCount, 1)
+		acc.add(i, key, MeasureTripsDuration, trip.Duration)
+		acc.add(i, key, MeasureTripsDistance, trip.Distance)
+	}
+}
+
+// addVehicle counts a vehicle in each interval it was in a measured state. Events must be sorted
+// by timestamp, and should include the last event before the start of the query so that the
+// vehicle's state at the start of the first interval is known.
+func (acc *metricsAccumulator) addVehicle(events []VehicleEventFact) {
+	var current *VehicleEventFact
+	next := 0
+	for i := 0; i < acc.nIntervals; i++ {
+		intervalStart, intervalEnd := acc.intervalBounds(i)
+		for next < len(events) && events[next].Timestamp.Before(intervalStart) {
+			current = &events[next]
+			next += 1
+		}
+
+		var states []VehicleState
+		var geographies []uuid.UUID
+		startedTrip := false
+		if current != nil {
+			states = append(states, current.VehicleState)
+			geographies = append(geographies, current.GeographyIDs...)
+		}
+		for ; next < len(events) && events[next].Timestamp.Before(intervalEnd); next++ {
+			current = &events[next]
+			states = append(states, current.VehicleState)
+			geographies = append(geographies, current.GeographyIDs...)
+			for _, eventType := range current.EventTypes {
+				if eventType == EventTypeTripStart {
+					startedTrip = true
+				}
+			}
+		}
+		if current == nil {
+			continue
+		}
+
+		stateSet := NewSet(states...)
+		deployed := len(intersect(stateSet, deployedVehicleStates)) > 0
+		measures := map[string]bool{
+			MeasureVehiclesDeployed:    deployed,
+			MeasureVehiclesAvailable:   stateSet.Contains(VehicleStateAvailable),
+			MeasureVehiclesUnavailable: stateSet.Contains(VehicleStateNonOperational),
+			MeasureVehiclesReserved:    stateSet.Contains(VehicleStateReserved),
+			MeasureVehiclesDead:        deployed && !startedTrip && !stateSet.Contains(VehicleStateOnTrip),
+		}
+		for _, key := range acc.dimensionKeys(current.ProviderID, current.VehicleType, geographies) {
+			for measure, counted := range measures {
+				if counted {
+					acc.add(i, key, measure, 1)
+				}
+			}
+		}
+	}
+}
+
+func (acc *metricsAccumulator) result() MetricsResult {
+	columns := []MetricsColumn{{Name: "start_date", ColumnType: "dimension", DataType: "timestamp"}}
+	for _, dimension := range acc.query.Dimensions {
+		dataType := "uuid"
+		if dimension == MetricDimensionVehicleType {
+			dataType = "string"
+		}
+		columns = append(columns, MetricsColumn{Name: dimension.String(), ColumnType: "dimension", DataType: dataType})
+	}
+	for _, measure := range acc.query.Measures {
+		columns = append(columns, MetricsColumn{Name: measure, ColumnType: "measure", DataType: "integer"})
+	}
+
+	keysByInterval := make([][]dimensionValues, acc.nIntervals)
+	for key := range acc.values {
+		keysByInterval[key.interval] = append(keysByInterval[key.interval], key.dimensions)
+	}
+	rows := [][]any{}
+	for i, keys := range keysByInterval {
+		// Without dimensions there's exactly one row per interval, even if nothing was measured.
+		if len(acc.query.Dimensions) == 0 && len(keys) == 0 {
+			keys = []dimensionValues{{}}
+		}
+		sort.Slice(keys, func(a, b int) bool {
+			for d := range keys[a] {
+				if keys[a][d] != keys[b][d] {
+					return keys[a][d] < keys[b][d]
+				}
+			}
+			return false
+		})
+		intervalStart, _ := acc.intervalBounds(i)
+		for _, key := range keys {
+			row := []any{NewTimestamp(intervalStart)}
+			for _, dimension := range acc.query.Dimensions {
+				row = append(row, key[dimension])
+			}
+			measures := acc.values[metricsKey{i, key}]
+			for _, measure := range acc.query.Measures {
+				row = append(row, measures[measure])
+			}
+			rows = append(rows, row)
+		}
+	}
+
+	return MetricsResult{
+		Version:      "2.0.0",
+		MetricsQuery: acc.query,
+		Columns:      columns,
+		Rows:         rows,
+	}
+}
+
+// ComputeMetrics aggregates trips and vehicle events into the measures requested by a valid query.
+func ComputeMetrics(query MetricsQuery, trips []TripFact, vehicleEvents []VehicleEventFact) MetricsResult {
+	acc := newMetricsAccumulator(query)
+	for _, trip := range trips {
+		acc.addTrip(trip)
+	}
+
+	eventsByVehicle := make(map[uuid.UUID][]VehicleEventFact)
+	for _, event := range vehicleEvents {
+		eventsByVehicle[event.DeviceID] = append(eventsByVehicle[event.DeviceID], event)
+	}
+	for _, events := range eventsByVehicle {
+		sort.SliceStable(events, func(i, j int) bool {
+			return events[i].Timestamp.Before(events[j].Timestamp)
+		})
+		acc.addVehicle(events)
+	}
+
+	return acc.result()
+}
+
+type MetricsFactsParams struct {
+	Range       TimeRange
+	ProviderIDs []uuid.UUID
+}
+
+type MetricsRepository interface {
+	ListTripFacts(ctx context.Context, params MetricsFactsParams) ([]TripFact, error)
+	ListVehicleEventFacts(ctx context.Context, params MetricsFactsParams) ([]VehicleEventFact, error)
+}
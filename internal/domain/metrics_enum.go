@@ -0,0 +1,150 @@
+// Code generated by go-enum DO NOT EDIT.
+// Version:
+// Revision:
+// Build Date:
+// Built By:
+
+package domain
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+)
+
+const (
+	// MetricDimensionProviderId is a MetricDimension of type Provider_id.
+	MetricDimensionProviderId MetricDimension = iota
+	// MetricDimensionVehicleType is a MetricDimension of type Vehicle_type.
+	MetricDimensionVehicleType
+	// MetricDimensionGeographyId is a MetricDimension of type Geography_id.
+	MetricDimensionGeographyId
+)
+
+var ErrInvalidMetricDimension = errors.New("not a valid MetricDimension")
+
+const _MetricDimensionName = "provider_idvehicle_typegeography_id"
+
+var _MetricDimensionMap = map[MetricDimension]string{
+	MetricDimensionProviderId:  _MetricDimensionName[0:11],
+	MetricDimensionVehicleType: _MetricDimensionName[11:23],
+	MetricDimensionGeographyId: _MetricDimensionName[23:35],
+}
+
+// String implements the Stringer interface.
+func (x MetricDimension) String() string {
+	if str, ok := _MetricDimensionMap[x]; ok {
+		return str
+	}
+	return fmt.Sprintf("MetricDimension(%d)", x)
+}
+
+// IsValid provides a quick way to determine if the typed value is
+// part of the allowed enumerated values
+func (x MetricDimension) IsValid() bool {
+	_, ok := _MetricDimensionMap[x]
+	return ok
+}
+
+var _MetricDimensionValue = map[string]MetricDimension{
+	_MetricDimensionName[0:11]:  MetricDimensionProviderId,
+	_MetricDimensionName[11:23]: MetricDimensionVehicleType,
+	_MetricDimensionName[23:35]: MetricDimensionGeographyId,
+}
+
+// ParseMetricDimension attempts to convert a string to a MetricDimension.
+func ParseMetricDimension(name string) (MetricDimension, error) {
+	if x, ok := _MetricDimensionValue[name]; ok {
+		return x, nil
+	}
+	return MetricDimension(0), fmt.Errorf("%s is %w", name, ErrInvalidMetricDimension)
+}
+
+// MarshalText implements the text marshaller method.
+func (x MetricDimension) MarshalText() ([]byte, error) {
+	return []byte(x.String()), nil
+}
+
+// UnmarshalText implements the text unmarshaller method.
+func (x *MetricDimension) UnmarshalText(text []byte) error {
+	name := string(text)
+	tmp, err := ParseMetricDimension(name)
+	if err != nil {
+		return err
+	}
+	*x = tmp
+	return nil
+}
+
+var errMetricDimensionNilPtr = errors.New("value pointer is nil") // one per type for package clashes
+
+// Scan implements the Scanner interface.
+func (x *MetricDimension) Scan(value interface{}) (err error) {
+	if value == nil {
+		*x = MetricDimension(0)
+		return
+	}
+
+	// A wider range of scannable types.
+	// driver.Value values at the top of the list for expediency
+	switch v := value.(type) {
+	case int64:
+		*x = MetricDimension(v)
+	case string:
+		*x, err = ParseMetricDimension(v)
+	case []byte:
+		*x, err = ParseMetricDimension(string(v))
+	case MetricDimension:
+		*x = v
+	case int:
+		*x = MetricDimension(v)
+	case *MetricDimension:
+		if v == nil {
+			return errMetricDimensionNilPtr
+		}
+		*x = *v
+	case uint:
+		*x = MetricDimension(v)
+	case uint64:
+		*x = MetricDimension(v)
+	case *int:
+		if v == nil {
+			return errMetricDimensionNilPtr
+		}
+		*x = MetricDimension(*v)
+	case *int64:
+		if v == nil {
+			return errMetricDimensionNilPtr
+		}
+		*x = MetricDimension(*v)
+	case float64: // json marshals everything as a float64 if it's a number
+		*x = MetricDimension(v)
+	case *float64: // json marshals everything as a float64 if it's a number
+		if v == nil {
+			return errMetricDimensionNilPtr
+		}
+		*x = MetricDimension(*v)
+	case *uint:
+		if v == nil {
+			return errMetricDimensionNilPtr
+		}
+		*x = MetricDimension(*v)
+	case *uint64:
+		if v == nil {
+			return errMetricDimensionNilPtr
+		}
+		*x = MetricDimension(*v)
+	case *string:
+		if v == nil {
+			return errMetricDimensionNilPtr
+		}
+		*x, err = ParseMetricDimension(*v)
+	}
+
+	return
+}
+
+// Value implements the driver Valuer interface.
+func (x MetricDimension) Value() (driver.Value, error) {
+	return x.String(), nil
+}
@@ -0,0 +1,168 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("MetricsQuery", func() {
+	var query MetricsQuery
+	BeforeEach(func() {
+		query = MetricsQuery{
+			Measures:  []string{MeasureTripsCount},
+			Interval:  "PT1H",
+			StartDate: NewTimestamp(time.Date(2023, time.July, 20, 0, 0, 0, 0, time.UTC)),
+			EndDate:   NewTimestamp(time.Date(2023, time.July, 21, 0, 0, 0, 0, time.UTC)),
+		}
+	})
+
+	It("is valid w/ all required fields", func() {
+		Expect(ValidateMetricsQuery(query)).To(BeEmpty())
+	})
+
+	It("rejects unsupported measures and intervals", func() {
+		query.Measures = []string{"trips.vibes"}
+		query.Interval = "P1M"
+		Expect(ValidateMetricsQuery(query)).To(ConsistOf(
+			`measures: unsupported measure "trips.vibes"`,
+			`interval: unsupported interval "P1M"`,
+		))
+	})
+
+	It("requires end_date to be after start_date", func() {
+		query.EndDate = query.StartDate
+		Expect(ValidateMetricsQuery(query)).To(ConsistOf("end_date: must be after start_date"))
+	})
+
+	It("limits the number of intervals a query spans", func() {
+		query.Interval = "PT15M"
+		query.EndDate = NewTimestamp(query.StartDate.AddDate(1, 0, 0))
+		Expect(ValidateMetricsQuery(query)).To(ConsistOf("end_date: queries may span at most 2000 intervals"))
+	})
+
+	It("rejects malformed filter values", func() {
+		query.Filters = []MetricsFilter{
+			{Name: MetricDimensionProviderId, Values: []string{"not-a-uuid"}},
+			{Name: MetricDimensionVehicleType, Values: []string{"hovercraft"}},
+		}
+		Expect(ValidateMetricsQuery(query)).To(ConsistOf(
+			`filters: invalid provider_id "not-a-uuid"`,
+			`filters: invalid vehicle_type "hovercraft"`,
+		))
+	})
+
+	It("can be restricted to a single provider", func() {
+		providerID := uuid.New()
+		query.Filters = []MetricsFilter{
+			{Name: MetricDimensionProviderId, Values: []string{uuid.NewString()}},
+			{Name: MetricDimensionVehicleType, Values: []string{"bicycle"}},
+		}
+		Expect(query.RestrictedToProvider(providerID).Filters).To(ConsistOf(
+			MetricsFilter{Name: MetricDimensionProviderId, Values: []string{providerID.String()}},
+			MetricsFilter{Name: MetricDimensionVehicleType, Values: []string{"bicycle"}},
+		))
+		Expect(query.RestrictedToProvider(providerID).ProviderIDs()).To(Equal([]uuid.UUID{providerID}))
+	})
+})
+
+var _ = Describe("ComputeMetrics", func() {
+	start := time.Date(2023, time.July, 20, 0, 0, 0, 0, time.UTC)
+	providerA := uuid.MustParse("3c95765d-4da6-41c6-b61e-1954472ec6c9")
+	providerB := uuid.MustParse("9e2b7a1c-5f3d-4b8e-a6c0-d1e2f3a4b5c6")
+	geography := uuid.MustParse("0d5c4b3a-2918-4f7e-a6d5-c4b3a2918f7e")
+	vehicle := uuid.MustParse("7b1f3c52-8e4d-4a6f-9c2b-1d0e5f6a7b8c")
+
+	var query MetricsQuery
+	BeforeEach(func() {
+		query = MetricsQuery{
+			Interval:  "PT1H",
+			StartDate: NewTimestamp(start),
+			EndDate:   NewTimestamp(start.Add(2 * time.Hour)),
+		}
+	})
+
+	Describe("trip measures", func() {
+		trips := []TripFact{
+			{TripID: uuid.New(), ProviderID: providerA, VehicleType: VehicleTypeBicycle, StartTime: start.Add(10 * time.Minute), Duration: 600, Distance: 2000, GeographyIDs: []uuid.UUID{geography}},
+			{TripID: uuid.New(), ProviderID: providerA, VehicleType: VehicleTypeScooterStanding, StartTime: start.Add(20 * time.Minute), Duration: 300, Distance: 1000},
+			{TripID: uuid.New(), ProviderID: providerB, VehicleType: VehicleTypeBicycle, StartTime: start.Add(70 * time.Minute), Duration: 900, Distance: 3000, GeographyIDs: []uuid.UUID{geography}},
+			{TripID: uuid.New(), ProviderID: providerB, VehicleType: VehicleTypeBicycle, StartTime: start.Add(3 * time.Hour), Duration: 900, Distance: 3000},
+		}
+
+		BeforeEach(func() {
+			query.Measures = []string{MeasureTripsCount, MeasureTripsDuration, MeasureTripsDistance}
+		})
+
+		It("aggregates trips by the interval they started in", func() {
+			result := ComputeMetrics(query, trips, nil)
+			Expect(result.Columns).To(HaveLen(4))
+			Expect(result.Rows).To(Equal([][]any{
+				{NewTimestamp(start), 2, 900, 3000},
+				{NewTimestamp(start.Add(time.Hour)), 1, 900, 3000},
+			}))
+		})
+
+		It("groups by the requested dimensions", func() {
+			query.Dimensions = []MetricDimension{MetricDimensionProviderId, MetricDimensionVehicleType}
+			query.Measures = []string{MeasureTripsCount}
+			result := ComputeMetrics(query, trips, nil)
+			Expect(result.Rows).To(Equal([][]any{
+				{NewTimestamp(start), providerA.String(), "bicycle", 1},
+				{NewTimestamp(start), providerA.String(), "scooter_standing", 1},
+				{NewTimestamp(start.Add(time.Hour)), providerB.String(), "bicycle", 1},
+			}))
+		})
+
+		It("applies filters", func() {
+			query.Measures = []string{MeasureTripsCount}
+			query.Filters = []MetricsFilter{{Name: MetricDimensionGeographyId, Values: []string{geography.String()}}}
+			result := ComputeMetrics(query, trips, nil)
+			Expect(result.Rows).To(Equal([][]any{
+				{NewTimestamp(start), 1},
+				{NewTimestamp(start.Add(time.Hour)), 1},
+			}))
+		})
+	})
+
+	Describe("vehicle measures", func() {
+		event := func(offset time.Duration, state VehicleState, eventTypes ...EventType) VehicleEventFact {
+			return VehicleEventFact{
+				DeviceID:     vehicle,
+				ProviderID:   providerA,
+				VehicleType:  VehicleTypeBicycle,
+				Timestamp:    start.Add(offset),
+				VehicleState: state,
+				EventTypes:   eventTypes,
+			}
+		}
+
+		BeforeEach(func() {
+			query.Measures = []string{MeasureVehiclesDeployed, MeasureVehiclesAvailable, MeasureVehiclesReserved, MeasureVehiclesDead}
+		})
+
+		It("carries a vehicle's state across intervals", func() {
+			events := []VehicleEventFact{event(-time.Hour, VehicleStateAvailable, EventTypeOnHours)}
+			result := ComputeMetrics(query, nil, events)
+			Expect(result.Rows).To(Equal([][]any{
+				{NewTimestamp(start), 1, 1, 0, 1},
+				{NewTimestamp(start.Add(time.Hour)), 1, 1, 0, 1},
+			}))
+		})
+
+		It("counts every state a vehicle was in during an interval", func() {
+			events := []VehicleEventFact{
+				event(10*time.Minute, VehicleStateReserved, EventTypeReservationStart),
+				event(20*time.Minute, VehicleStateOnTrip, EventTypeTripStart),
+				event(30*time.Minute, VehicleStateRemoved, EventTypeTripEnd, EventTypeRebalancePickUp),
+			}
+			result := ComputeMetrics(query, nil, events)
+			Expect(result.Rows).To(Equal([][]any{
+				{NewTimestamp(start), 1, 0, 1, 0},
+				{NewTimestamp(start.Add(time.Hour)), 0, 0, 0, 0},
+			}))
+		})
+	})
+})
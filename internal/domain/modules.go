@@ -0,0 +1,13 @@
+//go:generate go run github.com/abice/go-enum@v0.5.6 --marshal --sql
+
+package domain
+
+// Module is an MDS API that can be enabled or disabled as a whole. The agency module includes
+// stops and reports.
+// ENUM(agency, provider, policy, geography, jurisdiction, metrics)
+type Module int
+
+// AllModules returns every module.
+func AllModules() []Module {
+	return []Module{ModuleAgency, ModuleProvider, ModulePolicy, ModuleGeography, ModuleJurisdiction, ModuleMetrics}
+}
@@ -0,0 +1,162 @@
+// Code generated by go-enum DO NOT EDIT.
+// Version:
+// Revision:
+// Build Date:
+// Built By:
+
+package domain
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+)
+
+const (
+	// ModuleAgency is a Module of type Agency.
+	ModuleAgency Module = iota
+	// ModuleProvider is a Module of type Provider.
+	ModuleProvider
+	// ModulePolicy is a Module of type Policy.
+	ModulePolicy
+	// ModuleGeography is a Module of type Geography.
+	ModuleGeography
+	// ModuleJurisdiction is a Module of type Jurisdiction.
+	ModuleJurisdiction
+	// ModuleMetrics is a Module of type Metrics.
+	ModuleMetrics
+)
+
+var ErrInvalidModule = errors.New("not a valid Module")
+
+const _ModuleName = "agencyproviderpolicygeographyjurisdictionmetrics"
+
+var _ModuleMap = map[Module]string{
+	ModuleAgency:       _ModuleName[0:6],
+	ModuleProvider:     _ModuleName[6:14],
+	ModulePolicy:       _ModuleName[14:20],
+	ModuleGeography:    _ModuleName[20:29],
+	ModuleJurisdiction: _ModuleName[29:41],
+	ModuleMetrics:      _ModuleName[41:48],
+}
+
+// String implements the Stringer interface.
+func (x Module) String() string {
+	if str, ok := _ModuleMap[x]; ok {
+		return str
+	}
+	return fmt.Sprintf("Module(%d)", x)
+}
+
+// IsValid provides a quick way to determine if the typed value is
+// part of the allowed enumerated values
+func (x Module) IsValid() bool {
+	_, ok := _ModuleMap[x]
+	return ok
+}
+
+var _ModuleValue = map[string]Module{
+	_ModuleName[0:6]:   ModuleAgency,
+	_ModuleName[6:14]:  ModuleProvider,
+	_ModuleName[14:20]: ModulePolicy,
+	_ModuleName[20:29]: ModuleGeography,
+	_ModuleName[29:41]: ModuleJurisdiction,
+	_ModuleName[41:48]: ModuleMetrics,
+}
+
+// ParseModule attempts to convert a string to a Module.
+func ParseModule(name string) (Module, error) {
+	if x, ok := _ModuleValue[name]; ok {
+		return x, nil
+	}
+	return Module(0), fmt.Errorf("%s is %w", name, ErrInvalidModule)
+}
+
+// MarshalText implements the text marshaller method.
+func (x Module) MarshalText() ([]byte, error) {
+	return []byte(x.String()), nil
+}
+
+// UnmarshalText implements the text unmarshaller method.
+func (x *Module) UnmarshalText(text []byte) error {
+	name := string(text)
+	tmp, err := ParseModule(name)
+	if err != nil {
+		return err
+	}
+	*x = tmp
+	return nil
+}
+
+var errModuleNilPtr = errors.New("value pointer is nil") // one per type for package clashes
+
+// Scan implements the Scanner interface.
+func (x *Module) Scan(value interface{}) (err error) {
+	if value == nil {
+		*x = Module(0)
+		return
+	}
+
+	// A wider range of scannable types.
+	// driver.Value values at the top of the list for expediency
+	switch v := value.(type) {
+	case int64:
+		*x = Module(v)
+	case string:
+		*x, err = ParseModule(v)
+	case []byte:
+		*x, err = ParseModule(string(v))
+	case Module:
+		*x = v
+	case int:
+		*x = Module(v)
+	case *Module:
+		if v == nil {
+			return errModuleNilPtr
+		}
+		*x = *v
+	case uint:
+		*x = Module(v)
+	case uint64:
+		*x = Module(v)
+	case *int:
+		if v == nil {
+			return errModuleNilPtr
+		}
+		*x = Module(*v)
+	case *int64:
+		if v == nil {
+			return errModuleNilPtr
+		}
+		*x = Module(*v)
+	case float64: // json marshals everything as a float64 if it's a number
+		*x = Module(v)
+	case *float64: // json marshals everything as a float64 if it's a number
+		if v == nil {
+			return errModuleNilPtr
+		}
+		*x = Module(*v)
+	case *uint:
+		if v == nil {
+			return errModuleNilPtr
+		}
+		*x = Module(*v)
+	case *uint64:
+		if v == nil {
+			return errModuleNilPtr
+		}
+		*x = Module(*v)
+	case *string:
+		if v == nil {
+			return errModuleNilPtr
+		}
+		*x, err = ParseModule(*v)
+	}
+
+	return
+}
+
+// Value implements the driver Valuer interface.
+func (x Module) Value() (driver.Value, error) {
+	return x.String(), nil
+}
@@ -1,5 +1,9 @@
 package domain
 
+// DefaultMaxPageSize is the largest page[limit] accepted by paginated endpoints unless configured
+// otherwise.
+const DefaultMaxPageSize = 20
+
 type Page[T any] struct {
 	Items []T
 	Total int64
@@ -0,0 +1,214 @@
+//go:generate go run github.com/abice/go-enum@v0.5.6 --marshal --sql
+
+package domain
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ENUM(unknown, count, time, speed, rate, user)
+type RuleType int
+
+// ENUM(unspecified, seconds, minutes, hours, days, mph, kph)
+type RuleUnits int
+
+// ENUM(sun, mon, tue, wed, thu, fri, sat)
+type Day int
+
+// ENUM(unspecified, once_on_match, once_on_unmatch, each_time_unit, per_complete_time_unit)
+type RateRecurrence int
+
+// ENUM(unspecified, in_bounds, out_of_bounds)
+type RateAppliesWhen int
+
+// TimeOfDayFormat is the format of rule start_time and end_time, which are local to the agency.
+const TimeOfDayFormat = "15:04:05"
+
+type Rule struct {
+	RuleID          uuid.UUID                    `json:"rule_id"`
+	Name            string                       `json:"name"`
+	RuleType        RuleType                     `json:"rule_type"`
+	Geographies     []uuid.UUID                  `json:"geographies"`
+	States          map[VehicleState][]EventType `json:"states"`
+	RuleUnits       RuleUnits                    `json:"rule_units,omitempty"`
+	VehicleTypes    []VehicleType                `json:"vehicle_types,omitempty"`
+	PropulsionTypes []PropulsionType             `json:"propulsion_types,omitempty"`
+	Minimum         *float64                     `json:"minimum,omitempty"`
+	Maximum         *float64                     `json:"maximum,omitempty"`
+	RateAmount      *int                         `json:"rate_amount,omitempty"`
+	RateRecurrence  RateRecurrence               `json:"rate_recurrence,omitempty"`
+	RateAppliesWhen RateAppliesWhen              `json:"rate_applies_when,omitempty"`
+	StartTime       string                       `json:"start_time,omitempty"`
+	EndTime         string                       `json:"end_time,omitempty"`
+	Days            []Day                        `json:"days,omitempty"`
+	Messages        map[string]string            `json:"messages,omitempty"`
+	ValueURL        string                       `json:"value_url,omitempty"`
+}
+
+type Policy struct {
+	PolicyID      uuid.UUID   `json:"policy_id"`
+	Name          string      `json:"name"`
+	Description   string      `json:"description"`
+	ProviderIDs   []uuid.UUID `json:"provider_ids,omitempty"`
+	Currency      string      `json:"currency,omitempty"`
+	StartDate     Timestamp   `json:"start_date"`
+	EndDate       *Timestamp  `json:"end_date,omitempty"`
+	PublishedDate *Timestamp  `json:"published_date,omitempty"`
+	// PrevPolicies are the policies that this one supersedes, as MDS 2.0 has no separate
+	// supersedes field. They stop being in effect once this policy is published and starts.
+	PrevPolicies []uuid.UUID `json:"prev_policies,omitempty"`
+	Rules        []Rule      `json:"rules"`
+}
+
+// IsPublished reports whether the policy has been published. Published policies are immutable.
+func (p Policy) IsPublished() bool {
+	return p.PublishedDate != nil
+}
+
+var ruleTypeUnits = map[RuleType]Set[RuleUnits]{
+	RuleTypeTime:  NewSet(RuleUnitsSeconds, RuleUnitsMinutes, RuleUnitsHours, RuleUnitsDays),
+	RuleTypeSpeed: NewSet(RuleUnitsMph, RuleUnitsKph),
+}
+
+func validateTimeOfDay(field string, value string) []string {
+	if value == "" {
+		return nil
+	}
+	_, err := time.Parse(TimeOfDayFormat, value)
+	if err != nil {
+		return []string{fmt.Sprintf("%s: must be a time of day in the format HH:MM:SS", field)}
+	}
+	return nil
+}
+
+func validateRule(field string, rule Rule) []string {
+	var errs []string
+	if rule.RuleID == (uuid.UUID{}) {
+		errs = append(errs, fmt.Sprintf("%s.rule_id: null UUID is not allowed", field))
+	}
+	if rule.Name == "" {
+		errs = append(errs, fmt.Sprintf("%s.name: missing required field", field))
+	}
+	if rule.RuleType == RuleTypeUnknown {
+		errs = append(errs, fmt.Sprintf("%s.rule_type: missing required field", field))
+	}
+	if len(rule.Geographies) == 0 {
+		errs = append(errs, fmt.Sprintf("%s.geographies: must contain at least one geography", field))
+	}
+	if allowedUnits, ok := ruleTypeUnits[rule.RuleType]; ok && !allowedUnits.Contains(rule.RuleUnits) {
+		errs = append(errs, fmt.Sprintf("%s.rule_units: must be one of %v for %s rules", field, Stringify(allowedUnits), rule.RuleType))
+	}
+	if rule.Minimum != nil && rule.Maximum != nil && *rule.Minimum > *rule.Maximum {
+		errs = append(errs, fmt.Sprintf("%s.minimum: must not be greater than maximum", field))
+	}
+	if rule.RuleType == RuleTypeRate && rule.RateAmount == nil {
+		errs = append(errs, fmt.Sprintf("%s.rate_amount: required for rate rules", field))
+	}
+	errs = append(errs, validateTimeOfDay(field+".start_time", rule.StartTime)...)
+	errs = append(errs, validateTimeOfDay(field+".end_time", rule.EndTime)...)
+	return errs
+}
+
+func ValidatePolicy(value any) []string {
+	var errs []string
+	switch p := value.(type) {
+	case Policy:
+		if p.PolicyID == (uuid.UUID{}) {
+			errs = append(errs, "policy_id: null UUID is not allowed")
+		}
+		if p.Name == "" {
+			errs = append(errs, "name: missing required field")
+		}
+		if p.StartDate.IsZero() {
+			errs = append(errs, "start_date: missing required field")
+		}
+		if p.EndDate != nil && !p.EndDate.After(p.StartDate.Time) {
+			errs = append(errs, "end_date: must be after start_date")
+		}
+		if p.Currency != "" && len(p.Currency) != 3 {
+			errs = append(errs, "currency: must be an ISO 4217 currency code")
+		}
+		for _, prevPolicy := range p.PrevPolicies {
+			if prevPolicy == p.PolicyID {
+				errs = append(errs, "prev_policies: a policy cannot supersede itself")
+			}
+		}
+		if len(p.Rules) == 0 {
+			errs = append(errs, "rules: must contain at least one rule")
+		}
+		for i, rule := range p.Rules {
+			errs = append(errs, validateRule(fmt.Sprintf("rules[%d]", i), rule)...)
+		}
+	default:
+		panic("cannot validate unknown type")
+	}
+	return errs
+}
+
+type PoliciesResponse struct {
+	Version  string     `json:"version"`
+	Updated  *Timestamp `json:"updated,omitempty"`
+	Policies []Policy   `json:"policies"`
+}
+
+// NewPoliciesResponse wraps policies in a response whose updated field is the most recent time
+// any of the policies was published.
+func NewPoliciesResponse(policies []Policy) PoliciesResponse {
+	response := PoliciesResponse{
+		Version:  "2.0.0",
+		Policies: policies,
+	}
+	for _, policy := range policies {
+		if policy.PublishedDate != nil && (response.Updated == nil || policy.PublishedDate.After(response.Updated.Time)) {
+			response.Updated = policy.PublishedDate
+		}
+	}
+	return response
+}
+
+// Requirements describes the MDS APIs and endpoints the agency requires providers to implement.
+// The document is authored by the agency and served verbatim.
+type Requirements struct {
+	LastUpdated  Timestamp `json:"last_updated"`
+	Metadata     Record    `json:"metadata"`
+	Requirements []Record  `json:"requirements"`
+}
+
+func ValidateRequirements(value any) []string {
+	var errs []string
+	switch r := value.(type) {
+	case Requirements:
+		if len(r.Metadata.Entries) == 0 {
+			errs = append(errs, "metadata: missing required field")
+		}
+	default:
+		panic("cannot validate unknown type")
+	}
+	return errs
+}
+
+type RequirementsResponse struct {
+	Version string `json:"version"`
+	Requirements
+}
+
+// ListPoliciesParams selects the published policies that are in effect at any point between
+// Start and End. A nil End selects every policy in effect at or after Start.
+type ListPoliciesParams struct {
+	Start time.Time
+	End   *time.Time
+}
+
+type PolicyRepository interface {
+	ListPolicies(ctx context.Context, params ListPoliciesParams) ([]Policy, error)
+	FetchPolicy(ctx context.Context, policyID uuid.UUID) (Policy, error)
+	InsertPolicy(ctx context.Context, policy Policy) error
+	UpdatePolicy(ctx context.Context, policy Policy) error
+	PublishPolicy(ctx context.Context, policyID uuid.UUID, publishedDate time.Time) error
+	FetchRequirements(ctx context.Context) (Requirements, error)
+	UpsertRequirements(ctx context.Context, requirements Requirements) error
+}
@@ -0,0 +1,750 @@
+// Code generated by go-enum DO NOT EDIT.
+// Version:
+// Revision:
+// Build Date:
+// Built By:
+
+package domain
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+)
+
+const (
+	// DaySun is a Day of type Sun.
+	DaySun Day = iota
+	// DayMon is a Day of type Mon.
+	DayMon
+	// DayTue is a Day of type Tue.
+	DayTue
+	// DayWed is a Day of type Wed.
+	DayWed
+	// DayThu is a Day of type Thu.
+	DayThu
+	// DayFri is a Day of type Fri.
+	DayFri
+	// DaySat is a Day of type Sat.
+	DaySat
+)
+
+var ErrInvalidDay = errors.New("not a valid Day")
+
+const _DayName = "sunmontuewedthufrisat"
+
+var _DayMap = map[Day]string{
+	DaySun: _DayName[0:3],
+	DayMon: _DayName[3:6],
+	DayTue: _DayName[6:9],
+	DayWed: _DayName[9:12],
+	DayThu: _DayName[12:15],
+	DayFri: _DayName[15:18],
+	DaySat: _DayName[18:21],
+}
+
+// String implements the Stringer interface.
+func (x Day) String() string {
+	if str, ok := _DayMap[x]; ok {
+		return str
+	}
+	return fmt.Sprintf("Day(%d)", x)
+}
+
+// IsValid provides a quick way to determine if the typed value is
+// part of the allowed enumerated values
+func (x Day) IsValid() bool {
+	_, ok := _DayMap[x]
+	return ok
+}
+
+var _DayValue = map[string]Day{
+	_DayName[0:3]:   DaySun,
+	_DayName[3:6]:   DayMon,
+	_DayName[6:9]:   DayTue,
+	_DayName[9:12]:  DayWed,
+	_DayName[12:15]: DayThu,
+	_DayName[15:18]: DayFri,
+	_DayName[18:21]: DaySat,
+}
+
+// ParseDay attempts to convert a string to a Day.
+func ParseDay(name string) (Day, error) {
+	if x, ok := _DayValue[name]; ok {
+		return x, nil
+	}
+	return Day(0), fmt.Errorf("%s is %w", name, ErrInvalidDay)
+}
+
+// MarshalText implements the text marshaller method.
+func (x Day) MarshalText() ([]byte, error) {
+	return []byte(x.String()), nil
+}
+
+// UnmarshalText implements the text unmarshaller method.
+func (x *Day) UnmarshalText(text []byte) error {
+	name := string(text)
+	tmp, err := ParseDay(name)
+	if err != nil {
+		return err
+	}
+	*x = tmp
+	return nil
+}
+
+var errDayNilPtr = errors.New("value pointer is nil") // one per type for package clashes
+
+// Scan implements the Scanner interface.
+func (x *Day) Scan(value interface{}) (err error) {
+	if value == nil {
+		*x = Day(0)
+		return
+	}
+
+	// A wider range of scannable types.
+	// driver.Value values at the top of the list for expediency
+	switch v := value.(type) {
+	case int64:
+		*x = Day(v)
+	case string:
+		*x, err = ParseDay(v)
+	case []byte:
+		*x, err = ParseDay(string(v))
+	case Day:
+		*x = v
+	case int:
+		*x = Day(v)
+	case *Day:
+		if v == nil {
+			return errDayNilPtr
+		}
+		*x = *v
+	case uint:
+		*x = Day(v)
+	case uint64:
+		*x = Day(v)
+	case *int:
+		if v == nil {
+			return errDayNilPtr
+		}
+		*x = Day(*v)
+	case *int64:
+		if v == nil {
+			return errDayNilPtr
+		}
+		*x = Day(*v)
+	case float64: // json marshals everything as a float64 if it's a number
+		*x = Day(v)
+	case *float64: // json marshals everything as a float64 if it's a number
+		if v == nil {
+			return errDayNilPtr
+		}
+		*x = Day(*v)
+	case *uint:
+		if v == nil {
+			return errDayNilPtr
+		}
+		*x = Day(*v)
+	case *uint64:
+		if v == nil {
+			return errDayNilPtr
+		}
+		*x = Day(*v)
+	case *string:
+		if v == nil {
+			return errDayNilPtr
+		}
+		*x, err = ParseDay(*v)
+	}
+
+	return
+}
+
+// Value implements the driver Valuer interface.
+func (x Day) Value() (driver.Value, error) {
+	return x.String(), nil
+}
+
+const (
+	// RateAppliesWhenUnspecified is a RateAppliesWhen of type Unspecified.
+	RateAppliesWhenUnspecified RateAppliesWhen = iota
+	// RateAppliesWhenInBounds is a RateAppliesWhen of type In_bounds.
+	RateAppliesWhenInBounds
+	// RateAppliesWhenOutOfBounds is a RateAppliesWhen of type Out_of_bounds.
+	RateAppliesWhenOutOfBounds
+)
+
+var ErrInvalidRateAppliesWhen = errors.New("not a valid RateAppliesWhen")
+
+const _RateAppliesWhenName = "unspecifiedin_boundsout_of_bounds"
+
+var _RateAppliesWhenMap = map[RateAppliesWhen]string{
+	RateAppliesWhenUnspecified: _RateAppliesWhenName[0:11],
+	RateAppliesWhenInBounds:    _RateAppliesWhenName[11:20],
+	RateAppliesWhenOutOfBounds: _RateAppliesWhenName[20:33],
+}
+
+// String implements the Stringer interface.
+func (x RateAppliesWhen) String() string {
+	if str, ok := _RateAppliesWhenMap[x]; ok {
+		return str
+	}
+	return fmt.Sprintf("RateAppliesWhen(%d)", x)
+}
+
+// IsValid provides a quick way to determine if the typed value is
+// part of the allowed enumerated values
+func (x RateAppliesWhen) IsValid() bool {
+	_, ok := _RateAppliesWhenMap[x]
+	return ok
+}
+
+var _RateAppliesWhenValue = map[string]RateAppliesWhen{
+	_RateAppliesWhenName[0:11]:  RateAppliesWhenUnspecified,
+	_RateAppliesWhenName[11:20]: RateAppliesWhenInBounds,
+	_RateAppliesWhenName[20:33]: RateAppliesWhenOutOfBounds,
+}
+
+// ParseRateAppliesWhen attempts to convert a string to a RateAppliesWhen.
+func ParseRateAppliesWhen(name string) (RateAppliesWhen, error) {
+	if x, ok := _RateAppliesWhenValue[name]; ok {
+		return x, nil
+	}
+	return RateAppliesWhen(0), fmt.Errorf("%s is %w", name, ErrInvalidRateAppliesWhen)
+}
+
+// MarshalText implements the text marshaller method.
+func (x RateAppliesWhen) MarshalText() ([]byte, error) {
+	return []byte(x.String()), nil
+}
+
+// UnmarshalText implements the text unmarshaller method.
+func (x *RateAppliesWhen) UnmarshalText(text []byte) error {
+	name := string(text)
+	tmp, err := ParseRateAppliesWhen(name)
+	if err != nil {
+		return err
+	}
+	*x = tmp
+	return nil
+}
+
+var errRateAppliesWhenNilPtr = errors.New("value pointer is nil") // one per type for package clashes
+
+// Scan implements the Scanner interface.
+func (x *RateAppliesWhen) Scan(value interface{}) (err error) {
+	if value == nil {
+		*x = RateAppliesWhen(0)
+		return
+	}
+
+	// A wider range of scannable types.
+	// driver.Value values at the top of the list for expediency
+	switch v := value.(type) {
+	case int64:
+		*x = RateAppliesWhen(v)
+	case string:
+		*x, err = ParseRateAppliesWhen(v)
+	case []byte:
+		*x, err = ParseRateAppliesWhen(string(v))
+	case RateAppliesWhen:
+		*x = v
+	case int:
+		*x = RateAppliesWhen(v)
+	case *RateAppliesWhen:
+		if v == nil {
+			return errRateAppliesWhenNilPtr
+		}
+		*x = *v
+	case uint:
+		*x = RateAppliesWhen(v)
+	case uint64:
+		*x = RateAppliesWhen(v)
+	case *int:
+		if v == nil {
+			return errRateAppliesWhenNilPtr
+		}
+		*x = RateAppliesWhen(*v)
+	case *int64:
+		if v == nil {
+			return errRateAppliesWhenNilPtr
+		}
+		*x = RateAppliesWhen(*v)
+	case float64: // json marshals everything as a float64 if it's a number
+		*x = RateAppliesWhen(v)
+	case *float64: // json marshals everything as a float64 if it's a number
+		if v == nil {
+			return errRateAppliesWhenNilPtr
+		}
+		*x = RateAppliesWhen(*v)
+	case *uint:
+		if v == nil {
+			return errRateAppliesWhenNilPtr
+		}
+		*x = RateAppliesWhen(*v)
+	case *uint64:
+		if v == nil {
+			return errRateAppliesWhenNilPtr
+		}
+		*x = RateAppliesWhen(*v)
+	case *string:
+		if v == nil {
+			return errRateAppliesWhenNilPtr
+		}
+		*x, err = ParseRateAppliesWhen(*v)
+	}
+
+	return
+}
+
+// Value implements the driver Valuer interface.
+func (x RateAppliesWhen) Value() (driver.Value, error) {
+	return x.String(), nil
+}
+
+const (
+	// RateRecurrenceUnspecified is a RateRecurrence of type Unspecified.
+	RateRecurrenceUnspecified RateRecurrence = iota
+	// RateRecurrenceOnceOnMatch is a RateRecurrence of type Once_on_match.
+	RateRecurrenceOnceOnMatch
+	// RateRecurrenceOnceOnUnmatch is a RateRecurrence of type Once_on_unmatch.
+	RateRecurrenceOnceOnUnmatch
+	// RateRecurrenceEachTimeUnit is a RateRecurrence of type Each_time_unit.
+	RateRecurrenceEachTimeUnit
+	// RateRecurrencePerCompleteTimeUnit is a RateRecurrence of type Per_complete_time_unit.
+	RateRecurrencePerCompleteTimeUnit
+)
+
+var ErrInvalidRateRecurrence = errors.New("not a valid RateRecurrence")
+
+const _RateRecurrenceName = "unspecifiedonce_on_matchonce_on_unmatcheach_time_unitper_complete_time_unit"
+
+var _RateRecurrenceMap = map[RateRecurrence]string{
+	RateRecurrenceUnspecified:         _RateRecurrenceName[0:11],
+	RateRecurrenceOnceOnMatch:         _RateRecurrenceName[11:24],
+	RateRecurrenceOnceOnUnmatch:       _RateRecurrenceName[24:39],
+	RateRecurrenceEachTimeUnit:        _RateRecurrenceName[39:53],
+	RateRecurrencePerCompleteTimeUnit: _RateRecurrenceName[53:75],
+}
+
+// String implements the Stringer interface.
+func (x RateRecurrence) String() string {
+	if str, ok := _RateRecurrenceMap[x]; ok {
+		return str
+	}
+	return fmt.Sprintf("RateRecurrence(%d)", x)
+}
+
+// IsValid provides a quick way to determine if the typed value is
+// part of the allowed enumerated values
+func (x RateRecurrence) IsValid() bool {
+	_, ok := _RateRecurrenceMap[x]
+	return ok
+}
+
+var _RateRecurrenceValue = map[string]RateRecurrence{
+	_RateRecurrenceName[0:11]:  RateRecurrenceUnspecified,
+	_RateRecurrenceName[11:24]: RateRecurrenceOnceOnMatch,
+	_RateRecurrenceName[24:39]: RateRecurrenceOnceOnUnmatch,
+	_RateRecurrenceName[39:53]: RateRecurrenceEachTimeUnit,
+	_RateRecurrenceName[53:75]: RateRecurrencePerCompleteTimeUnit,
+}
+
+// ParseRateRecurrence attempts to convert a string to a RateRecurrence.
+func ParseRateRecurrence(name string) (RateRecurrence, error) {
+	if x, ok := _RateRecurrenceValue[name]; ok {
+		return x, nil
+	}
+	return RateRecurrence(0), fmt.Errorf("%s is %w", name, ErrInvalidRateRecurrence)
+}
+
+// MarshalText implements the text marshaller method.
+func (x RateRecurrence) MarshalText() ([]byte, error) {
+	return []byte(x.String()), nil
+}
+
+// UnmarshalText implements the text unmarshaller method.
+func (x *RateRecurrence) UnmarshalText(text []byte) error {
+	name := string(text)
+	tmp, err := ParseRateRecurrence(name)
+	if err != nil {
+		return err
+	}
+	*x = tmp
+	return nil
+}
+
+var errRateRecurrenceNilPtr = errors.New("value pointer is nil") // one per type for package clashes
+
+// Scan implements the Scanner interface.
+func (x *RateRecurrence) Scan(value interface{}) (err error) {
+	if value == nil {
+		*x = RateRecurrence(0)
+		return
+	}
+
+	// A wider range of scannable types.
+	// driver.Value values at the top of the list for expediency
+	switch v := value.(type) {
+	case int64:
+		*x = RateRecurrence(v)
+	case string:
+		*x, err = ParseRateRecurrence(v)
+	case []byte:
+		*x, err = ParseRateRecurrence(string(v))
+	case RateRecurrence:
+		*x = v
+	case int:
+		*x = RateRecurrence(v)
+	case *RateRecurrence:
+		if v == nil {
+			return errRateRecurrenceNilPtr
+		}
+		*x = *v
+	case uint:
+		*x = RateRecurrence(v)
+	case uint64:
+		*x = RateRecurrence(v)
+	case *int:
+		if v == nil {
+			return errRateRecurrenceNilPtr
+		}
+		*x = RateRecurrence(*v)
+	case *int64:
+		if v == nil {
+			return errRateRecurrenceNilPtr
+		}
+		*x = RateRecurrence(*v)
+	case float64: // json marshals everything as a float64 if it's a number
+		*x = RateRecurrence(v)
+	case *float64: // json marshals everything as a float64 if it's a number
+		if v == nil {
+			return errRateRecurrenceNilPtr
+		}
+		*x = RateRecurrence(*v)
+	case *uint:
+		if v == nil {
+			return errRateRecurrenceNilPtr
+		}
+		*x = RateRecurrence(*v)
+	case *uint64:
+		if v == nil {
+			return errRateRecurrenceNilPtr
+		}
+		*x = RateRecurrence(*v)
+	case *string:
+		if v == nil {
+			return errRateRecurrenceNilPtr
+		}
+		*x, err = ParseRateRecurrence(*v)
+	}
+
+	return
+}
+
+// Value implements the driver Valuer interface.
+func (x RateRecurrence) Value() (driver.Value, error) {
+	return x.String(), nil
+}
+
+const (
+	// RuleTypeUnknown is a RuleType of type Unknown.
+	RuleTypeUnknown RuleType = iota
+	// RuleTypeCount is a RuleType of type Count.
+	RuleTypeCount
+	// RuleTypeTime is a RuleType of type Time.
+	RuleTypeTime
+	// RuleTypeSpeed is a RuleType of type Speed.
+	RuleTypeSpeed
+	// RuleTypeRate is a RuleType of type Rate.
+	RuleTypeRate
+	// RuleTypeUser is a RuleType of type User.
+	RuleTypeUser
+)
+
+var ErrInvalidRuleType = errors.New("not a valid RuleType")
+
+const _RuleTypeName = "unknowncounttimespeedrateuser"
+
+var _RuleTypeMap = map[RuleType]string{
+	RuleTypeUnknown: _RuleTypeName[0:7],
+	RuleTypeCount:   _RuleTypeName[7:12],
+	RuleTypeTime:    _RuleTypeName[12:16],
+	RuleTypeSpeed:   _RuleTypeName[16:21],
+	RuleTypeRate:    _RuleTypeName[21:25],
+	RuleTypeUser:    _RuleTypeName[25:29],
+}
+
+// String implements the Stringer interface.
+func (x RuleType) String() string {
+	if str, ok := _RuleTypeMap[x]; ok {
+		return str
+	}
+	return fmt.Sprintf("RuleType(%d)", x)
+}
+
+// IsValid provides a quick way to determine if the typed value is
+// part of the allowed enumerated values
+func (x RuleType) IsValid() bool {
+	_, ok := _RuleTypeMap[x]
+	return ok
+}
+
+var _RuleTypeValue = map[string]RuleType{
+	_RuleTypeName[0:7]:   RuleTypeUnknown,
+	_RuleTypeName[7:12]:  RuleTypeCount,
+	_RuleTypeName[12:16]: RuleTypeTime,
+	_RuleTypeName[16:21]: RuleTypeSpeed,
+	_RuleTypeName[21:25]: RuleTypeRate,
+	_RuleTypeName[25:29]: RuleTypeUser,
+}
+
+// ParseRuleType attempts to convert a string to a RuleType.
+func ParseRuleType(name string) (RuleType, error) {
+	if x, ok := _RuleTypeValue[name]; ok {
+		return x, nil
+	}
+	return RuleType(0), fmt.Errorf("%s is %w", name, ErrInvalidRuleType)
+}
+
+// MarshalText implements the text marshaller method.
+func (x RuleType) MarshalText() ([]byte, error) {
+	return []byte(x.String()), nil
+}
+
+// UnmarshalText implements the text unmarshaller method.
+func (x *RuleType) UnmarshalText(text []byte) error {
+	name := string(text)
+	tmp, err := ParseRuleType(name)
+	if err != nil {
+		return err
+	}
+	*x = tmp
+	return nil
+}
+
+var errRuleTypeNilPtr = errors.New("value pointer is nil") // one per type for package clashes
+
+// Scan implements the Scanner interface.
+func (x *RuleType) Scan(value interface{}) (err error) {
+	if value == nil {
+		*x = RuleType(0)
+		return
+	}
+
+	// A wider range of scannable types.
+	// driver.Value values at the top of the list for expediency
+	switch v := value.(type) {
+	case int64:
+		*x = RuleType(v)
+	case string:
+		*x, err = ParseRuleType(v)
+	case []byte:
+		*x, err = ParseRuleType(string(v))
+	case RuleType:
+		*x = v
+	case int:
+		*x = RuleType(v)
+	case *RuleType:
+		if v == nil {
+			return errRuleTypeNilPtr
+		}
+		*x = *v
+	case uint:
+		*x = RuleType(v)
+	case uint64:
+		*x = RuleType(v)
+	case *int:
+		if v == nil {
+			return errRuleTypeNilPtr
+		}
+		*x = RuleType(*v)
+	case *int64:
+		if v == nil {
+			return errRuleTypeNilPtr
+		}
+		*x = RuleType(*v)
+	case float64: // json marshals everything as a float64 if it's a number
+		*x = RuleType(v)
+	case *float64: // json marshals everything as a float64 if it's a number
+		if v == nil {
+			return errRuleTypeNilPtr
+		}
+		*x = RuleType(*v)
+	case *uint:
+		if v == nil {
+			return errRuleTypeNilPtr
+		}
+		*x = RuleType(*v)
+	case *uint64:
+		if v == nil {
+			return errRuleTypeNilPtr
+		}
+		*x = RuleType(*v)
+	case *string:
+		if v == nil {
+			return errRuleTypeNilPtr
+		}
+		*x, err = ParseRuleType(*v)
+	}
+
+	return
+}
+
+// Value implements the driver Valuer interface.
+func (x RuleType) Value() (driver.Value, error) {
+	return x.String(), nil
+}
+
+const (
+	// RuleUnitsUnspecified is a RuleUnits of type Unspecified.
+	RuleUnitsUnspecified RuleUnits = iota
+	// RuleUnitsSeconds is a RuleUnits of type Seconds.
+	RuleUnitsSeconds
+	// RuleUnitsMinutes is a RuleUnits of type Minutes.
+	RuleUnitsMinutes
+	// RuleUnitsHours is a RuleUnits of type Hours.
+	RuleUnitsHours
+	// RuleUnitsDays is a RuleUnits of type Days.
+	RuleUnitsDays
+	// RuleUnitsMph is a RuleUnits of type Mph.
+	RuleUnitsMph
+	// RuleUnitsKph is a RuleUnits of type Kph.
+	RuleUnitsKph
+)
+
+var ErrInvalidRuleUnits = errors.New("not a valid RuleUnits")
+
+const _RuleUnitsName = "unspecifiedsecondsminuteshoursdaysmphkph"
+
+var _RuleUnitsMap = map[RuleUnits]string{
+	RuleUnitsUnspecified: _RuleUnitsName[0:11],
+	RuleUnitsSeconds:     _RuleUnitsName[11:18],
+	RuleUnitsMinutes:     _RuleUnitsName[18:25],
+	RuleUnitsHours:       _RuleUnitsName[25:30],
+	RuleUnitsDays:        _RuleUnitsName[30:34],
+	RuleUnitsMph:         _RuleUnitsName[34:37],
+	RuleUnitsKph:         _RuleUnitsName[37:40],
+}
+
+// String implements the Stringer interface.
+func (x RuleUnits) String() string {
+	if str, ok := _RuleUnitsMap[x]; ok {
+		return str
+	}
+	return fmt.Sprintf("RuleUnits(%d)", x)
+}
+
+// IsValid provides a quick way to determine if the typed value is
+// part of the allowed enumerated values
+func (x RuleUnits) IsValid() bool {
+	_, ok := _RuleUnitsMap[x]
+	return ok
+}
+
+var _RuleUnitsValue = map[string]RuleUnits{
+	_RuleUnitsName[0:11]:  RuleUnitsUnspecified,
+	_RuleUnitsName[11:18]: RuleUnitsSeconds,
+	_RuleUnitsName[18:25]: RuleUnitsMinutes,
+	_RuleUnitsName[25:30]: RuleUnitsHours,
+	_RuleUnitsName[30:34]: RuleUnitsDays,
+	_RuleUnitsName[34:37]: RuleUnitsMph,
+	_RuleUnitsName[37:40]: RuleUnitsKph,
+}
+
+// ParseRuleUnits attempts to convert a string to a RuleUnits.
+func ParseRuleUnits(name string) (RuleUnits, error) {
+	if x, ok := _RuleUnitsValue[name]; ok {
+		return x, nil
+	}
+	return RuleUnits(0), fmt.Errorf("%s is %w", name, ErrInvalidRuleUnits)
+}
+
+// MarshalText implements the text marshaller method.
+func (x RuleUnits) MarshalText() ([]byte, error) {
+	return []byte(x.String()), nil
+}
+
+// UnmarshalText implements the text unmarshaller method.
+func (x *RuleUnits) UnmarshalText(text []byte) error {
+	name := string(text)
+	tmp, err := ParseRuleUnits(name)
+	if err != nil {
+		return err
+	}
+	*x = tmp
+	return nil
+}
+
+var errRuleUnitsNilPtr = errors.New("value pointer is nil") // one per type for package clashes
+
+// Scan implements the Scanner interface.
+func (x *RuleUnits) Scan(value interface{}) (err error) {
+	if value == nil {
+		*x = RuleUnits(0)
+		return
+	}
+
+	// A wider range of scannable types.
+	// driver.Value values at the top of the list for expediency
+	switch v := value.(type) {
+	case int64:
+		*x = RuleUnits(v)
+	case string:
+		*x, err = ParseRuleUnits(v)
+	case []byte:
+		*x, err = ParseRuleUnits(string(v))
+	case RuleUnits:
+		*x = v
+	case int:
+		*x = RuleUnits(v)
+	case *RuleUnits:
+		if v == nil {
+			return errRuleUnitsNilPtr
+		}
+		*x = *v
+	case uint:
+		*x = RuleUnits(v)
+	case uint64:
+		*x = RuleUnits(v)
+	case *int:
+		if v == nil {
+			return errRuleUnitsNilPtr
+		}
+		*x = RuleUnits(*v)
+	case *int64:
+		if v == nil {
+			return errRuleUnitsNilPtr
+		}
+		*x = RuleUnits(*v)
+	case float64: // json marshals everything as a float64 if it's a number
+		*x = RuleUnits(v)
+	case *float64: // json marshals everything as a float64 if it's a number
+		if v == nil {
+			return errRuleUnitsNilPtr
+		}
+		*x = RuleUnits(*v)
+	case *uint:
+		if v == nil {
+			return errRuleUnitsNilPtr
+		}
+		*x = RuleUnits(*v)
+	case *uint64:
+		if v == nil {
+			return errRuleUnitsNilPtr
+		}
+		*x = RuleUnits(*v)
+	case *string:
+		if v == nil {
+			return errRuleUnitsNilPtr
+		}
+		*x, err = ParseRuleUnits(*v)
+	}
+
+	return
+}
+
+// Value implements the driver Valuer interface.
+func (x RuleUnits) Value() (driver.Value, error) {
+	return x.String(), nil
+}
@@ -0,0 +1,116 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func makeValidPolicy() Policy {
+	maximum := 25.0
+	return Policy{
+		PolicyID:    uuid.MustParse("39a7b6f8-1c2d-4e5f-8a9b-0c1d2e3f4a5b"),
+		Name:        "Downtown speed limit",
+		Description: "Scooters may not exceed 15 mph downtown",
+		StartDate:   NewTimestamp(time.UnixMilli(1690000000000)),
+		Rules: []Rule{
+			{
+				RuleID:      uuid.MustParse("7c1e2d3f-4a5b-4c6d-8e7f-9a0b1c2d3e4f"),
+				Name:        "Speed limit",
+				RuleType:    RuleTypeSpeed,
+				RuleUnits:   RuleUnitsMph,
+				Geographies: []uuid.UUID{uuid.MustParse("0d5c4b3a-2918-4f7e-a6d5-c4b3a2918f7e")},
+				States:      map[VehicleState][]EventType{VehicleStateOnTrip: {}},
+				Maximum:     &maximum,
+				Days:        []Day{DayMon, DayTue},
+				StartTime:   "07:00:00",
+				EndTime:     "19:00:00",
+			},
+		},
+	}
+}
+
+var _ = Describe("Policy", func() {
+	It("round trips through JSON", func() {
+		policy := makeValidPolicy()
+		data, err := json.Marshal(policy)
+		Expect(err).NotTo(HaveOccurred())
+
+		var output Policy
+		Expect(json.Unmarshal(data, &output)).To(Succeed())
+		Expect(output).To(Equal(policy))
+	})
+
+	It("serializes rule states keyed by vehicle state", func() {
+		data, err := json.Marshal(makeValidPolicy().Rules[0])
+		Expect(err).NotTo(HaveOccurred())
+		Expect(data).To(ContainSubstring(`"states":{"on_trip":[]}`))
+	})
+
+	It("is valid w/ all required fields", func() {
+		Expect(ValidatePolicy(makeValidPolicy())).To(BeEmpty())
+	})
+
+	It("requires at least one rule", func() {
+		policy := makeValidPolicy()
+		policy.Rules = nil
+		Expect(ValidatePolicy(policy)).To(ConsistOf("rules: must contain at least one rule"))
+	})
+
+	It("requires end_date to be after start_date", func() {
+		policy := makeValidPolicy()
+		endDate := NewTimestamp(policy.StartDate.Add(-time.Hour))
+		policy.EndDate = &endDate
+		Expect(ValidatePolicy(policy)).To(ConsistOf("end_date: must be after start_date"))
+	})
+
+	It("does not allow a policy to supersede itself", func() {
+		policy := makeValidPolicy()
+		policy.PrevPolicies = []uuid.UUID{policy.PolicyID}
+		Expect(ValidatePolicy(policy)).To(ConsistOf("prev_policies: a policy cannot supersede itself"))
+	})
+
+	It("requires rule units appropriate to the rule type", func() {
+		policy := makeValidPolicy()
+		policy.Rules[0].RuleUnits = RuleUnitsMinutes
+		Expect(ValidatePolicy(policy)).To(ConsistOf("rules[0].rule_units: must be one of [mph kph] for speed rules"))
+	})
+
+	It("requires rate_amount for rate rules", func() {
+		policy := makeValidPolicy()
+		policy.Rules[0].RuleType = RuleTypeRate
+		policy.Rules[0].RuleUnits = RuleUnitsUnspecified
+		Expect(ValidatePolicy(policy)).To(ConsistOf("rules[0].rate_amount: required for rate rules"))
+	})
+
+	It("rejects malformed times of day", func() {
+		policy := makeValidPolicy()
+		policy.Rules[0].StartTime = "7am"
+		Expect(ValidatePolicy(policy)).To(ConsistOf("rules[0].start_time: must be a time of day in the format HH:MM:SS"))
+	})
+
+	It("rejects a minimum greater than the maximum", func() {
+		policy := makeValidPolicy()
+		minimum := 30.0
+		policy.Rules[0].Minimum = &minimum
+		Expect(ValidatePolicy(policy)).To(ConsistOf("rules[0].minimum: must not be greater than maximum"))
+	})
+})
+
+var _ = Describe("NewPoliciesResponse", func() {
+	It("uses the most recent published_date as the updated time", func() {
+		earlier := NewTimestamp(time.UnixMilli(1690000000000))
+		later := NewTimestamp(time.UnixMilli(1690000060000))
+		response := NewPoliciesResponse([]Policy{{PublishedDate: &later}, {PublishedDate: &earlier}, {}})
+		Expect(response.Updated).To(Equal(&later))
+	})
+
+	It("omits the updated time when no policies are published", func() {
+		data, err := json.Marshal(NewPoliciesResponse([]Policy{}))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(data).To(MatchJSON(`{"version":"2.0.0","policies":[]}`))
+	})
+})
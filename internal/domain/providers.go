@@ -0,0 +1,87 @@
+//go:generate go run github.com/abice/go-enum@v0.5.6 --marshal --sql
+
+package domain
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/google/uuid"
+)
+
+// ENUM(active, suspended)
+type ProviderStatus int
+
+// Provider is an operator registered with the agency. Only active providers may submit data.
+type Provider struct {
+	ProviderID   uuid.UUID        `json:"provider_id"`
+	ProviderName string           `json:"provider_name"`
+	URL          string           `json:"url,omitempty"`
+	Contact      string           `json:"contact,omitempty"`
+	Status       ProviderStatus   `json:"status"`
+	VehicleTypes Set[VehicleType] `json:"vehicle_types"`
+	// DataProviders are the data vendors allowed to submit data on behalf of the provider.
+	DataProviders []uuid.UUID `json:"data_providers"`
+}
+
+// AllowsVehicleType reports whether the provider may register vehicles of type vehicleType. A
+// provider without any vehicle types may register every type.
+func (provider Provider) AllowsVehicleType(vehicleType VehicleType) bool {
+	return len(provider.VehicleTypes) == 0 || provider.VehicleTypes.Contains(vehicleType)
+}
+
+// DelegatesTo reports whether dataProviderID may submit data on behalf of the provider.
+func (provider Provider) DelegatesTo(dataProviderID uuid.UUID) bool {
+	for _, dataProvider := range provider.DataProviders {
+		if dataProvider == dataProviderID {
+			return true
+		}
+	}
+	return false
+}
+
+func ValidateProvider(value any) []string {
+	var errs []string
+	switch p := value.(type) {
+	case Provider:
+		if p.ProviderID == (uuid.UUID{}) {
+			errs = append(errs, "provider_id: null UUID is not allowed")
+		}
+		if p.ProviderName == "" {
+			errs = append(errs, "provider_name: missing required field")
+		}
+		if p.URL != "" {
+			u, err := url.Parse(p.URL)
+			if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				errs = append(errs, "url: must be an absolute http(s) URL")
+			}
+		}
+		for _, dataProvider := range p.DataProviders {
+			if dataProvider == p.ProviderID {
+				errs = append(errs, fmt.Sprintf("data_providers: %s is the provider itself", dataProvider))
+			}
+		}
+	default:
+		panic("cannot validate unknown type")
+	}
+	return errs
+}
+
+type ProvidersResponse struct {
+	Version   string     `json:"version"`
+	Providers []Provider `json:"providers"`
+}
+
+type ProviderResponse struct {
+	Version  string   `json:"version"`
+	Provider Provider `json:"provider"`
+}
+
+type ProviderRepository interface {
+	ListProviders(ctx context.Context) ([]Provider, error)
+	FetchProvider(ctx context.Context, providerID uuid.UUID) (Provider, error)
+	InsertProvider(ctx context.Context, provider Provider) error
+	UpdateProvider(ctx context.Context, provider Provider) error
+	DeleteProvider(ctx context.Context, providerID uuid.UUID) error
+}
@@ -0,0 +1,146 @@
+// Code generated by go-enum DO NOT EDIT.
+// Version:
+// Revision:
+// Build Date:
+// Built By:
+
+package domain
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+)
+
+const (
+	// ProviderStatusActive is a ProviderStatus of type Active.
+	ProviderStatusActive ProviderStatus = iota
+	// ProviderStatusSuspended is a ProviderStatus of type Suspended.
+	ProviderStatusSuspended
+)
+
+var ErrInvalidProviderStatus = errors.New("not a valid ProviderStatus")
+
+const _ProviderStatusName = "activesuspended"
+
+var _ProviderStatusMap = map[ProviderStatus]string{
+	ProviderStatusActive:    _ProviderStatusName[0:6],
+	ProviderStatusSuspended: _ProviderStatusName[6:15],
+}
+
+// String implements the Stringer interface.
+func (x ProviderStatus) String() string {
+	if str, ok := _ProviderStatusMap[x]; ok {
+		return str
+	}
+	return fmt.Sprintf("ProviderStatus(%d)", x)
+}
+
+// IsValid provides a quick way to determine if the typed value is
+// part of the allowed enumerated values
+func (x ProviderStatus) IsValid() bool {
+	_, ok := _ProviderStatusMap[x]
+	return ok
+}
+
+var _ProviderStatusValue = map[string]ProviderStatus{
+	_ProviderStatusName[0:6]:  ProviderStatusActive,
+	_ProviderStatusName[6:15]: ProviderStatusSuspended,
+}
+
+// ParseProviderStatus attempts to convert a string to a ProviderStatus.
+func ParseProviderStatus(name string) (ProviderStatus, error) {
+	if x, ok := _ProviderStatusValue[name]; ok {
+		return x, nil
+	}
+	return ProviderStatus(0), fmt.Errorf("%s is %w", name, ErrInvalidProviderStatus)
+}
+
+// MarshalText implements the text marshaller method.
+func (x ProviderStatus) MarshalText() ([]byte, error) {
+	return []byte(x.String()), nil
+}
+
+// UnmarshalText implements the text unmarshaller method.
+func (x *ProviderStatus) UnmarshalText(text []byte) error {
+	name := string(text)
+	tmp, err := ParseProviderStatus(name)
+	if err != nil {
+		return err
+	}
+	*x = tmp
+	return nil
+}
+
+var errProviderStatusNilPtr = errors.New("value pointer is nil") // one per type for package clashes
+
+// Scan implements the Scanner interface.
+func (x *ProviderStatus) Scan(value interface{}) (err error) {
+	if value == nil {
+		*x = ProviderStatus(0)
+		return
+	}
+
+	// A wider range of scannable types.
+	// driver.Value values at the top of the list for expediency
+	switch v := value.(type) {
+	case int64:
+		*x = ProviderStatus(v)
+	case string:
+		*x, err = ParseProviderStatus(v)
+	case []byte:
+		*x, err = ParseProviderStatus(string(v))
+	case ProviderStatus:
+		*x = v
+	case int:
+		*x = ProviderStatus(v)
+	case *ProviderStatus:
+		if v == nil {
+			return errProviderStatusNilPtr
+		}
+		*x = *v
+	case uint:
+		*x = ProviderStatus(v)
+	case uint64:
+		*x = ProviderStatus(v)
+	case *int:
+		if v == nil {
+			return errProviderStatusNilPtr
+		}
+		*x = ProviderStatus(*v)
+	case *int64:
+		if v == nil {
+			return errProviderStatusNilPtr
+		}
+		*x = ProviderStatus(*v)
+	case float64: // json marshals everything as a float64 if it's a number
+		*x = ProviderStatus(v)
+	case *float64: // json marshals everything as a float64 if it's a number
+		if v == nil {
+			return errProviderStatusNilPtr
+		}
+		*x = ProviderStatus(*v)
+	case *uint:
+		if v == nil {
+			return errProviderStatusNilPtr
+		}
+		*x = ProviderStatus(*v)
+	case *uint64:
+		if v == nil {
+			return errProviderStatusNilPtr
+		}
+		*x = ProviderStatus(*v)
+	case *string:
+		if v == nil {
+			return errProviderStatusNilPtr
+		}
+		*x, err = ParseProviderStatus(*v)
+	}
+
+	return
+}
+
+// Value implements the driver Valuer interface.
+func (x ProviderStatus) Value() (driver.Value, error) {
+	return x.String(), nil
+}
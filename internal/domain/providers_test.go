@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"encoding/json"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Provider", func() {
+	var provider Provider
+	BeforeEach(func() {
+		provider = Provider{
+			ProviderID:   uuid.MustParse("3c95765d-4da6-41c6-b61e-1954472ec6c9"),
+			ProviderName: "Scoot",
+			URL:          "https://example.com",
+		}
+	})
+
+	It("is valid w/ all required fields", func() {
+		Expect(ValidateProvider(provider)).To(BeEmpty())
+	})
+
+	It("requires a provider_id and provider_name", func() {
+		Expect(ValidateProvider(Provider{})).To(ConsistOf(
+			"provider_id: null UUID is not allowed",
+			"provider_name: missing required field",
+		))
+	})
+
+	It("requires url to be an absolute http(s) URL", func() {
+		provider.URL = "example.com"
+		Expect(ValidateProvider(provider)).To(ConsistOf("url: must be an absolute http(s) URL"))
+	})
+
+	It("cannot be its own data provider", func() {
+		provider.DataProviders = []uuid.UUID{provider.ProviderID}
+		Expect(ValidateProvider(provider)).To(ConsistOf("data_providers: 3c95765d-4da6-41c6-b61e-1954472ec6c9 is the provider itself"))
+	})
+
+	It("only delegates to its data providers", func() {
+		dataProviderID := uuid.MustParse("0c1b5ab4-1c1e-4b41-9d0e-5b2b0d0a8f53")
+		Expect(provider.DelegatesTo(dataProviderID)).To(BeFalse())
+		provider.DataProviders = []uuid.UUID{dataProviderID}
+		Expect(provider.DelegatesTo(dataProviderID)).To(BeTrue())
+	})
+
+	It("defaults to active", func() {
+		Expect(json.Unmarshal([]byte(`{"provider_id": "3c95765d-4da6-41c6-b61e-1954472ec6c9", "provider_name": "Scoot"}`), &provider)).To(Succeed())
+		Expect(provider.Status).To(Equal(ProviderStatusActive))
+	})
+
+	It("allows every vehicle type unless restricted", func() {
+		Expect(provider.AllowsVehicleType(VehicleTypeCar)).To(BeTrue())
+		provider.VehicleTypes = NewSet(VehicleTypeBicycle, VehicleTypeScooterStanding)
+		Expect(provider.AllowsVehicleType(VehicleTypeScooterStanding)).To(BeTrue())
+		Expect(provider.AllowsVehicleType(VehicleTypeCar)).To(BeFalse())
+	})
+})
@@ -0,0 +1,47 @@
+package domain
+
+// Readiness reports whether the server can handle requests: the database must be reachable and have
+// every migration that the server knows of applied.
+type Readiness struct {
+	Ready    bool              `json:"ready"`
+	Database DatabaseReadiness `json:"database"`
+	Schema   SchemaReadiness   `json:"schema"`
+}
+
+type DatabaseReadiness struct {
+	Ready bool   `json:"ready"`
+	Error string `json:"error,omitempty"`
+}
+
+type SchemaReadiness struct {
+	Ready bool `json:"ready"`
+	// Version is the newest migration applied to the database.
+	Version int64 `json:"version"`
+	// LatestVersion is the newest migration the server knows of.
+	LatestVersion int64  `json:"latest_version"`
+	Error         string `json:"error,omitempty"`
+}
+
+// NewReadiness combines the readiness of the database and its schema. The schema may be newer than
+// the server so that migrations can be applied before a new version of the server is deployed.
+func NewReadiness(database DatabaseReadiness, version int64, latestVersion int64, schemaErr error) Readiness {
+	schema := SchemaReadiness{
+		Version:       version,
+		LatestVersion: latestVersion,
+	}
+	switch {
+	case !database.Ready:
+		schema.Error = "database is unavailable"
+	case schemaErr != nil:
+		schema.Error = schemaErr.Error()
+	case version < latestVersion:
+		schema.Error = "database schema is behind the server's migrations"
+	default:
+		schema.Ready = true
+	}
+	return Readiness{
+		Ready:    database.Ready && schema.Ready,
+		Database: database,
+		Schema:   schema,
+	}
+}
@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Readiness", func() {
+	available := DatabaseReadiness{Ready: true}
+
+	It("is ready when the schema is up to date", func() {
+		readiness := NewReadiness(available, 20261016190000, 20261016190000, nil)
+		Expect(readiness.Ready).To(BeTrue())
+		Expect(readiness.Schema).To(Equal(SchemaReadiness{Ready: true, Version: 20261016190000, LatestVersion: 20261016190000}))
+	})
+
+	It("is ready when the schema is ahead of the server", func() {
+		Expect(NewReadiness(available, 20261016200000, 20261016190000, nil).Ready).To(BeTrue())
+	})
+
+	It("is not ready when the schema is behind", func() {
+		readiness := NewReadiness(available, 20261016180000, 20261016190000, nil)
+		Expect(readiness.Ready).To(BeFalse())
+		Expect(readiness.Database.Ready).To(BeTrue())
+		Expect(readiness.Schema.Error).To(Equal("database schema is behind the server's migrations"))
+	})
+
+	It("is not ready when the schema version can't be read", func() {
+		readiness := NewReadiness(available, 0, 20261016190000, errors.New("failed to read schema version"))
+		Expect(readiness.Ready).To(BeFalse())
+		Expect(readiness.Schema.Error).To(Equal("failed to read schema version"))
+	})
+
+	It("is not ready when the database is unavailable", func() {
+		readiness := NewReadiness(DatabaseReadiness{Error: "connection refused"}, 0, 20261016190000, nil)
+		Expect(readiness.Ready).To(BeFalse())
+		Expect(readiness.Schema.Ready).To(BeFalse())
+		Expect(readiness.Schema.Error).To(Equal("database is unavailable"))
+	})
+})
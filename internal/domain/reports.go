@@ -0,0 +1,78 @@
+//go:generate go run github.com/abice/go-enum@v0.5.6 --marshal --sql
+
+package domain
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ENUM(all_riders, low_income, adaptive_scooter)
+type SpecialGroupType int
+
+// ReportDuration is the only reporting period currently defined by MDS.
+const ReportDuration = "P1M"
+
+// maxPrivateCount is the largest count that must be redacted from reports to protect rider
+// privacy. MDS requires these counts to be reported as -1 instead.
+const maxPrivateCount = 10
+
+type MonthlyReport struct {
+	ProviderID       uuid.UUID        `json:"provider_id"`
+	StartDate        Timestamp        `json:"start_date"`
+	Duration         string           `json:"duration"`
+	SpecialGroupType SpecialGroupType `json:"special_group_type"`
+	GeographyID      uuid.UUID        `json:"geography_id"`
+	VehicleType      VehicleType      `json:"vehicle_type"`
+	TripCount        int              `json:"trip_count"`
+	RiderCount       int              `json:"rider_count"`
+}
+
+func validateReportCount(field string, count int) []string {
+	if count < -1 {
+		return []string{fmt.Sprintf("%s: must be non-negative or -1", field)}
+	}
+	if count > 0 && count <= maxPrivateCount {
+		return []string{fmt.Sprintf("%s: counts of %d or fewer must be reported as -1", field, maxPrivateCount)}
+	}
+	return nil
+}
+
+func ValidateReport(value any) []string {
+	var errs []string
+	switch r := value.(type) {
+	case MonthlyReport:
+		if r.StartDate.IsZero() {
+			errs = append(errs, "start_date: missing required field")
+		} else {
+			startDate := r.StartDate.UTC()
+			if !startDate.Equal(time.Date(startDate.Year(), startDate.Month(), 1, 0, 0, 0, 0, time.UTC)) {
+				errs = append(errs, "start_date: must be midnight UTC on the first day of a month")
+			}
+		}
+		if r.Duration != ReportDuration {
+			errs = append(errs, fmt.Sprintf("duration: must be %s", ReportDuration))
+		}
+		if r.GeographyID == (uuid.UUID{}) {
+			errs = append(errs, "geography_id: null UUID is not allowed")
+		}
+		errs = append(errs, validateReportCount("trip_count", r.TripCount)...)
+		errs = append(errs, validateReportCount("rider_count", r.RiderCount)...)
+	default:
+		panic("cannot validate unknown type")
+	}
+	return errs
+}
+
+type ReportsResponse struct {
+	Version string          `json:"version"`
+	Reports []MonthlyReport `json:"reports"`
+}
+
+type ReportRepository interface {
+	ListReports(ctx context.Context, providerID *uuid.UUID) ([]MonthlyReport, error)
+	InsertReport(ctx context.Context, report MonthlyReport) error
+}
@@ -0,0 +1,150 @@
+// Code generated by go-enum DO NOT EDIT.
+// Version:
+// Revision:
+// Build Date:
+// Built By:
+
+package domain
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+)
+
+const (
+	// SpecialGroupTypeAllRiders is a SpecialGroupType of type All_riders.
+	SpecialGroupTypeAllRiders SpecialGroupType = iota
+	// SpecialGroupTypeLowIncome is a SpecialGroupType of type Low_income.
+	SpecialGroupTypeLowIncome
+	// SpecialGroupTypeAdaptiveScooter is a SpecialGroupType of type Adaptive_scooter.
+	SpecialGroupTypeAdaptiveScooter
+)
+
+var ErrInvalidSpecialGroupType = errors.New("not a valid SpecialGroupType")
+
+const _SpecialGroupTypeName = "all_riderslow_incomeadaptive_scooter"
+
+var _SpecialGroupTypeMap = map[SpecialGroupType]string{
+	SpecialGroupTypeAllRiders:       _SpecialGroupTypeName[0:10],
+	SpecialGroupTypeLowIncome:       _SpecialGroupTypeName[10:20],
+	SpecialGroupTypeAdaptiveScooter: _SpecialGroupTypeName[20:36],
+}
+
+// String implements the Stringer interface.
+func (x SpecialGroupType) String() string {
+	if str, ok := _SpecialGroupTypeMap[x]; ok {
+		return str
+	}
+	return fmt.Sprintf("SpecialGroupType(%d)", x)
+}
+
+// IsValid provides a quick way to determine if the typed value is
+// part of the allowed enumerated values
+func (x SpecialGroupType) IsValid() bool {
+	_, ok := _SpecialGroupTypeMap[x]
+	return ok
+}
+
+var _SpecialGroupTypeValue = map[string]SpecialGroupType{
+	_SpecialGroupTypeName[0:10]:  SpecialGroupTypeAllRiders,
+	_SpecialGroupTypeName[10:20]: SpecialGroupTypeLowIncome,
+	_SpecialGroupTypeName[20:36]: SpecialGroupTypeAdaptiveScooter,
+}
+
+// ParseSpecialGroupType attempts to convert a string to a SpecialGroupType.
+func ParseSpecialGroupType(name string) (SpecialGroupType, error) {
+	if x, ok := _SpecialGroupTypeValue[name]; ok {
+		return x, nil
+	}
+	return SpecialGroupType(0), fmt.Errorf("%s is %w", name, ErrInvalidSpecialGroupType)
+}
+
+// MarshalText implements the text marshaller method.
+func (x SpecialGroupType) MarshalText() ([]byte, error) {
+	return []byte(x.String()), nil
+}
+
+// UnmarshalText implements the text unmarshaller method.
+func (x *SpecialGroupType) UnmarshalText(text []byte) error {
+	name := string(text)
+	tmp, err := ParseSpecialGroupType(name)
+	if err != nil {
+		return err
+	}
+	*x = tmp
+	return nil
+}
+
+var errSpecialGroupTypeNilPtr = errors.New("value pointer is nil") // one per type for package clashes
+
+// Scan implements the Scanner interface.
+func (x *SpecialGroupType) Scan(value interface{}) (err error) {
+	if value == nil {
+		*x = SpecialGroupType(0)
+		return
+	}
+
+	// A wider range of scannable types.
+	// driver.Value values at the top of the list for expediency
+	switch v := value.(type) {
+	case int64:
+		*x = SpecialGroupType(v)
+	case string:
+		*x, err = ParseSpecialGroupType(v)
+	case []byte:
+		*x, err = ParseSpecialGroupType(string(v))
+	case SpecialGroupType:
+		*x = v
+	case int:
+		*x = SpecialGroupType(v)
+	case *SpecialGroupType:
+		if v == nil {
+			return errSpecialGroupTypeNilPtr
+		}
+		*x = *v
+	case uint:
+		*x = SpecialGroupType(v)
+	case uint64:
+		*x = SpecialGroupType(v)
+	case *int:
+		if v == nil {
+			return errSpecialGroupTypeNilPtr
+		}
+		*x = SpecialGroupType(*v)
+	case *int64:
+		if v == nil {
+			return errSpecialGroupTypeNilPtr
+		}
+		*x = SpecialGroupType(*v)
+	case float64: // json marshals everything as a float64 if it's a number
+		*x = SpecialGroupType(v)
+	case *float64: // json marshals everything as a float64 if it's a number
+		if v == nil {
+			return errSpecialGroupTypeNilPtr
+		}
+		*x = SpecialGroupType(*v)
+	case *uint:
+		if v == nil {
+			return errSpecialGroupTypeNilPtr
+		}
+		*x = SpecialGroupType(*v)
+	case *uint64:
+		if v == nil {
+			return errSpecialGroupTypeNilPtr
+		}
+		*x = SpecialGroupType(*v)
+	case *string:
+		if v == nil {
+			return errSpecialGroupTypeNilPtr
+		}
+		*x, err = ParseSpecialGroupType(*v)
+	}
+
+	return
+}
+
+// Value implements the driver Valuer interface.
+func (x SpecialGroupType) Value() (driver.Value, error) {
+	return x.String(), nil
+}
@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Report", func() {
+	var report MonthlyReport
+	BeforeEach(func() {
+		report = MonthlyReport{
+			ProviderID:       uuid.MustParse("a2b1c9a4-5a8d-4d1e-9c8e-2f0b7a1d3c4e"),
+			StartDate:        NewTimestamp(time.Date(2023, time.June, 1, 0, 0, 0, 0, time.UTC)),
+			Duration:         ReportDuration,
+			SpecialGroupType: SpecialGroupTypeAllRiders,
+			GeographyID:      uuid.MustParse("7b3c2d1e-0f9a-4b8c-8d7e-6f5a4b3c2d1e"),
+			VehicleType:      VehicleTypeScooterStanding,
+			TripCount:        1240,
+			RiderCount:       310,
+		}
+	})
+
+	It("is valid w/ all required fields", func() {
+		Expect(ValidateReport(report)).To(BeEmpty())
+	})
+
+	It("requires start_date to be the beginning of a month", func() {
+		report.StartDate = NewTimestamp(time.Date(2023, time.June, 2, 0, 0, 0, 0, time.UTC))
+		Expect(ValidateReport(report)).To(ConsistOf("start_date: must be midnight UTC on the first day of a month"))
+	})
+
+	It("requires a monthly duration", func() {
+		report.Duration = "P1W"
+		Expect(ValidateReport(report)).To(ConsistOf("duration: must be P1M"))
+	})
+
+	It("requires small counts to be redacted", func() {
+		report.RiderCount = 7
+		Expect(ValidateReport(report)).To(ConsistOf("rider_count: counts of 10 or fewer must be reported as -1"))
+	})
+
+	It("accepts redacted and zero counts", func() {
+		report.TripCount = 0
+		report.RiderCount = -1
+		Expect(ValidateReport(report)).To(BeEmpty())
+	})
+})
@@ -26,6 +26,13 @@ func NewSet[T constraints.Ordered](items ...T) Set[T] {
 	return elements
 }
 
+func (pts Set[T]) Contains(item T) bool {
+	i := sort.Search(len(pts), func(i int) bool {
+		return pts[i] >= item
+	})
+	return i < len(pts) && pts[i] == item
+}
+
 func (pts *Set[T]) UnmarshalJSON(data []byte) (err error) {
 	var elements []T
 	err = json.Unmarshal(data, &elements)
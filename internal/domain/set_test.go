@@ -32,4 +32,10 @@ var _ = Describe("Set", func() {
 			NewSet("electric", "combustion"),
 		))
 	})
+
+	It("reports whether it contains an element", func() {
+		set := NewSet("electric", "combustion")
+		Expect(set.Contains("electric")).To(BeTrue())
+		Expect(set.Contains("human")).To(BeFalse())
+	})
 })
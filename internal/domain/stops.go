@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+type StopStatus struct {
+	IsInstalled bool `json:"is_installed"`
+	IsRenting   bool `json:"is_renting"`
+	IsReturning bool `json:"is_returning"`
+}
+
+// VehicleTypeCounts maps vehicle types to the number of vehicles (or places for vehicles) of that type.
+type VehicleTypeCounts map[VehicleType]int
+
+type Stop struct {
+	StopID               uuid.UUID         `json:"stop_id"`
+	Name                 string            `json:"name"`
+	LastReported         Timestamp         `json:"last_reported"`
+	Location             GPS               `json:"location"`
+	Status               StopStatus        `json:"status"`
+	ProviderID           uuid.UUID         `json:"provider_id,omitempty"`
+	DataProviderID       uuid.UUID         `json:"data_provider_id,omitempty"`
+	RegionID             string            `json:"region_id,omitempty"`
+	ShortName            string            `json:"short_name,omitempty"`
+	Address              string            `json:"address,omitempty"`
+	PostCode             string            `json:"post_code,omitempty"`
+	CrossStreet          string            `json:"cross_street,omitempty"`
+	RentalMethods        []string          `json:"rental_methods,omitempty"`
+	NumVehiclesAvailable VehicleTypeCounts `json:"num_vehicles_available"`
+	NumVehiclesDisabled  VehicleTypeCounts `json:"num_vehicles_disabled,omitempty"`
+	NumPlacesAvailable   VehicleTypeCounts `json:"num_places_available,omitempty"`
+	NumPlacesDisabled    VehicleTypeCounts `json:"num_places_disabled,omitempty"`
+	ParentStop           *uuid.UUID        `json:"parent_stop,omitempty"`
+	Devices              []uuid.UUID       `json:"devices,omitempty"`
+	WheelchairBoarding   bool              `json:"wheelchair_boarding,omitempty"`
+}
+
+func validateVehicleTypeCounts(field string, counts VehicleTypeCounts) []string {
+	var errs []string
+	for vehicleType, count := range counts {
+		if count < 0 {
+			errs = append(errs, fmt.Sprintf("%s.%s: must be non-negative", field, vehicleType))
+		}
+	}
+	return errs
+}
+
+func ValidateStop(value any) []string {
+	var errs []string
+	switch s := value.(type) {
+	case Stop:
+		if s.StopID == (uuid.UUID{}) {
+			errs = append(errs, "stop_id: null UUID is not allowed")
+		}
+		if s.Name == "" {
+			errs = append(errs, "name: missing required field")
+		}
+		if s.LastReported.IsZero() {
+			errs = append(errs, "last_reported: missing required field")
+		}
+		if s.ParentStop != nil && *s.ParentStop == s.StopID {
+			errs = append(errs, "parent_stop: a stop cannot be its own parent")
+		}
+		errs = append(errs, validateGPS("location", s.Location)...)
+		errs = append(errs, validateVehicleTypeCounts("num_vehicles_available", s.NumVehiclesAvailable)...)
+		errs = append(errs, validateVehicleTypeCounts("num_vehicles_disabled", s.NumVehiclesDisabled)...)
+		errs = append(errs, validateVehicleTypeCounts("num_places_available", s.NumPlacesAvailable)...)
+		errs = append(errs, validateVehicleTypeCounts("num_places_disabled", s.NumPlacesDisabled)...)
+	default:
+		panic("cannot validate unknown type")
+	}
+	return errs
+}
+
+type StopsResponse struct {
+	Version string `json:"version"`
+	Stops   []Stop `json:"stops"`
+}
+
+type StopRepository interface {
+	FetchStop(ctx context.Context, stopID uuid.UUID) (Stop, error)
+	ListStops(ctx context.Context) ([]Stop, error)
+	InsertStop(ctx context.Context, stop Stop) error
+	UpdateStop(ctx context.Context, stop Stop) error
+}
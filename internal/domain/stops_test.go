@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Stop", func() {
+	var stop Stop
+	BeforeEach(func() {
+		stop = Stop{
+			StopID:               uuid.MustParse("6a1c2b3d-4e5f-4a6b-8c7d-9e0f1a2b3c4d"),
+			Name:                 "Pioneer Courthouse Square",
+			LastReported:         NewTimestamp(time.UnixMilli(1690000000000)),
+			Location:             GPS{Lat: 45.5189, Lng: -122.6793},
+			Status:               StopStatus{IsInstalled: true, IsRenting: true, IsReturning: true},
+			NumVehiclesAvailable: VehicleTypeCounts{VehicleTypeScooterStanding: 4},
+		}
+	})
+
+	It("is valid w/ all required fields", func() {
+		Expect(ValidateStop(stop)).To(BeEmpty())
+	})
+
+	It("requires a name", func() {
+		stop.Name = ""
+		Expect(ValidateStop(stop)).To(ConsistOf("name: missing required field"))
+	})
+
+	It("rejects negative vehicle counts", func() {
+		stop.NumVehiclesDisabled = VehicleTypeCounts{VehicleTypeBicycle: -1}
+		Expect(ValidateStop(stop)).To(ConsistOf("num_vehicles_disabled.bicycle: must be non-negative"))
+	})
+
+	It("rejects stops that are their own parent", func() {
+		stop.ParentStop = &stop.StopID
+		Expect(ValidateStop(stop)).To(ConsistOf("parent_stop: a stop cannot be its own parent"))
+	})
+
+	It("marshals vehicle type counts as an object keyed by vehicle type", func() {
+		Expect(json.Marshal(stop.NumVehiclesAvailable)).To(MatchJSON(`{ "scooter_standing": 4 }`))
+	})
+})
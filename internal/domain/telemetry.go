@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type Telemetry struct {
+	TelemetryID    uuid.UUID   `json:"telemetry_id"`
+	DeviceID       uuid.UUID   `json:"device_id"`
+	ProviderID     uuid.UUID   `json:"provider_id"`
+	DataProviderID uuid.UUID   `json:"data_provider_id,omitempty"`
+	Timestamp      Timestamp   `json:"timestamp"`
+	Location       GPS         `json:"location"`
+	BatteryPercent int         `json:"battery_percent,omitempty"`
+	FuelPercent    int         `json:"fuel_percent,omitempty"`
+	JourneyID      uuid.UUID   `json:"journey_id,omitempty"`
+	TripIDs        []uuid.UUID `json:"trip_ids,omitempty"`
+}
+
+func ValidateTelemetry(value any) []string {
+	var errs []string
+	switch t := value.(type) {
+	case Telemetry:
+		if t.TelemetryID == (uuid.UUID{}) {
+			errs = append(errs, "telemetry_id: null UUID is not allowed")
+		}
+		if t.DeviceID == (uuid.UUID{}) {
+			errs = append(errs, "device_id: null UUID is not allowed")
+		}
+		if t.Timestamp.IsZero() {
+			errs = append(errs, "timestamp: missing required field")
+		}
+		if t.BatteryPercent < 0 || t.BatteryPercent > 100 {
+			errs = append(errs, "battery_percent: must be between 0 and 100")
+		}
+		if t.FuelPercent < 0 || t.FuelPercent > 100 {
+			errs = append(errs, "fuel_percent: must be between 0 and 100")
+		}
+		errs = append(errs, validateGPS("location", t.Location)...)
+	default:
+		panic("cannot validate unknown type")
+	}
+	return errs
+}
+
+type TelemetryResponse struct {
+	Version   string      `json:"version"`
+	Telemetry []Telemetry `json:"telemetry"`
+}
+
+type ListTelemetryParams struct {
+	ProviderID *uuid.UUID
+	Timestamp  TimeRange
+}
+
+type TelemetryRepository interface {
+	ListTelemetry(ctx context.Context, params ListTelemetryParams) ([]Telemetry, error)
+	InsertTelemetry(ctx context.Context, telemetry Telemetry) error
+}
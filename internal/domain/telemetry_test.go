@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Telemetry", func() {
+	var telemetry Telemetry
+	BeforeEach(func() {
+		telemetry = Telemetry{
+			TelemetryID: uuid.MustParse("0b9f2e3c-1d4a-4c5b-8e6f-7a8b9c0d1e2f"),
+			DeviceID:    uuid.MustParse("1443963e-7d93-469c-b8e1-a262715c3b49"),
+			Timestamp:   NewTimestamp(time.UnixMilli(1690000000000)),
+			Location:    GPS{Lat: 45.5, Lng: -122.6},
+		}
+	})
+
+	It("is valid w/ all required fields", func() {
+		Expect(ValidateTelemetry(telemetry)).To(BeEmpty())
+	})
+
+	It("requires a timestamp", func() {
+		telemetry.Timestamp = Timestamp{}
+		Expect(ValidateTelemetry(telemetry)).To(ConsistOf("timestamp: missing required field"))
+	})
+
+	It("requires a location", func() {
+		telemetry.Location = GPS{}
+		Expect(ValidateTelemetry(telemetry)).To(ConsistOf("location: missing required field"))
+	})
+
+	It("rejects invalid GPS readings", func() {
+		telemetry.Location.Lng = 181
+		telemetry.Location.Heading = 360
+		Expect(ValidateTelemetry(telemetry)).To(ConsistOf(
+			"location.lng: must be between -180 and 180",
+			"location.heading: must be between 0 and 360",
+		))
+	})
+
+	It("rejects battery percentages over 100", func() {
+		telemetry.BatteryPercent = 101
+		Expect(ValidateTelemetry(telemetry)).To(ConsistOf("battery_percent: must be between 0 and 100"))
+	})
+})
@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Timestamp is an instant in time that is serialized as the number of milliseconds since
+// the Unix epoch, which is how MDS represents all timestamps.
+type Timestamp struct {
+	time.Time
+}
+
+func NewTimestamp(t time.Time) Timestamp {
+	return Timestamp{t.Truncate(time.Millisecond).UTC()}
+}
+
+func (ts Timestamp) MarshalJSON() ([]byte, error) {
+	return json.Marshal(ts.UnixMilli())
+}
+
+func (ts *Timestamp) UnmarshalJSON(data []byte) (err error) {
+	var millis int64
+	err = json.Unmarshal(data, &millis)
+	if err != nil {
+		return
+	}
+	*ts = NewTimestamp(time.UnixMilli(millis))
+	return
+}
+
+// TimeRange is the half-open interval of time [Start, End).
+type TimeRange struct {
+	Start time.Time
+	End   time.Time
+}
+
+// HourFormat is the format MDS uses to identify the hour of data requested from the hourly
+// Provider API endpoints, e.g. 2023-07-20T14 for 2:00 PM to 3:00 PM UTC.
+const HourFormat = "2006-01-02T15"
+
+// ParseHour parses an hour in HourFormat into the range of time it covers.
+func ParseHour(value string) (hour TimeRange, err error) {
+	start, err := time.ParseInLocation(HourFormat, value, time.UTC)
+	if err != nil {
+		return
+	}
+	hour = TimeRange{
+		Start: start,
+		End:   start.Add(time.Hour),
+	}
+	return
+}
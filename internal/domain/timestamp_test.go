@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ParseHour", func() {
+	It("parses an hour into the range of time it covers", func() {
+		Expect(ParseHour("2023-07-20T14")).To(Equal(TimeRange{
+			Start: time.Date(2023, time.July, 20, 14, 0, 0, 0, time.UTC),
+			End:   time.Date(2023, time.July, 20, 15, 0, 0, 0, time.UTC),
+		}))
+	})
+
+	It("rolls over to the next day at the end of the day", func() {
+		hour, err := ParseHour("2023-07-20T23")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(hour.End).To(Equal(time.Date(2023, time.July, 21, 0, 0, 0, 0, time.UTC)))
+	})
+
+	DescribeTable("rejects malformed hours",
+		func(value string) {
+			_, err := ParseHour(value)
+			Expect(err).To(HaveOccurred())
+		},
+		Entry(nil, ""),
+		Entry(nil, "2023-07-20"),
+		Entry(nil, "2023-07-20T14:00"),
+		Entry(nil, "2023-07-20T24"),
+		Entry(nil, "1690000000000"),
+	)
+})
@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type Fare struct {
+	QuotedCost         int      `json:"quoted_cost,omitempty"`
+	ActualCost         int      `json:"actual_cost"`
+	Components         Record   `json:"components,omitempty"`
+	Currency           string   `json:"currency"`
+	PaymentMethods     []string `json:"payment_methods,omitempty"`
+	FixedPrice         bool     `json:"fixed_price,omitempty"`
+	TransitIntegration bool     `json:"transit_integration,omitempty"`
+}
+
+type Trip struct {
+	TripID          uuid.UUID  `json:"trip_id"`
+	DeviceID        uuid.UUID  `json:"device_id"`
+	ProviderID      uuid.UUID  `json:"provider_id"`
+	DataProviderID  uuid.UUID  `json:"data_provider_id,omitempty"`
+	JourneyID       uuid.UUID  `json:"journey_id,omitempty"`
+	StartTime       Timestamp  `json:"start_time"`
+	EndTime         Timestamp  `json:"end_time"`
+	StartLocation   GPS        `json:"start_location"`
+	EndLocation     GPS        `json:"end_location"`
+	Duration        int        `json:"duration"`
+	Distance        int        `json:"distance"`
+	Fare            *Fare      `json:"fare,omitempty"`
+	TripAttributes  Record     `json:"trip_attributes,omitempty"`
+	PublicationTime *Timestamp `json:"publication_time,omitempty"`
+}
+
+func ValidateTrip(value any) []string {
+	var errs []string
+	switch t := value.(type) {
+	case Trip:
+		if t.TripID == (uuid.UUID{}) {
+			errs = append(errs, "trip_id: null UUID is not allowed")
+		}
+		if t.DeviceID == (uuid.UUID{}) {
+			errs = append(errs, "device_id: null UUID is not allowed")
+		}
+		if t.StartTime.IsZero() {
+			errs = append(errs, "start_time: missing required field")
+		}
+		if t.EndTime.IsZero() {
+			errs = append(errs, "end_time: missing required field")
+		}
+		if t.EndTime.Before(t.StartTime.Time) {
+			errs = append(errs, "end_time: must not be before start_time")
+		}
+		if t.Duration < 0 {
+			errs = append(errs, "duration: must be non-negative")
+		}
+		if t.Distance < 0 {
+			errs = append(errs, "distance: must be non-negative")
+		}
+		errs = append(errs, validateGPS("start_location", t.StartLocation)...)
+		errs = append(errs, validateGPS("end_location", t.EndLocation)...)
+		if t.Fare != nil {
+			if len(t.Fare.Currency) != 3 {
+				errs = append(errs, "fare.currency: must be an ISO 4217 currency code")
+			}
+		}
+	default:
+		panic("cannot validate unknown type")
+	}
+	return errs
+}
+
+type TripsResponse struct {
+	Version string `json:"version"`
+	Trips   []Trip `json:"trips"`
+}
+
+type ListTripsParams struct {
+	ProviderID *uuid.UUID
+	EndTime    TimeRange
+}
+
+type TripRepository interface {
+	ListTrips(ctx context.Context, params ListTripsParams) ([]Trip, error)
+	InsertTrip(ctx context.Context, trip Trip) error
+}
@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Trip", func() {
+	var trip Trip
+	BeforeEach(func() {
+		startTime := time.UnixMilli(1690000000000)
+		trip = Trip{
+			TripID:        uuid.MustParse("5f2f0d9c-6b1e-4a0e-9d8c-7b6a5f4e3d2c"),
+			DeviceID:      uuid.MustParse("1443963e-7d93-469c-b8e1-a262715c3b49"),
+			StartTime:     NewTimestamp(startTime),
+			EndTime:       NewTimestamp(startTime.Add(10 * time.Minute)),
+			StartLocation: GPS{Lat: 45.5, Lng: -122.6},
+			EndLocation:   GPS{Lat: 45.52, Lng: -122.68},
+			Duration:      600,
+			Distance:      2400,
+		}
+	})
+
+	It("is valid w/ all required fields", func() {
+		Expect(ValidateTrip(trip)).To(BeEmpty())
+	})
+
+	It("rejects trips that end before they start", func() {
+		trip.EndTime = NewTimestamp(trip.StartTime.Add(-time.Minute))
+		Expect(ValidateTrip(trip)).To(ConsistOf("end_time: must not be before start_time"))
+	})
+
+	It("rejects fares w/o a valid currency", func() {
+		trip.Fare = &Fare{ActualCost: 350, Currency: "dollars"}
+		Expect(ValidateTrip(trip)).To(ConsistOf("fare.currency: must be an ISO 4217 currency code"))
+	})
+
+	It("round trips through JSON", func() {
+		trip.Fare = &Fare{ActualCost: 350, Currency: "USD", PaymentMethods: []string{"cash"}}
+		data, err := json.Marshal(trip)
+		Expect(err).NotTo(HaveOccurred())
+
+		var output Trip
+		Expect(json.Unmarshal(data, &output)).To(Succeed())
+		Expect(output).To(Equal(trip))
+	})
+})
@@ -48,11 +48,11 @@ type PaginatedVehiclesResponse struct {
 
 type FetchVehicleParams struct {
 	VehicleID  uuid.UUID
-	ProviderID uuid.UUID
+	ProviderID *uuid.UUID
 }
 
 type ListVehiclesParams struct {
-	ProviderID uuid.UUID
+	ProviderID *uuid.UUID
 	Offset     int32
 	Limit      int32
 }
@@ -63,3 +63,21 @@ type VehicleRepository interface {
 	InsertVehicle(ctx context.Context, vehicle Vehicle) error
 	UpdateVehicle(ctx context.Context, vehicle Vehicle) error
 }
+
+type VehicleStatus struct {
+	DeviceID       uuid.UUID  `json:"device_id"`
+	ProviderID     uuid.UUID  `json:"provider_id"`
+	DataProviderID uuid.UUID  `json:"data_provider_id,omitempty"`
+	LastEvent      *Event     `json:"last_event"`
+	LastTelemetry  *Telemetry `json:"last_telemetry"`
+}
+
+type PaginatedVehicleStatusesResponse struct {
+	PaginatedResponse
+	VehiclesStatus []VehicleStatus `json:"vehicles_status"`
+}
+
+type VehicleStatusRepository interface {
+	FetchVehicleStatus(ctx context.Context, params FetchVehicleParams) (VehicleStatus, error)
+	ListVehicleStatuses(ctx context.Context, params ListVehiclesParams) (Page[VehicleStatus], error)
+}
@@ -76,3 +76,20 @@ var _ = Describe("PaginatedVehiclesResponse", func() {
 		`))
 	})
 })
+
+var _ = Describe("VehicleStatus", func() {
+	It("marshals missing events and telemetry as null", func() {
+		Expect(json.Marshal(VehicleStatus{
+			DeviceID:   uuid.MustParse("1443963e-7d93-469c-b8e1-a262715c3b49"),
+			ProviderID: uuid.MustParse("a2b1c9a4-5a8d-4d1e-9c8e-2f0b7a1d3c4e"),
+		})).To(MatchJSON(`
+		{
+			"device_id": "1443963e-7d93-469c-b8e1-a262715c3b49",
+			"provider_id": "a2b1c9a4-5a8d-4d1e-9c8e-2f0b7a1d3c4e",
+			"data_provider_id": "00000000-0000-0000-0000-000000000000",
+			"last_event": null,
+			"last_telemetry": null
+		}
+		`))
+	})
+})
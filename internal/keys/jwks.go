@@ -0,0 +1,260 @@
+package keys
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/big"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+)
+
+// minRefreshInterval limits how often a token with an unknown key ID can trigger a refresh.
+const minRefreshInterval = 10 * time.Second
+
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+}
+
+type jwksDocument struct {
+	Keys []jwk `json:"keys"`
+}
+
+func decodeBigInt(field string, value string) (*big.Int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil || len(data) == 0 {
+		return nil, fmt.Errorf("malformed %s", field)
+	}
+	return new(big.Int).SetBytes(data), nil
+}
+
+func parseJWK(key jwk) (crypto.PublicKey, error) {
+	switch key.Kty {
+	case "RSA":
+		n, err := decodeBigInt("n", key.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt("e", key.E)
+		if err != nil {
+			return nil, err
+		}
+		if !e.IsInt64() || e.Int64() > 1<<31-1 {
+			return nil, fmt.Errorf("exponent too large")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch key.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", key.Crv)
+		}
+		x, err := decodeBigInt("x", key.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt("y", key.Y)
+		if err != nil {
+			return nil, err
+		}
+		if !curve.IsOnCurve(x, y) {
+			return nil, fmt.Errorf("point is not on curve %s", key.Crv)
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	case "OKP":
+		if key.Crv != "Ed25519" {
+			return nil, fmt.Errorf("unsupported curve %q", key.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(key.X)
+		if err != nil || len(x) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("malformed x")
+		}
+		return ed25519.PublicKey(x), nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", key.Kty)
+	}
+}
+
+type identifiedKey struct {
+	kid string
+	key crypto.PublicKey
+}
+
+// parseJWKS returns the signing keys in a JWKS document. Keys that can't be used to verify tokens
+// are skipped rather than failing the whole document, so that a provider of keys can publish key
+// types we don't support yet.
+func parseJWKS(data []byte) ([]identifiedKey, error) {
+	var document jwksDocument
+	err := json.Unmarshal(data, &document)
+	if err != nil {
+		return nil, fmt.Errorf("malformed JWKS document: %w", err)
+	}
+	keys := make([]identifiedKey, 0, len(document.Keys))
+	for _, key := range document.Keys {
+		if key.Use != "" && key.Use != "sig" {
+			continue
+		}
+		publicKey, err := parseJWK(key)
+		if err != nil {
+			slog.Warn("skipping key in JWKS document", "kid", key.Kid, "error", err)
+			continue
+		}
+		keys = append(keys, identifiedKey{key.Kid, publicKey})
+	}
+	return keys, nil
+}
+
+// JWKS is a Set backed by a JSON Web Key Set document, which is cached and periodically
+// refreshed. Tokens signed with a key ID that isn't in the cache trigger an early refresh.
+type JWKS struct {
+	source             *url.URL
+	client             *http.Client
+	minRefreshInterval time.Duration
+
+	refreshLock sync.Mutex
+	lock        sync.RWMutex
+	keys        []identifiedKey
+	fetchedAt   time.Time
+}
+
+// NewJWKS fetches the JWKS document at source and refreshes it every refreshInterval until ctx is
+// done. A refreshInterval of zero disables periodic refreshes.
+func NewJWKS(ctx context.Context, source *url.URL, refreshInterval time.Duration) (*JWKS, error) {
+	jwks := &JWKS{
+		source:             source,
+		client:             &http.Client{Timeout: 10 * time.Second},
+		minRefreshInterval: minRefreshInterval,
+	}
+	err := jwks.Refresh(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if refreshInterval > 0 {
+		go jwks.refreshPeriodically(ctx, refreshInterval)
+	}
+	return jwks, nil
+}
+
+func (jwks *JWKS) refreshPeriodically(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			err := jwks.Refresh(ctx)
+			if err != nil {
+				slog.Error("failed to refresh JWKS", "url", jwks.source.String(), "error", err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (jwks *JWKS) fetch(ctx context.Context) ([]byte, error) {
+	if jwks.source.Scheme == "file" {
+		return os.ReadFile(jwks.source.Path)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, jwks.source.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	res, err := jwks.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("got unexpected http status code in response: %s", res.Status)
+	}
+	return io.ReadAll(res.Body)
+}
+
+// Refresh replaces the cached keys with those currently published at the JWKS source. The cached
+// keys are kept if the source can't be read.
+func (jwks *JWKS) Refresh(ctx context.Context) error {
+	jwks.refreshLock.Lock()
+	defer jwks.refreshLock.Unlock()
+	return jwks.refresh(ctx)
+}
+
+func (jwks *JWKS) refresh(ctx context.Context) error {
+	data, err := jwks.fetch(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch JWKS document: %w", err)
+	}
+	keys, err := parseJWKS(data)
+	if err != nil {
+		return err
+	}
+	jwks.lock.Lock()
+	defer jwks.lock.Unlock()
+	jwks.keys = keys
+	jwks.fetchedAt = time.Now()
+	return nil
+}
+
+// lookup returns the cached keys with the given key ID, or every cached key if kid is empty.
+func (jwks *JWKS) lookup(kid string) (keys []crypto.PublicKey, fetchedAt time.Time) {
+	jwks.lock.RLock()
+	defer jwks.lock.RUnlock()
+	for _, key := range jwks.keys {
+		if kid == "" || key.kid == kid {
+			keys = append(keys, key.key)
+		}
+	}
+	return keys, jwks.fetchedAt
+}
+
+func (jwks *JWKS) Keys(ctx context.Context, kid string, alg string) ([]crypto.PublicKey, error) {
+	keys, fetchedAt := jwks.lookup(kid)
+	if len(keys) == 0 && kid != "" {
+		keys = jwks.refreshForUnknownKey(ctx, kid, fetchedAt)
+	}
+	keys = keysSupportingAlgorithm(keys, alg)
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownKey, kid)
+	}
+	return keys, nil
+}
+
+func (jwks *JWKS) refreshForUnknownKey(ctx context.Context, kid string, fetchedAt time.Time) []crypto.PublicKey {
+	jwks.refreshLock.Lock()
+	defer jwks.refreshLock.Unlock()
+	// Another request may have refreshed the keys while we were waiting.
+	keys, latestFetchedAt := jwks.lookup(kid)
+	if len(keys) > 0 || latestFetchedAt != fetchedAt || time.Since(fetchedAt) < jwks.minRefreshInterval {
+		return keys
+	}
+	err := jwks.refresh(ctx)
+	if err != nil {
+		slog.Error("failed to refresh JWKS", "url", jwks.source.String(), "error", err)
+	}
+	keys, _ = jwks.lookup(kid)
+	return keys
+}
@@ -0,0 +1,179 @@
+package keys
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func generateKey() *rsa.PrivateKey {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	Expect(err).NotTo(HaveOccurred())
+	return key
+}
+
+func marshalJWKS(keys map[string]*rsa.PrivateKey) []byte {
+	var document jwksDocument
+	for kid, key := range keys {
+		document.Keys = append(document.Keys, jwk{
+			Kty: "RSA",
+			Kid: kid,
+			Use: "sig",
+			N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		})
+	}
+	data, err := json.Marshal(document)
+	Expect(err).NotTo(HaveOccurred())
+	return data
+}
+
+// jwksServer serves a JWKS document whose keys can be changed during a test.
+type jwksServer struct {
+	*httptest.Server
+	lock     sync.Mutex
+	keys     map[string]*rsa.PrivateKey
+	requests int
+}
+
+func newJWKSServer(keys map[string]*rsa.PrivateKey) *jwksServer {
+	server := &jwksServer{keys: keys}
+	server.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		server.lock.Lock()
+		defer server.lock.Unlock()
+		server.requests += 1
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(marshalJWKS(server.keys))
+	}))
+	DeferCleanup(server.Close)
+	return server
+}
+
+func (server *jwksServer) setKeys(keys map[string]*rsa.PrivateKey) {
+	server.lock.Lock()
+	defer server.lock.Unlock()
+	server.keys = keys
+}
+
+func (server *jwksServer) requestCount() int {
+	server.lock.Lock()
+	defer server.lock.Unlock()
+	return server.requests
+}
+
+func (server *jwksServer) sourceURL() *url.URL {
+	source, err := url.Parse(server.URL + "/jwks.json")
+	Expect(err).NotTo(HaveOccurred())
+	return source
+}
+
+var _ = Describe("JWKS", func() {
+	var ctx context.Context
+	var firstKey, secondKey *rsa.PrivateKey
+	BeforeEach(func() {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithCancel(context.Background())
+		DeferCleanup(cancel)
+		firstKey = generateKey()
+		secondKey = generateKey()
+	})
+
+	It("selects keys by key ID", func() {
+		server := newJWKSServer(map[string]*rsa.PrivateKey{"first": firstKey, "second": secondKey})
+		jwks, err := NewJWKS(ctx, server.sourceURL(), 0)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(jwks.Keys(ctx, "first", "RS256")).To(ConsistOf(&firstKey.PublicKey))
+		Expect(jwks.Keys(ctx, "second", "RS256")).To(ConsistOf(&secondKey.PublicKey))
+		Expect(server.requestCount()).To(Equal(1))
+	})
+
+	It("tries every key for tokens without a key ID", func() {
+		server := newJWKSServer(map[string]*rsa.PrivateKey{"first": firstKey, "second": secondKey})
+		jwks, err := NewJWKS(ctx, server.sourceURL(), 0)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(jwks.Keys(ctx, "", "RS256")).To(ConsistOf(&firstKey.PublicKey, &secondKey.PublicKey))
+	})
+
+	It("only returns keys that support the token's algorithm", func() {
+		server := newJWKSServer(map[string]*rsa.PrivateKey{"first": firstKey})
+		jwks, err := NewJWKS(ctx, server.sourceURL(), 0)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = jwks.Keys(ctx, "first", "ES256")
+		Expect(err).To(MatchError(ErrUnknownKey))
+	})
+
+	It("refreshes when asked for an unknown key ID", func() {
+		server := newJWKSServer(map[string]*rsa.PrivateKey{"first": firstKey})
+		jwks, err := NewJWKS(ctx, server.sourceURL(), 0)
+		Expect(err).NotTo(HaveOccurred())
+		jwks.minRefreshInterval = 0
+
+		server.setKeys(map[string]*rsa.PrivateKey{"second": secondKey})
+		Expect(jwks.Keys(ctx, "second", "RS256")).To(ConsistOf(&secondKey.PublicKey))
+		_, err = jwks.Keys(ctx, "first", "RS256")
+		Expect(err).To(MatchError(ErrUnknownKey))
+	})
+
+	It("limits how often unknown key IDs trigger a refresh", func() {
+		server := newJWKSServer(map[string]*rsa.PrivateKey{"first": firstKey})
+		jwks, err := NewJWKS(ctx, server.sourceURL(), 0)
+		Expect(err).NotTo(HaveOccurred())
+
+		for i := 0; i < 3; i++ {
+			_, err = jwks.Keys(ctx, "unknown", "RS256")
+			Expect(err).To(MatchError(ErrUnknownKey))
+		}
+		Expect(server.requestCount()).To(Equal(1))
+	})
+
+	It("refreshes periodically", func() {
+		server := newJWKSServer(map[string]*rsa.PrivateKey{"first": firstKey})
+		jwks, err := NewJWKS(ctx, server.sourceURL(), 10*time.Millisecond)
+		Expect(err).NotTo(HaveOccurred())
+
+		server.setKeys(map[string]*rsa.PrivateKey{"second": secondKey})
+		Eventually(func() []crypto.PublicKey {
+			keys, _ := jwks.lookup("second")
+			return keys
+		}).Should(ConsistOf(&secondKey.PublicKey))
+	})
+
+	It("keeps the cached keys when the source is unavailable", func() {
+		server := newJWKSServer(map[string]*rsa.PrivateKey{"first": firstKey})
+		jwks, err := NewJWKS(ctx, server.sourceURL(), 0)
+		Expect(err).NotTo(HaveOccurred())
+
+		server.Close()
+		Expect(jwks.Refresh(ctx)).To(HaveOccurred())
+		Expect(jwks.Keys(ctx, "first", "RS256")).To(ConsistOf(&firstKey.PublicKey))
+	})
+
+	It("parses EC and OKP keys", func() {
+		keys, err := parseJWKS([]byte(`{"keys": [
+			{"kty": "EC", "kid": "ec", "crv": "P-256", "x": "f83OJ3D2xF1Bg8vub9tLe1gHMzV76e8Tus9uPHvRVEU", "y": "x_FEzRu9m36HLN_tue659LNpXW6pCyStikYjKIWI5a0"},
+			{"kty": "OKP", "kid": "ed", "crv": "Ed25519", "x": "11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo"}
+		]}`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(keys).To(HaveLen(2))
+		Expect(SupportsAlgorithm(keys[0].key, "ES256")).To(BeTrue())
+		Expect(SupportsAlgorithm(keys[1].key, "EdDSA")).To(BeTrue())
+	})
+
+	It("skips unsupported keys", func() {
+		keys, err := parseJWKS([]byte(`{"keys": [{"kty": "oct", "kid": "secret", "k": "c2VjcmV0"}, {"kty": "RSA", "kid": "enc", "use": "enc"}]}`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(keys).To(BeEmpty())
+	})
+})
@@ -0,0 +1,232 @@
+// Package keys resolves the public keys that auth tokens are verified with.
+package keys
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+var ErrUnknownKey = errors.New("unknown key")
+
+// Algorithms are the JWS algorithms that tokens may be signed with.
+var Algorithms = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512", "EdDSA"}
+
+// Set resolves the keys that may have signed a token from the token's key ID (kid) and
+// algorithm (alg).
+type Set interface {
+	Keys(ctx context.Context, kid string, alg string) ([]crypto.PublicKey, error)
+}
+
+// SupportsAlgorithm reports whether key can verify signatures made with the JWS algorithm alg.
+func SupportsAlgorithm(key crypto.PublicKey, alg string) bool {
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		return strings.HasPrefix(alg, "RS") || strings.HasPrefix(alg, "PS")
+	case *ecdsa.PublicKey:
+		switch alg {
+		case "ES256":
+			return k.Curve == elliptic.P256()
+		case "ES384":
+			return k.Curve == elliptic.P384()
+		case "ES512":
+			return k.Curve == elliptic.P521()
+		}
+	case ed25519.PublicKey:
+		return alg == "EdDSA"
+	}
+	return false
+}
+
+func keysSupportingAlgorithm(keys []crypto.PublicKey, alg string) []crypto.PublicKey {
+	var supported []crypto.PublicKey
+	for _, key := range keys {
+		if SupportsAlgorithm(key, alg) {
+			supported = append(supported, key)
+		}
+	}
+	return supported
+}
+
+type staticSet struct {
+	keys []crypto.PublicKey
+}
+
+// Static returns a Set of keys without key IDs. Tokens are checked against every key that
+// supports their algorithm, regardless of their key ID.
+func Static(keys ...crypto.PublicKey) Set {
+	return staticSet{keys}
+}
+
+func (set staticSet) Keys(ctx context.Context, kid string, alg string) ([]crypto.PublicKey, error) {
+	keys := keysSupportingAlgorithm(set.keys, alg)
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("%w: no key supports %s", ErrUnknownKey, alg)
+	}
+	return keys, nil
+}
+
+type multiSet []Set
+
+// Multi combines sets so that tokens signed with a key from any of them are accepted.
+func Multi(sets ...Set) Set {
+	return multiSet(sets)
+}
+
+func (sets multiSet) Keys(ctx context.Context, kid string, alg string) ([]crypto.PublicKey, error) {
+	var keys []crypto.PublicKey
+	for _, set := range sets {
+		setKeys, err := set.Keys(ctx, kid, alg)
+		if err != nil && !errors.Is(err, ErrUnknownKey) {
+			return nil, err
+		}
+		keys = append(keys, setKeys...)
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownKey, kid)
+	}
+	return keys, nil
+}
+
+func checkPublicKeyType(key any) (crypto.PublicKey, error) {
+	switch key.(type) {
+	case *rsa.PublicKey, *ecdsa.PublicKey, ed25519.PublicKey:
+		return key, nil
+	default:
+		return nil, fmt.Errorf("unsupported public key type %T", key)
+	}
+}
+
+// parsePEM parses every key in a sequence of PEM blocks. Certificates are only used as containers
+// for their public key; their validity period and issuer are not checked.
+func parsePEM(pemBytes []byte) (keys []crypto.PublicKey, err error) {
+	for {
+		var pemBlock *pem.Block
+		pemBlock, pemBytes = pem.Decode(pemBytes)
+		if pemBlock == nil {
+			break
+		}
+		var key any
+		switch pemBlock.Type {
+		case "RSA PUBLIC KEY":
+			key, err = x509.ParsePKCS1PublicKey(pemBlock.Bytes)
+		case "PUBLIC KEY":
+			key, err = x509.ParsePKIXPublicKey(pemBlock.Bytes)
+		case "CERTIFICATE":
+			var cert *x509.Certificate
+			cert, err = x509.ParseCertificate(pemBlock.Bytes)
+			if err == nil {
+				key = cert.PublicKey
+			}
+		default:
+			err = fmt.Errorf("invalid public key of type %s", pemBlock.Type)
+		}
+		if err != nil {
+			return
+		}
+		key, err = checkPublicKeyType(key)
+		if err != nil {
+			return
+		}
+		keys = append(keys, key)
+	}
+	if len(keys) == 0 {
+		err = fmt.Errorf("no PEM data found")
+	}
+	return
+}
+
+// LoadSigningKey reads a PEM-encoded PKCS #1 RSA, SEC 1 EC or PKCS #8 private key from the file
+// at path.
+func LoadSigningKey(path string) (crypto.Signer, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	pemBlock, _ := pem.Decode(contents)
+	if pemBlock == nil {
+		return nil, fmt.Errorf("no PEM data found")
+	}
+	var key any
+	switch pemBlock.Type {
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(pemBlock.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(pemBlock.Bytes)
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(pemBlock.Bytes)
+	default:
+		err = fmt.Errorf("invalid private key of type %s", pemBlock.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported private key type %T", key)
+	}
+	_, err = SigningAlgorithm(signer.Public())
+	if err != nil {
+		return nil, err
+	}
+	return signer, nil
+}
+
+// SigningAlgorithm returns the JWS algorithm that tokens signed with the private half of key use.
+func SigningAlgorithm(key crypto.PublicKey) (string, error) {
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		return "RS256", nil
+	case *ecdsa.PublicKey:
+		switch k.Curve {
+		case elliptic.P256():
+			return "ES256", nil
+		case elliptic.P384():
+			return "ES384", nil
+		case elliptic.P521():
+			return "ES512", nil
+		}
+		return "", fmt.Errorf("unsupported curve %s", k.Curve.Params().Name)
+	case ed25519.PublicKey:
+		return "EdDSA", nil
+	default:
+		return "", fmt.Errorf("unsupported public key type %T", key)
+	}
+}
+
+// Load reads the keys at source. http(s):// sources must serve a JWKS document. file:// sources
+// may contain either a JWKS document or PEM-encoded keys and certificates. JWKS documents are
+// refreshed every refreshInterval until ctx is done, so that keys can be rotated without a restart.
+func Load(ctx context.Context, source *url.URL, refreshInterval time.Duration) (Set, error) {
+	switch source.Scheme {
+	case "http", "https":
+		return NewJWKS(ctx, source, refreshInterval)
+	case "file":
+		contents, err := os.ReadFile(source.Path)
+		if err != nil {
+			return nil, err
+		}
+		if bytes.HasPrefix(bytes.TrimSpace(contents), []byte("{")) {
+			return NewJWKS(ctx, source, refreshInterval)
+		}
+		keys, err := parsePEM(contents)
+		if err != nil {
+			return nil, err
+		}
+		return Static(keys...), nil
+	default:
+		return nil, fmt.Errorf("unsupported public key source: %s", source.Scheme)
+	}
+}
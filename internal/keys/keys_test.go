@@ -0,0 +1,188 @@
+package keys
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func marshalPKIX(key any) []byte {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	Expect(err).NotTo(HaveOccurred())
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+}
+
+func marshalCertificate(key *ecdsa.PrivateKey) []byte {
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "open-transit"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	Expect(err).NotTo(HaveOccurred())
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+var _ = Describe("SupportsAlgorithm", func() {
+	It("matches keys to the algorithms they can verify", func() {
+		ecKey, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+		Expect(err).NotTo(HaveOccurred())
+		edKey, _, err := ed25519.GenerateKey(rand.Reader)
+		Expect(err).NotTo(HaveOccurred())
+		rsaKey := &generateKey().PublicKey
+
+		Expect(SupportsAlgorithm(rsaKey, "RS256")).To(BeTrue())
+		Expect(SupportsAlgorithm(rsaKey, "PS512")).To(BeTrue())
+		Expect(SupportsAlgorithm(rsaKey, "ES256")).To(BeFalse())
+		Expect(SupportsAlgorithm(&ecKey.PublicKey, "ES384")).To(BeTrue())
+		Expect(SupportsAlgorithm(&ecKey.PublicKey, "ES256")).To(BeFalse())
+		Expect(SupportsAlgorithm(edKey, "EdDSA")).To(BeTrue())
+		Expect(SupportsAlgorithm(edKey, "RS256")).To(BeFalse())
+	})
+})
+
+var _ = Describe("Multi", func() {
+	It("combines the keys of every set", func() {
+		ctx := context.Background()
+		first, second := generateKey(), generateKey()
+		keySet := Multi(Static(&first.PublicKey), Static(&second.PublicKey))
+		Expect(keySet.Keys(ctx, "", "RS256")).To(ConsistOf(&first.PublicKey, &second.PublicKey))
+	})
+
+	It("fails when no set has a matching key", func() {
+		ctx := context.Background()
+		keySet := Multi(Static(&generateKey().PublicKey), Static(&generateKey().PublicKey))
+		_, err := keySet.Keys(ctx, "", "EdDSA")
+		Expect(err).To(MatchError(ErrUnknownKey))
+	})
+})
+
+var _ = Describe("Load", func() {
+	var ctx context.Context
+	var key *rsa.PrivateKey
+	BeforeEach(func() {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithCancel(context.Background())
+		DeferCleanup(cancel)
+		key = generateKey()
+	})
+
+	writeFile := func(name string, contents []byte) *url.URL {
+		path := filepath.Join(GinkgoT().TempDir(), name)
+		Expect(os.WriteFile(path, contents, 0600)).To(Succeed())
+		return &url.URL{Scheme: "file", Path: path}
+	}
+
+	It("loads PEM-encoded keys from files", func() {
+		source := writeFile("key.pem", pem.EncodeToMemory(&pem.Block{Type: "RSA PUBLIC KEY", Bytes: x509.MarshalPKCS1PublicKey(&key.PublicKey)}))
+		keySet, err := Load(ctx, source, 0)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(keySet.Keys(ctx, "anything", "RS256")).To(ConsistOf(&key.PublicKey))
+	})
+
+	It("loads PKIX-encoded keys from files", func() {
+		ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		Expect(err).NotTo(HaveOccurred())
+		source := writeFile("key.pem", marshalPKIX(&ecKey.PublicKey))
+		keySet, err := Load(ctx, source, 0)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(keySet.Keys(ctx, "", "ES256")).To(ConsistOf(&ecKey.PublicKey))
+	})
+
+	It("loads the public key of certificates", func() {
+		ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		Expect(err).NotTo(HaveOccurred())
+		source := writeFile("cert.pem", marshalCertificate(ecKey))
+		keySet, err := Load(ctx, source, 0)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(keySet.Keys(ctx, "", "ES256")).To(ConsistOf(&ecKey.PublicKey))
+	})
+
+	It("loads every key in a PEM file", func() {
+		edKey, _, err := ed25519.GenerateKey(rand.Reader)
+		Expect(err).NotTo(HaveOccurred())
+		source := writeFile("keys.pem", append(marshalPKIX(&key.PublicKey), marshalPKIX(edKey)...))
+		keySet, err := Load(ctx, source, 0)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(keySet.Keys(ctx, "", "RS256")).To(ConsistOf(&key.PublicKey))
+		Expect(keySet.Keys(ctx, "", "EdDSA")).To(Equal([]crypto.PublicKey{edKey}))
+	})
+
+	It("rejects files without PEM data", func() {
+		_, err := Load(ctx, writeFile("key.pem", []byte("not a key")), 0)
+		Expect(err).To(MatchError("no PEM data found"))
+	})
+
+	It("rejects unsupported PEM blocks", func() {
+		source := writeFile("key.pem", pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}))
+		_, err := Load(ctx, source, 0)
+		Expect(err).To(MatchError("invalid public key of type RSA PRIVATE KEY"))
+	})
+
+	It("loads JWKS documents from files", func() {
+		source := writeFile("jwks.json", marshalJWKS(map[string]*rsa.PrivateKey{"first": key}))
+		keySet, err := Load(ctx, source, 0)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(keySet.Keys(ctx, "first", "RS256")).To(ConsistOf(&key.PublicKey))
+	})
+
+	It("loads JWKS documents from http servers", func() {
+		server := newJWKSServer(map[string]*rsa.PrivateKey{"first": key})
+		keySet, err := Load(ctx, server.sourceURL(), 0)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(keySet.Keys(ctx, "first", "RS256")).To(ConsistOf(&key.PublicKey))
+	})
+
+	It("rejects unsupported sources", func() {
+		_, err := Load(ctx, &url.URL{Scheme: "ftp", Host: "example.com", Path: "/key.pem"}, 0)
+		Expect(err).To(MatchError("unsupported public key source: ftp"))
+	})
+})
+
+var _ = Describe("LoadSigningKey", func() {
+	writeKey := func(block *pem.Block) string {
+		path := filepath.Join(GinkgoT().TempDir(), "key.pem")
+		Expect(os.WriteFile(path, pem.EncodeToMemory(block), 0600)).To(Succeed())
+		return path
+	}
+
+	It("loads PKCS #1 RSA keys", func() {
+		key := generateKey()
+		signer, err := LoadSigningKey(writeKey(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(signer.Public()).To(Equal(&key.PublicKey))
+		Expect(SigningAlgorithm(signer.Public())).To(Equal("RS256"))
+	})
+
+	It("loads PKCS #8 keys", func() {
+		key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+		Expect(err).NotTo(HaveOccurred())
+		der, err := x509.MarshalPKCS8PrivateKey(key)
+		Expect(err).NotTo(HaveOccurred())
+		signer, err := LoadSigningKey(writeKey(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(signer.Public()).To(Equal(&key.PublicKey))
+		Expect(SigningAlgorithm(signer.Public())).To(Equal("ES384"))
+	})
+
+	It("rejects public keys", func() {
+		_, err := LoadSigningKey(writeKey(&pem.Block{Type: "RSA PUBLIC KEY", Bytes: x509.MarshalPKCS1PublicKey(&generateKey().PublicKey)}))
+		Expect(err).To(MatchError("invalid private key of type RSA PUBLIC KEY"))
+	})
+})
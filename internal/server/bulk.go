@@ -0,0 +1,29 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/go-chi/render"
+	"github.com/technopolitica/open-transit/internal/domain"
+)
+
+func renderBulkResponse[T any](w http.ResponseWriter, r *http.Request, successStatus int, nServerErrors int, response domain.BulkApiResponse[T]) {
+	// An empty payload has nothing to report on, and would otherwise be classified below as every
+	// item having failed with a server error.
+	if response.Total == 0 {
+		renderBadParams(w, r, []string{"payload: must contain at least one item"})
+		return
+	}
+	httpStatus := successStatus
+	// If all of the inserts failed and all of the errors were classified as server errors,
+	// return a http.StatusInternalServerError Internal Server Error response to notify the client.
+	if nServerErrors == response.Total {
+		httpStatus = http.StatusInternalServerError
+	} else if response.Success == 0 { // Otherwise if no inserts were successful at least some of them were bad requests
+		httpStatus = http.StatusBadRequest
+	}
+	annotateRequestLog(r, "bulk_total", response.Total, "bulk_success", response.Success, "bulk_failures", len(response.Failures))
+	observeBulkResponse(r, response)
+	w.WriteHeader(httpStatus)
+	render.JSON(w, r, response)
+}
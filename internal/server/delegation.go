@@ -0,0 +1,88 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/technopolitica/open-transit/internal/db"
+	"github.com/technopolitica/open-transit/internal/domain"
+)
+
+// writeAccess decides which providers the caller may submit data for. Providers may only submit
+// their own data, while data providers may submit data for every active provider that has
+// delegated to them. Lookups are cached for the request since bulk payloads usually repeat the
+// same provider_id.
+type writeAccess struct {
+	auth       domain.AuthInfo
+	repository db.Repository
+	provider   domain.Provider
+	delegators map[uuid.UUID]delegation
+}
+
+type delegation struct {
+	provider domain.Provider
+	errs     []string
+}
+
+func newWriteAccess(r *http.Request) *writeAccess {
+	provider, _ := GetProvider(r)
+	return &writeAccess{
+		auth:       GetAuthInfo(r),
+		repository: GetRepository(r),
+		provider:   provider,
+		delegators: map[uuid.UUID]delegation{},
+	}
+}
+
+// authorize returns the registration of providerID if the caller may submit data for it, or the
+// reasons it may not. otherProviderErr is reported to providers submitting another provider's data.
+// Submissions from data providers are attributed to them through dataProviderID.
+func (access *writeAccess) authorize(ctx context.Context, providerID uuid.UUID, dataProviderID *uuid.UUID, otherProviderErr string) (provider domain.Provider, errs []string, err error) {
+	if access.auth.Role != domain.RoleDataProvider {
+		if providerID != access.auth.ProviderID {
+			errs = append(errs, otherProviderErr)
+		}
+		provider = access.provider
+		return
+	}
+
+	if *dataProviderID != uuid.Nil && *dataProviderID != access.auth.ProviderID {
+		errs = append(errs, "data_provider_id: does not match user's data provider ID")
+	}
+	*dataProviderID = access.auth.ProviderID
+
+	cached, ok := access.delegators[providerID]
+	if !ok {
+		cached, err = access.fetchDelegation(ctx, providerID)
+		if err != nil {
+			return
+		}
+		access.delegators[providerID] = cached
+	}
+	provider = cached.provider
+	errs = append(errs, cached.errs...)
+	return
+}
+
+func (access *writeAccess) fetchDelegation(ctx context.Context, providerID uuid.UUID) (result delegation, err error) {
+	provider, err := access.repository.FetchProvider(ctx, providerID)
+	// Providers that haven't delegated to the caller are indistinguishable from unregistered ones
+	// so that data providers can't discover which providers are registered.
+	if (err != nil && errors.Is(err, db.ErrNotFound)) || (err == nil && !provider.DelegatesTo(access.auth.ProviderID)) {
+		err = nil
+		result.errs = []string{"provider_id: not authorized to submit data on behalf of this provider"}
+		return
+	}
+	if err != nil {
+		err = fmt.Errorf("failed to fetch provider: %w", err)
+		return
+	}
+	if provider.Status != domain.ProviderStatusActive {
+		result.errs = []string{"provider_id: provider is suspended"}
+	}
+	result.provider = provider
+	return
+}
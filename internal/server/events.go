@@ -0,0 +1,180 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/technopolitica/open-transit/internal/db"
+	"github.com/technopolitica/open-transit/internal/domain"
+)
+
+// validateEventSequence checks that an event is a valid transition from the event reported for
+// the vehicle just before it and, for events submitted out of order, that the event reported just
+// after it is still a valid transition from it.
+func validateEventSequence(ctx context.Context, repository db.Repository, event domain.Event) (errs []string, err error) {
+	params := domain.FetchAdjacentEventParams{
+		VehicleID:  event.DeviceID,
+		ProviderID: event.ProviderID,
+		Timestamp:  event.Timestamp.Time,
+		EventID:    event.EventID,
+	}
+	previousState := domain.InitialVehicleState
+	previous, err := repository.FetchPreviousEvent(ctx, params)
+	if err != nil && !errors.Is(err, db.ErrNotFound) {
+		err = fmt.Errorf("failed to fetch previous event: %w", err)
+		return
+	}
+	if err == nil {
+		previousState = previous.VehicleState
+	}
+	errs = domain.ValidateVehicleStateTransition(previousState, event)
+
+	next, err := repository.FetchNextEvent(ctx, params)
+	if err != nil && errors.Is(err, db.ErrNotFound) {
+		err = nil
+		return
+	}
+	if err != nil {
+		err = fmt.Errorf("failed to fetch next event: %w", err)
+		return
+	}
+	errs = append(errs, domain.ValidateNextVehicleStateTransition(event, next)...)
+	return
+}
+
+func NewEventsRouter() *chi.Mux {
+	eventsRouter := chi.NewRouter()
+	eventsRouter.With(requireScope(domain.ScopeEventsWrite), requireActiveProvider).Post("/", func(w http.ResponseWriter, r *http.Request) {
+		var events []domain.Event
+		err := render.DecodeJSON(r.Body, &events)
+		if err != nil {
+			GetLogger(r).Info("malformed Event payload", "error", err)
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, domain.ApiError{
+				Type:    domain.ApiErrorTypeBadParam,
+				Details: []string{"events payload is not valid JSON"},
+			})
+			return
+		}
+		defer r.Body.Close()
+
+		ctx := r.Context()
+		repository := GetRepository(r)
+		nServerErrors := 0
+		response := domain.BulkApiResponse[domain.Event]{
+			Total: len(events),
+		}
+		addServerError := func(event domain.Event, err error) {
+			GetLogger(r).Error("failed to process event", "device_id", event.DeviceID, "error", err)
+			response.Failures = append(response.Failures, domain.FailureDetails[domain.Event]{
+				Item: event,
+				ApiError: domain.ApiError{
+					Type:    domain.ApiErrorTypeUnknown,
+					Details: []string{"An unknown error has occurred"},
+				},
+			})
+			nServerErrors += 1
+		}
+		access := newWriteAccess(r)
+		for _, event := range events {
+			errs := domain.ValidateEvent(event)
+			_, accessErrs, err := access.authorize(ctx, event.ProviderID, &event.DataProviderID, "provider_id: not allowed to submit events for another provider")
+			if err != nil {
+				addServerError(event, err)
+				continue
+			}
+			errs = append(errs, accessErrs...)
+			if len(errs) > 0 {
+				response.Failures = append(response.Failures, domain.FailureDetails[domain.Event]{
+					Item: event,
+					ApiError: domain.ApiError{
+						Type:    domain.ApiErrorTypeBadParam,
+						Details: errs,
+					},
+				})
+				continue
+			}
+
+			_, err = repository.FetchVehicle(ctx, domain.FetchVehicleParams{
+				VehicleID:  event.DeviceID,
+				ProviderID: &event.ProviderID,
+			})
+			if err != nil && errors.Is(err, db.ErrNotFound) {
+				response.Failures = append(response.Failures, domain.FailureDetails[domain.Event]{
+					Item: event,
+					ApiError: domain.ApiError{
+						Type:    domain.ApiErrorTypeUnregistered,
+						Details: []string{},
+					},
+				})
+				continue
+			}
+			if err != nil {
+				addServerError(event, fmt.Errorf("failed to fetch vehicle: %w", err))
+				continue
+			}
+
+			// Events that are sent again are reported as already registered rather than validated
+			// as a transition from themselves.
+			_, err = repository.FetchEvent(ctx, event.EventID)
+			if err == nil {
+				response.Failures = append(response.Failures, domain.FailureDetails[domain.Event]{
+					Item: event,
+					ApiError: domain.ApiError{
+						Type:    domain.ApiErrorTypeAlreadyRegistered,
+						Details: []string{"An event with event_id is already registered"},
+					},
+				})
+				continue
+			}
+			if !errors.Is(err, db.ErrNotFound) {
+				addServerError(event, fmt.Errorf("failed to fetch event: %w", err))
+				continue
+			}
+
+			errs, err = validateEventSequence(ctx, repository, event)
+			if err != nil {
+				addServerError(event, err)
+				continue
+			}
+			if len(errs) > 0 {
+				response.Failures = append(response.Failures, domain.FailureDetails[domain.Event]{
+					Item: event,
+					ApiError: domain.ApiError{
+						Type:    domain.ApiErrorTypeBadParam,
+						Details: errs,
+					},
+				})
+				continue
+			}
+
+			err = repository.InsertEvent(ctx, event)
+
+			if err != nil && errors.Is(err, db.ErrConflict) {
+				response.Failures = append(response.Failures, domain.FailureDetails[domain.Event]{
+					Item: event,
+					ApiError: domain.ApiError{
+						Type:    domain.ApiErrorTypeAlreadyRegistered,
+						Details: []string{"An event with event_id is already registered"},
+					},
+				})
+				continue
+			}
+
+			if err != nil {
+				addServerError(event, fmt.Errorf("failed to insert event: %w", err))
+				continue
+			}
+
+			response.Success += 1
+			observeIngestionLag(r, "event", event.ProviderID, event.Timestamp)
+		}
+
+		renderBulkResponse(w, r, http.StatusCreated, nServerErrors, response)
+	})
+	return eventsRouter
+}
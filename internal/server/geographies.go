@@ -0,0 +1,126 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+	"github.com/technopolitica/open-transit/internal/db"
+	"github.com/technopolitica/open-transit/internal/domain"
+)
+
+func NewGeographiesRouter() *chi.Mux {
+	geographiesRouter := chi.NewRouter()
+	geographiesRouter.With(requireScope(domain.ScopeGeographiesRead)).Get("/", func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		repository := GetRepository(r)
+		geographies, err := repository.ListGeographies(ctx)
+		if err != nil {
+			GetLogger(r).Error("failed to list geographies", "error", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		render.JSON(w, r, domain.GeographiesResponse{
+			Version:     "2.0.0",
+			Geographies: geographies,
+		})
+	})
+	geographiesRouter.With(requireScope(domain.ScopeGeographiesRead)).Get("/{geographyID}", func(w http.ResponseWriter, r *http.Request) {
+		geographyID, err := uuid.Parse(chi.URLParam(r, "geographyID"))
+		if err != nil {
+			renderBadParams(w, r, []string{"geography_id: must be a valid UUID"})
+			return
+		}
+
+		ctx := r.Context()
+		repository := GetRepository(r)
+		geography, err := repository.FetchGeography(ctx, geographyID)
+
+		if err != nil && errors.Is(err, db.ErrNotFound) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if err != nil {
+			GetLogger(r).Error("failed to fetch geography", "error", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		render.JSON(w, r, domain.GeographyResponse{
+			Version:   "2.0.0",
+			Geography: geography,
+		})
+	})
+	// Geographies are published as soon as they're created and are immutable from then on.
+	geographiesRouter.With(requireScope(domain.ScopeGeographiesWrite)).Post("/", func(w http.ResponseWriter, r *http.Request) {
+		var geography domain.Geography
+		err := render.DecodeJSON(r.Body, &geography)
+		if err != nil {
+			GetLogger(r).Info("malformed Geography payload", "error", err)
+			renderBadParams(w, r, []string{"geography payload is not valid JSON"})
+			return
+		}
+		defer r.Body.Close()
+
+		errs := domain.ValidateGeography(geography)
+		if !geography.PublishedDate.IsZero() {
+			errs = append(errs, "published_date: set when the geography is published")
+		}
+		if len(errs) > 0 {
+			renderBadParams(w, r, errs)
+			return
+		}
+
+		ctx := r.Context()
+		repository := GetRepository(r)
+		for _, prevGeographyID := range geography.PrevGeographies {
+			_, err := repository.FetchGeography(ctx, prevGeographyID)
+			if err != nil && errors.Is(err, db.ErrNotFound) {
+				errs = append(errs, fmt.Sprintf("prev_geographies: %s is not a published geography", prevGeographyID))
+				continue
+			}
+			if err != nil {
+				GetLogger(r).Error("failed to fetch previous geography", "error", err)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+		}
+		if len(errs) > 0 {
+			renderBadParams(w, r, errs)
+			return
+		}
+
+		geography.PublishedDate = domain.NewTimestamp(time.Now())
+		err = repository.InsertGeography(ctx, geography)
+
+		if err != nil && errors.Is(err, db.ErrConflict) {
+			w.WriteHeader(http.StatusConflict)
+			render.JSON(w, r, domain.ApiError{
+				Type:    domain.ApiErrorTypeAlreadyRegistered,
+				Details: []string{"A geography with geography_id is already registered"},
+			})
+			return
+		}
+
+		if err != nil {
+			GetLogger(r).Error("failed to insert geography", "error", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		render.JSON(w, r, domain.GeographyResponse{
+			Version:   "2.0.0",
+			Geography: geography,
+		})
+	})
+	return geographiesRouter
+}
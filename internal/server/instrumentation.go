@@ -0,0 +1,175 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/technopolitica/open-transit/internal/domain"
+)
+
+const metricsNamespace = "open_transit"
+
+// Instruments are the Prometheus metrics the server records about the requests it handles and the
+// data it ingests. A nil *Instruments records nothing.
+type Instruments struct {
+	requests        *prometheus.CounterVec
+	requestDuration *prometheus.HistogramVec
+	bulkItems       *prometheus.CounterVec
+	ingestionLag    *prometheus.HistogramVec
+}
+
+// NewInstruments creates the server's metrics and registers them with registerer.
+func NewInstruments(registerer prometheus.Registerer) *Instruments {
+	instruments := &Instruments{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "http_requests_total",
+			Help:      "Number of requests handled, by route pattern, method and status.",
+		}, []string{"route", "method", "status"}),
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "Time taken to handle requests, by route pattern and method.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"route", "method"}),
+		bulkItems: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "bulk_items_total",
+			Help:      "Number of items submitted to bulk endpoints, by route pattern, submitting provider and result: success or the ApiErrorType of the failure.",
+		}, []string{"route", "provider_id", "result"}),
+		ingestionLag: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "ingestion_lag_seconds",
+			Help:      "Time between the timestamp of stored events and telemetry and when they were received, by type and provider.",
+			Buckets:   []float64{1, 5, 15, 30, 60, 120, 300, 900, 1800, 3600, 6 * 3600, 24 * 3600},
+		}, []string{"type", "provider_id"}),
+	}
+	registerer.MustRegister(
+		instruments.requests,
+		instruments.requestDuration,
+		instruments.bulkItems,
+		instruments.ingestionLag,
+	)
+	return instruments
+}
+
+func getInstruments(r *http.Request) *Instruments {
+	instruments, _ := r.Context().Value(ContextKeyInstruments).(*Instruments)
+	return instruments
+}
+
+// instrumentRequests counts requests and measures how long they take. Requests are labelled with
+// their route pattern rather than their path so that IDs in paths don't create new series.
+func instrumentRequests(instruments *Instruments) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			r = r.WithContext(context.WithValue(r.Context(), ContextKeyInstruments, instruments))
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			start := time.Now()
+			next.ServeHTTP(ww, r)
+			duration := time.Since(start)
+
+			status := ww.Status()
+			if status == 0 {
+				status = http.StatusOK
+			}
+			route := chi.RouteContext(r.Context()).RoutePattern()
+			instruments.requests.WithLabelValues(route, r.Method, strconv.Itoa(status)).Inc()
+			instruments.requestDuration.WithLabelValues(route, r.Method).Observe(duration.Seconds())
+		})
+	}
+}
+
+// observeBulkResponse counts the items of a bulk request by whether they were stored or the type of
+// error that they were rejected with.
+func observeBulkResponse[T any](r *http.Request, response domain.BulkApiResponse[T]) {
+	instruments := getInstruments(r)
+	if instruments == nil {
+		return
+	}
+	route := chi.RouteContext(r.Context()).RoutePattern()
+	providerID := ""
+	if auth, ok := r.Context().Value(ContextKeyAuth).(domain.AuthInfo); ok && auth.ProviderID != uuid.Nil {
+		providerID = auth.ProviderID.String()
+	}
+	instruments.bulkItems.WithLabelValues(route, providerID, "success").Add(float64(response.Success))
+	for _, failure := range response.Failures {
+		instruments.bulkItems.WithLabelValues(route, providerID, failure.Type.String()).Inc()
+	}
+}
+
+// observeIngestionLag records how long after timestamp an item of kind ingestType was received
+// from providerID.
+func observeIngestionLag(r *http.Request, ingestType string, providerID uuid.UUID, timestamp domain.Timestamp) {
+	instruments := getInstruments(r)
+	if instruments == nil {
+		return
+	}
+	lag := time.Since(timestamp.Time)
+	instruments.ingestionLag.WithLabelValues(ingestType, providerID.String()).Observe(lag.Seconds())
+}
+
+// poolCollector exports the statistics of a database connection pool.
+type poolCollector struct {
+	pool *pgxpool.Pool
+
+	acquiredConns     *prometheus.Desc
+	idleConns         *prometheus.Desc
+	totalConns        *prometheus.Desc
+	maxConns          *prometheus.Desc
+	acquireCount      *prometheus.Desc
+	acquireDuration   *prometheus.Desc
+	emptyAcquireCount *prometheus.Desc
+	canceledAcquires  *prometheus.Desc
+}
+
+// NewPoolCollector returns a collector of the statistics of pool.
+func NewPoolCollector(pool *pgxpool.Pool) prometheus.Collector {
+	desc := func(name string, help string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName(metricsNamespace, "db_pool", name), help, nil, nil)
+	}
+	return &poolCollector{
+		pool:              pool,
+		acquiredConns:     desc("acquired_conns", "Number of connections currently in use."),
+		idleConns:         desc("idle_conns", "Number of idle connections."),
+		totalConns:        desc("total_conns", "Number of open connections."),
+		maxConns:          desc("max_conns", "Maximum number of open connections."),
+		acquireCount:      desc("acquires_total", "Number of connections acquired from the pool."),
+		acquireDuration:   desc("acquire_duration_seconds_total", "Total time spent waiting to acquire connections."),
+		emptyAcquireCount: desc("empty_acquires_total", "Number of acquires that had to wait for a connection because the pool was empty."),
+		canceledAcquires:  desc("canceled_acquires_total", "Number of acquires that were canceled before a connection became available."),
+	}
+}
+
+func (collector *poolCollector) Describe(descs chan<- *prometheus.Desc) {
+	prometheus.DescribeByCollect(collector, descs)
+}
+
+func (collector *poolCollector) Collect(metrics chan<- prometheus.Metric) {
+	stats := collector.pool.Stat()
+	metrics <- prometheus.MustNewConstMetric(collector.acquiredConns, prometheus.GaugeValue, float64(stats.AcquiredConns()))
+	metrics <- prometheus.MustNewConstMetric(collector.idleConns, prometheus.GaugeValue, float64(stats.IdleConns()))
+	metrics <- prometheus.MustNewConstMetric(collector.totalConns, prometheus.GaugeValue, float64(stats.TotalConns()))
+	metrics <- prometheus.MustNewConstMetric(collector.maxConns, prometheus.GaugeValue, float64(stats.MaxConns()))
+	metrics <- prometheus.MustNewConstMetric(collector.acquireCount, prometheus.CounterValue, float64(stats.AcquireCount()))
+	metrics <- prometheus.MustNewConstMetric(collector.acquireDuration, prometheus.CounterValue, stats.AcquireDuration().Seconds())
+	metrics <- prometheus.MustNewConstMetric(collector.emptyAcquireCount, prometheus.CounterValue, float64(stats.EmptyAcquireCount()))
+	metrics <- prometheus.MustNewConstMetric(collector.canceledAcquires, prometheus.CounterValue, float64(stats.CanceledAcquireCount()))
+}
+
+// NewAdminRouter builds the router of the operational endpoints, which are served on their own port
+// so that they aren't exposed alongside the MDS APIs. /metrics exports the metrics in gatherer.
+func NewAdminRouter(gatherer prometheus.Gatherer) *chi.Mux {
+	router := chi.NewRouter()
+	router.Use(middleware.Heartbeat("/health"))
+	router.Handle("/metrics", promhttp.HandlerFor(gatherer, promhttp.HandlerOpts{}))
+	return router
+}
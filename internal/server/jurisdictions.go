@@ -0,0 +1,179 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+	"github.com/technopolitica/open-transit/internal/db"
+	"github.com/technopolitica/open-transit/internal/domain"
+)
+
+// parseEffectiveParam parses the optional effective query parameter, which selects the point in
+// time at which jurisdictions are read. It defaults to now.
+func parseEffectiveParam(r *http.Request, now time.Time) (effective time.Time, errs []string) {
+	value, errs := parseOptionalMillisParam(r, "effective")
+	effective = now
+	if value != nil {
+		effective = *value
+	}
+	return
+}
+
+func decodeJurisdiction(w http.ResponseWriter, r *http.Request) (jurisdiction domain.Jurisdiction, ok bool) {
+	err := render.DecodeJSON(r.Body, &jurisdiction)
+	if err != nil {
+		GetLogger(r).Info("malformed Jurisdiction payload", "error", err)
+		renderBadParams(w, r, []string{"jurisdiction payload is not valid JSON"})
+		return
+	}
+	defer r.Body.Close()
+
+	errs := domain.ValidateJurisdiction(jurisdiction)
+	if !jurisdiction.Timestamp.IsZero() {
+		errs = append(errs, "timestamp: set when the jurisdiction is recorded")
+	}
+	if len(errs) > 0 {
+		renderBadParams(w, r, errs)
+		return
+	}
+	jurisdiction.Timestamp = domain.NewTimestamp(time.Now())
+	ok = true
+	return
+}
+
+func renderJurisdictionWriteError(w http.ResponseWriter, r *http.Request, jurisdiction domain.Jurisdiction, err error) {
+	switch {
+	case errors.Is(err, db.ErrNotFound):
+		w.WriteHeader(http.StatusNotFound)
+	case errors.Is(err, db.ErrInvalidReference):
+		renderBadParams(w, r, []string{fmt.Sprintf("geography_id: no geography with geography_id %s is registered", jurisdiction.GeographyID)})
+	case errors.Is(err, db.ErrConflict):
+		w.WriteHeader(http.StatusConflict)
+		render.JSON(w, r, domain.ApiError{
+			Type:    domain.ApiErrorTypeAlreadyRegistered,
+			Details: []string{"A jurisdiction with jurisdiction_id is already registered"},
+		})
+	default:
+		GetLogger(r).Error("failed to record jurisdiction", "error", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+func NewJurisdictionsRouter() *chi.Mux {
+	jurisdictionsRouter := chi.NewRouter()
+	jurisdictionsRouter.With(requireScope(domain.ScopeJurisdictionsRead)).Get("/", func(w http.ResponseWriter, r *http.Request) {
+		effective, errs := parseEffectiveParam(r, time.Now())
+		if len(errs) > 0 {
+			renderBadParams(w, r, errs)
+			return
+		}
+
+		ctx := r.Context()
+		repository := GetRepository(r)
+		jurisdictions, err := repository.ListJurisdictions(ctx, domain.ListJurisdictionsParams{
+			Effective: effective,
+		})
+		if err != nil {
+			GetLogger(r).Error("failed to list jurisdictions", "error", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		render.JSON(w, r, domain.JurisdictionsResponse{
+			Version:       "2.0.0",
+			Jurisdictions: jurisdictions,
+		})
+	})
+	jurisdictionsRouter.With(requireScope(domain.ScopeJurisdictionsRead)).Get("/{jurisdictionID}", func(w http.ResponseWriter, r *http.Request) {
+		jurisdictionID, err := uuid.Parse(chi.URLParam(r, "jurisdictionID"))
+		if err != nil {
+			renderBadParams(w, r, []string{"jurisdiction_id: must be a valid UUID"})
+			return
+		}
+		effective, errs := parseEffectiveParam(r, time.Now())
+		if len(errs) > 0 {
+			renderBadParams(w, r, errs)
+			return
+		}
+
+		ctx := r.Context()
+		repository := GetRepository(r)
+		jurisdiction, err := repository.FetchJurisdiction(ctx, domain.FetchJurisdictionParams{
+			JurisdictionID: jurisdictionID,
+			Effective:      effective,
+		})
+
+		if err != nil && errors.Is(err, db.ErrNotFound) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if err != nil {
+			GetLogger(r).Error("failed to fetch jurisdiction", "error", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		render.JSON(w, r, domain.JurisdictionResponse{
+			Version:      "2.0.0",
+			Jurisdiction: jurisdiction,
+		})
+	})
+	jurisdictionsRouter.With(requireScope(domain.ScopeJurisdictionsWrite)).Post("/", func(w http.ResponseWriter, r *http.Request) {
+		jurisdiction, ok := decodeJurisdiction(w, r)
+		if !ok {
+			return
+		}
+
+		ctx := r.Context()
+		repository := GetRepository(r)
+		err := repository.InsertJurisdiction(ctx, jurisdiction)
+		if err != nil {
+			renderJurisdictionWriteError(w, r, jurisdiction, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		render.JSON(w, r, domain.JurisdictionResponse{
+			Version:      "2.0.0",
+			Jurisdiction: jurisdiction,
+		})
+	})
+	jurisdictionsRouter.With(requireScope(domain.ScopeJurisdictionsWrite)).Put("/{jurisdictionID}", func(w http.ResponseWriter, r *http.Request) {
+		jurisdictionID, err := uuid.Parse(chi.URLParam(r, "jurisdictionID"))
+		if err != nil {
+			renderBadParams(w, r, []string{"jurisdiction_id: must be a valid UUID"})
+			return
+		}
+		jurisdiction, ok := decodeJurisdiction(w, r)
+		if !ok {
+			return
+		}
+		if jurisdiction.JurisdictionID != jurisdictionID {
+			renderBadParams(w, r, []string{"jurisdiction_id: must match the jurisdiction being updated"})
+			return
+		}
+
+		ctx := r.Context()
+		repository := GetRepository(r)
+		err = repository.InsertJurisdictionVersion(ctx, jurisdiction)
+		if err != nil {
+			renderJurisdictionWriteError(w, r, jurisdiction, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		render.JSON(w, r, domain.JurisdictionResponse{
+			Version:      "2.0.0",
+			Jurisdiction: jurisdiction,
+		})
+	})
+	return jurisdictionsRouter
+}
@@ -0,0 +1,91 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/google/uuid"
+	"github.com/technopolitica/open-transit/internal/domain"
+)
+
+// requestLog holds the logger of a request. Attributes added while the request is handled, such as
+// the caller's provider_id, are carried by every message logged afterwards, including the request's
+// own log line.
+type requestLog struct {
+	logger *slog.Logger
+}
+
+// GetLogger returns the logger of the request, which tags messages with its request_id and, once
+// the caller is authenticated, their provider_id.
+func GetLogger(r *http.Request) *slog.Logger {
+	requestLog, ok := r.Context().Value(ContextKeyLog).(*requestLog)
+	if !ok {
+		return slog.Default()
+	}
+	return requestLog.logger
+}
+
+// annotateRequestLog adds attributes, as alternating keys and values, to the request's logger.
+func annotateRequestLog(r *http.Request, args ...any) {
+	requestLog, ok := r.Context().Value(ContextKeyLog).(*requestLog)
+	if !ok {
+		return
+	}
+	requestLog.logger = requestLog.logger.With(args...)
+}
+
+// annotateAuthInfo tags the request's log with the caller's role and, if they have one, their
+// provider ID.
+func annotateAuthInfo(r *http.Request, auth domain.AuthInfo) {
+	if auth.ProviderID == uuid.Nil {
+		annotateRequestLog(r, "role", auth.Role)
+		return
+	}
+	annotateRequestLog(r, "role", auth.Role, "provider_id", auth.ProviderID)
+}
+
+// logRequests gives every request a logger tagged with its request ID, which is also returned in
+// the X-Request-Id header. If logLines is set, a line is logged for every request once it has been
+// handled, at the error level for server errors.
+func logRequests(logger *slog.Logger, logLines bool) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requestID := middleware.GetReqID(r.Context())
+			w.Header().Set(middleware.RequestIDHeader, requestID)
+			requestLog := &requestLog{logger: logger.With("request_id", requestID)}
+			r = r.WithContext(context.WithValue(r.Context(), ContextKeyLog, requestLog))
+			if !logLines {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			start := time.Now()
+			next.ServeHTTP(ww, r)
+			latency := time.Since(start)
+
+			status := ww.Status()
+			if status == 0 {
+				status = http.StatusOK
+			}
+			level := slog.LevelInfo
+			if status >= http.StatusInternalServerError {
+				level = slog.LevelError
+			}
+			// The route is only known once the request has been routed.
+			route := chi.RouteContext(r.Context()).RoutePattern()
+			requestLog.logger.LogAttrs(r.Context(), level, "request",
+				slog.String("method", r.Method),
+				slog.String("path", r.URL.Path),
+				slog.String("route", route),
+				slog.Int("status", status),
+				slog.Int("bytes", ww.BytesWritten()),
+				slog.Float64("latency_ms", float64(latency.Microseconds())/1000),
+			)
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/technopolitica/open-transit/internal/domain"
+)
+
+func NewMetricsRouter() *chi.Mux {
+	metricsRouter := chi.NewRouter()
+	metricsRouter.With(requireScope(domain.ScopeMetricsRead)).Get("/", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		render.JSON(w, r, domain.NewMetricsDiscoveryResponse())
+	})
+	metricsRouter.With(requireScope(domain.ScopeMetricsRead)).Post("/query", func(w http.ResponseWriter, r *http.Request) {
+		var query domain.MetricsQuery
+		err := render.DecodeJSON(r.Body, &query)
+		if err != nil {
+			GetLogger(r).Info("malformed MetricsQuery payload", "error", err)
+			renderBadParams(w, r, []string{"metrics query is not valid JSON"})
+			return
+		}
+		defer r.Body.Close()
+
+		errs := domain.ValidateMetricsQuery(query)
+		if len(errs) > 0 {
+			renderBadParams(w, r, errs)
+			return
+		}
+
+		// Providers may only see metrics computed from their own data.
+		auth := GetAuthInfo(r)
+		if auth.IsProvider() {
+			query = query.RestrictedToProvider(auth.ProviderID)
+		}
+
+		ctx := r.Context()
+		repository := GetRepository(r)
+		params := domain.MetricsFactsParams{
+			Range:       domain.TimeRange{Start: query.StartDate.Time, End: query.EndDate.Time},
+			ProviderIDs: query.ProviderIDs(),
+		}
+		var trips []domain.TripFact
+		if query.NeedsTrips() {
+			trips, err = repository.ListTripFacts(ctx, params)
+			if err != nil {
+				GetLogger(r).Error("failed to list trips for metrics", "error", err)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+		}
+		var vehicleEvents []domain.VehicleEventFact
+		if query.NeedsVehicleEvents() {
+			vehicleEvents, err = repository.ListVehicleEventFacts(ctx, params)
+			if err != nil {
+				GetLogger(r).Error("failed to list events for metrics", "error", err)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+		}
+
+		w.WriteHeader(http.StatusOK)
+		render.JSON(w, r, domain.ComputeMetrics(query, trips, vehicleEvents))
+	})
+	return metricsRouter
+}
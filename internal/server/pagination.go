@@ -0,0 +1,59 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/technopolitica/open-transit/internal/domain"
+)
+
+func paginationLinks(r *http.Request, params ListVehiclesParams, total int64) domain.PaginationLinks {
+	baseURL := domain.URL{URL: r.URL}
+	first := baseURL.ModifyQuery(func(query *url.Values) {
+		query.Set("page[offset]", "0")
+	})
+	lastOffset := (int(total) / params.Limit) * params.Limit
+	last := baseURL.ModifyQuery(func(query *url.Values) {
+		query.Set("page[offset]", fmt.Sprint(lastOffset))
+	})
+	prevOffset := params.Offset - params.Limit
+	// If we get a nonsensical offset that's greater than the last offset, we'll point
+	// the prev link to the last offset.
+	if prevOffset > lastOffset {
+		prevOffset = lastOffset
+	}
+	hasPrev := prevOffset >= 0
+	var prev domain.URL
+	if hasPrev {
+		prev = baseURL.ModifyQuery(func(query *url.Values) {
+			query.Set("page[offset]", fmt.Sprint(prevOffset))
+		})
+	}
+	nextOffset := params.Offset + params.Limit
+	hasNext := nextOffset <= lastOffset
+	var next domain.URL
+	if hasNext {
+		next = baseURL.ModifyQuery(func(query *url.Values) {
+			query.Set("page[offset]", fmt.Sprint(nextOffset))
+		})
+	}
+	return domain.PaginationLinks{
+		First: first.String(),
+		Last:  last.String(),
+		Prev:  prev.String(),
+		Next:  next.String(),
+	}
+}
+
+// FIXME: we can't use render.JSON for paginated responses because the default json.Marshal
+// implementation escapes HTML characters by default (including the ampersand '&'), which breaks
+// the rendering of URLs...
+func renderPaginatedJSON(w http.ResponseWriter, status int, value any) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	return encoder.Encode(value)
+}
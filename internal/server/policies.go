@@ -0,0 +1,306 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+	"github.com/technopolitica/open-transit/internal/db"
+	"github.com/technopolitica/open-transit/internal/domain"
+)
+
+func parseListPoliciesParams(r *http.Request, now time.Time) (params domain.ListPoliciesParams, errs []string) {
+	start, startErrs := parseOptionalMillisParam(r, "start_date")
+	end, endErrs := parseOptionalMillisParam(r, "end_date")
+	errs = append(startErrs, endErrs...)
+	if len(errs) > 0 {
+		return
+	}
+	params.Start = now
+	if start != nil {
+		params.Start = *start
+	}
+	if end != nil && !end.After(params.Start) {
+		errs = append(errs, "end_date: must be after start_date")
+	}
+	params.End = end
+	return
+}
+
+func parsePolicyID(w http.ResponseWriter, r *http.Request) (policyID uuid.UUID, ok bool) {
+	policyID, err := uuid.Parse(chi.URLParam(r, "policyID"))
+	if err != nil {
+		renderBadParams(w, r, []string{"policy_id: must be a valid UUID"})
+		return
+	}
+	ok = true
+	return
+}
+
+func decodePolicy(w http.ResponseWriter, r *http.Request) (policy domain.Policy, ok bool) {
+	err := render.DecodeJSON(r.Body, &policy)
+	if err != nil {
+		GetLogger(r).Info("malformed Policy payload", "error", err)
+		renderBadParams(w, r, []string{"policy payload is not valid JSON"})
+		return
+	}
+	defer r.Body.Close()
+
+	errs := domain.ValidatePolicy(policy)
+	if policy.PublishedDate != nil {
+		errs = append(errs, "published_date: set when the policy is published")
+	}
+	if len(errs) > 0 {
+		renderBadParams(w, r, errs)
+		return
+	}
+	ok = true
+	return
+}
+
+func renderImmutablePolicy(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusConflict)
+	render.JSON(w, r, domain.ApiError{
+		Type:    domain.ApiErrorTypeBadParam,
+		Details: []string{"published_date: published policies cannot be modified"},
+	})
+}
+
+func NewPoliciesRouter() *chi.Mux {
+	policiesRouter := chi.NewRouter()
+	policiesRouter.With(requireScope(domain.ScopePoliciesRead)).Get("/", func(w http.ResponseWriter, r *http.Request) {
+		params, errs := parseListPoliciesParams(r, time.Now())
+		if len(errs) > 0 {
+			renderBadParams(w, r, errs)
+			return
+		}
+
+		ctx := r.Context()
+		repository := GetRepository(r)
+		policies, err := repository.ListPolicies(ctx, params)
+		if err != nil {
+			GetLogger(r).Error("failed to list policies", "error", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		render.JSON(w, r, domain.NewPoliciesResponse(policies))
+	})
+	policiesRouter.With(requireScope(domain.ScopePoliciesRead)).Get("/{policyID}", func(w http.ResponseWriter, r *http.Request) {
+		policyID, ok := parsePolicyID(w, r)
+		if !ok {
+			return
+		}
+
+		ctx := r.Context()
+		repository := GetRepository(r)
+		policy, err := repository.FetchPolicy(ctx, policyID)
+
+		// Drafts are only visible to the agency authoring them.
+		if (err != nil && errors.Is(err, db.ErrNotFound)) || (err == nil && !policy.IsPublished() && !GetAuthInfo(r).HasScope(domain.ScopePoliciesWrite)) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if err != nil {
+			GetLogger(r).Error("failed to fetch policy", "error", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		render.JSON(w, r, domain.NewPoliciesResponse([]domain.Policy{policy}))
+	})
+	policiesRouter.With(requireScope(domain.ScopePoliciesWrite)).Post("/", func(w http.ResponseWriter, r *http.Request) {
+		policy, ok := decodePolicy(w, r)
+		if !ok {
+			return
+		}
+
+		ctx := r.Context()
+		repository := GetRepository(r)
+		err := repository.InsertPolicy(ctx, policy)
+
+		if err != nil && errors.Is(err, db.ErrConflict) {
+			w.WriteHeader(http.StatusConflict)
+			render.JSON(w, r, domain.ApiError{
+				Type:    domain.ApiErrorTypeAlreadyRegistered,
+				Details: []string{"A policy with policy_id is already registered"},
+			})
+			return
+		}
+
+		if err != nil {
+			GetLogger(r).Error("failed to insert policy", "error", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		render.JSON(w, r, domain.NewPoliciesResponse([]domain.Policy{policy}))
+	})
+	policiesRouter.With(requireScope(domain.ScopePoliciesWrite)).Put("/{policyID}", func(w http.ResponseWriter, r *http.Request) {
+		policyID, ok := parsePolicyID(w, r)
+		if !ok {
+			return
+		}
+		policy, ok := decodePolicy(w, r)
+		if !ok {
+			return
+		}
+		if policy.PolicyID != policyID {
+			renderBadParams(w, r, []string{"policy_id: must match the policy being updated"})
+			return
+		}
+
+		ctx := r.Context()
+		repository := GetRepository(r)
+		err := repository.UpdatePolicy(ctx, policy)
+
+		if err != nil && errors.Is(err, db.ErrNotFound) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if err != nil && errors.Is(err, db.ErrImmutable) {
+			renderImmutablePolicy(w, r)
+			return
+		}
+
+		if err != nil {
+			GetLogger(r).Error("failed to update policy", "error", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		render.JSON(w, r, domain.NewPoliciesResponse([]domain.Policy{policy}))
+	})
+	policiesRouter.With(requireScope(domain.ScopePoliciesPublish)).Post("/{policyID}/publish", func(w http.ResponseWriter, r *http.Request) {
+		policyID, ok := parsePolicyID(w, r)
+		if !ok {
+			return
+		}
+
+		ctx := r.Context()
+		repository := GetRepository(r)
+		policy, err := repository.FetchPolicy(ctx, policyID)
+
+		if err != nil && errors.Is(err, db.ErrNotFound) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if err != nil {
+			GetLogger(r).Error("failed to fetch policy", "error", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if policy.IsPublished() {
+			renderImmutablePolicy(w, r)
+			return
+		}
+
+		// A policy can only supersede policies that have already been published.
+		var errs []string
+		for _, prevPolicyID := range policy.PrevPolicies {
+			prevPolicy, err := repository.FetchPolicy(ctx, prevPolicyID)
+			if err != nil && !errors.Is(err, db.ErrNotFound) {
+				GetLogger(r).Error("failed to fetch previous policy", "error", err)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			if err != nil || !prevPolicy.IsPublished() {
+				errs = append(errs, fmt.Sprintf("prev_policies: %s is not a published policy", prevPolicyID))
+			}
+		}
+		if len(errs) > 0 {
+			renderBadParams(w, r, errs)
+			return
+		}
+
+		publishedDate := domain.NewTimestamp(time.Now())
+		err = repository.PublishPolicy(ctx, policyID, publishedDate.Time)
+
+		if err != nil && errors.Is(err, db.ErrImmutable) {
+			renderImmutablePolicy(w, r)
+			return
+		}
+
+		if err != nil {
+			GetLogger(r).Error("failed to publish policy", "error", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		policy.PublishedDate = &publishedDate
+		w.WriteHeader(http.StatusOK)
+		render.JSON(w, r, domain.NewPoliciesResponse([]domain.Policy{policy}))
+	})
+	return policiesRouter
+}
+
+func NewRequirementsRouter() *chi.Mux {
+	requirementsRouter := chi.NewRouter()
+	requirementsRouter.With(requireScope(domain.ScopePoliciesRead)).Get("/", func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		repository := GetRepository(r)
+		requirements, err := repository.FetchRequirements(ctx)
+
+		if err != nil && errors.Is(err, db.ErrNotFound) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if err != nil {
+			GetLogger(r).Error("failed to fetch requirements", "error", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		render.JSON(w, r, domain.RequirementsResponse{
+			Version:      "2.0.0",
+			Requirements: requirements,
+		})
+	})
+	requirementsRouter.With(requireScope(domain.ScopePoliciesWrite)).Put("/", func(w http.ResponseWriter, r *http.Request) {
+		var requirements domain.Requirements
+		err := render.DecodeJSON(r.Body, &requirements)
+		if err != nil {
+			GetLogger(r).Info("malformed Requirements payload", "error", err)
+			renderBadParams(w, r, []string{"requirements payload is not valid JSON"})
+			return
+		}
+		defer r.Body.Close()
+
+		errs := domain.ValidateRequirements(requirements)
+		if len(errs) > 0 {
+			renderBadParams(w, r, errs)
+			return
+		}
+
+		requirements.LastUpdated = domain.NewTimestamp(time.Now())
+		ctx := r.Context()
+		repository := GetRepository(r)
+		err = repository.UpsertRequirements(ctx, requirements)
+		if err != nil {
+			GetLogger(r).Error("failed to store requirements", "error", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		render.JSON(w, r, domain.RequirementsResponse{
+			Version:      "2.0.0",
+			Requirements: requirements,
+		})
+	})
+	return requirementsRouter
+}
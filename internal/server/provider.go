@@ -0,0 +1,244 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/technopolitica/open-transit/internal/domain"
+)
+
+// recentEventsRetention is how far back in time the /events/recent endpoint serves events.
+// Older events must be requested an hour at a time from /events/historical.
+const recentEventsRetention = 14 * 24 * time.Hour
+
+func renderBadParams(w http.ResponseWriter, r *http.Request, errs []string) {
+	w.WriteHeader(http.StatusBadRequest)
+	render.JSON(w, r, domain.ApiError{
+		Type:    domain.ApiErrorTypeBadParam,
+		Details: errs,
+	})
+}
+
+func parseHourParam(r *http.Request, name string) (hour domain.TimeRange, errs []string) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		errs = append(errs, fmt.Sprintf("%s: missing required parameter", name))
+		return
+	}
+	hour, err := domain.ParseHour(value)
+	if err != nil {
+		errs = append(errs, fmt.Sprintf("%s: must be an hour in the format YYYY-MM-DDTHH", name))
+	}
+	return
+}
+
+func parseMillisParam(r *http.Request, name string) (t time.Time, errs []string) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		errs = append(errs, fmt.Sprintf("%s: missing required parameter", name))
+		return
+	}
+	millis, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		errs = append(errs, fmt.Sprintf("%s: must be a timestamp in milliseconds since the Unix epoch", name))
+		return
+	}
+	t = time.UnixMilli(millis).UTC()
+	return
+}
+
+func parseOptionalMillisParam(r *http.Request, name string) (t *time.Time, errs []string) {
+	if r.URL.Query().Get(name) == "" {
+		return
+	}
+	value, errs := parseMillisParam(r, name)
+	if len(errs) == 0 {
+		t = &value
+	}
+	return
+}
+
+func parseRecentEventsParams(r *http.Request, now time.Time) (window domain.TimeRange, errs []string) {
+	start, startErrs := parseMillisParam(r, "start_time")
+	end, endErrs := parseMillisParam(r, "end_time")
+	errs = append(startErrs, endErrs...)
+	if len(errs) > 0 {
+		return
+	}
+	if start.Before(now.Add(-recentEventsRetention)) {
+		errs = append(errs, "start_time: must be within the last two weeks")
+	}
+	if !end.After(start) {
+		errs = append(errs, "end_time: must be after start_time")
+	}
+	window = domain.TimeRange{Start: start, End: end}
+	return
+}
+
+// parseCompletedHourParam parses an hourly window query parameter, rendering an error response
+// and returning false if it is malformed or the hour has not finished yet (MDS requires that
+// hourly data is only served once it is complete).
+func parseCompletedHourParam(w http.ResponseWriter, r *http.Request, name string) (hour domain.TimeRange, ok bool) {
+	hour, errs := parseHourParam(r, name)
+	if len(errs) > 0 {
+		renderBadParams(w, r, errs)
+		return
+	}
+	if hour.End.After(time.Now()) {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	ok = true
+	return
+}
+
+func NewProviderRouter(maxPageSize int) *chi.Mux {
+	providerRouter := chi.NewRouter()
+	providerRouter.With(requireScope(domain.ScopeVehiclesRead)).Get("/vehicles", listVehicles(maxPageSize))
+	providerRouter.With(requireScope(domain.ScopeVehiclesRead)).Get("/vehicles/status", listVehicleStatuses(maxPageSize))
+	providerRouter.With(requireScope(domain.ScopeVehiclesRead)).Get("/vehicles/status/{vid}", fetchVehicleStatus)
+	providerRouter.With(requireScope(domain.ScopeVehiclesRead)).Get("/vehicles/{vid:.+}", fetchVehicle)
+	providerRouter.With(requireScope(domain.ScopeStopsRead)).Get("/stops", listStops)
+	providerRouter.With(requireScope(domain.ScopeStopsRead)).Get("/stops/{stopID}", fetchStop)
+	providerRouter.With(requireScope(domain.ScopeTripsRead)).Get("/trips", func(w http.ResponseWriter, r *http.Request) {
+		hour, ok := parseCompletedHourParam(w, r, "end_time")
+		if !ok {
+			return
+		}
+		providerID, errs := readableProviderID(r)
+		if len(errs) > 0 {
+			renderBadParams(w, r, errs)
+			return
+		}
+
+		ctx := r.Context()
+		repository := GetRepository(r)
+		trips, err := repository.ListTrips(ctx, domain.ListTripsParams{
+			ProviderID: providerID,
+			EndTime:    hour,
+		})
+		if err != nil {
+			GetLogger(r).Error("failed to list trips", "error", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		render.JSON(w, r, domain.TripsResponse{
+			Version: "2.0.0",
+			Trips:   trips,
+		})
+	})
+	providerRouter.With(requireScope(domain.ScopeTelemetryRead)).Get("/telemetry", func(w http.ResponseWriter, r *http.Request) {
+		hour, ok := parseCompletedHourParam(w, r, "telemetry_time")
+		if !ok {
+			return
+		}
+		providerID, errs := readableProviderID(r)
+		if len(errs) > 0 {
+			renderBadParams(w, r, errs)
+			return
+		}
+
+		ctx := r.Context()
+		repository := GetRepository(r)
+		telemetry, err := repository.ListTelemetry(ctx, domain.ListTelemetryParams{
+			ProviderID: providerID,
+			Timestamp:  hour,
+		})
+		if err != nil {
+			GetLogger(r).Error("failed to list telemetry", "error", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		render.JSON(w, r, domain.TelemetryResponse{
+			Version:   "2.0.0",
+			Telemetry: telemetry,
+		})
+	})
+	providerRouter.With(requireScope(domain.ScopeEventsRead)).Get("/events/historical", func(w http.ResponseWriter, r *http.Request) {
+		hour, ok := parseCompletedHourParam(w, r, "event_time")
+		if !ok {
+			return
+		}
+		providerID, errs := readableProviderID(r)
+		if len(errs) > 0 {
+			renderBadParams(w, r, errs)
+			return
+		}
+
+		ctx := r.Context()
+		repository := GetRepository(r)
+		events, err := repository.ListEvents(ctx, domain.ListEventsParams{
+			ProviderID: providerID,
+			Timestamp:  hour,
+		})
+		if err != nil {
+			GetLogger(r).Error("failed to list events", "error", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		render.JSON(w, r, domain.EventsResponse{
+			Version: "2.0.0",
+			Events:  events,
+		})
+	})
+	providerRouter.With(requireScope(domain.ScopeEventsRead)).Get("/events/recent", func(w http.ResponseWriter, r *http.Request) {
+		window, errs := parseRecentEventsParams(r, time.Now())
+		providerID, providerErrs := readableProviderID(r)
+		errs = append(errs, providerErrs...)
+		if len(errs) > 0 {
+			renderBadParams(w, r, errs)
+			return
+		}
+
+		ctx := r.Context()
+		repository := GetRepository(r)
+		events, err := repository.ListEvents(ctx, domain.ListEventsParams{
+			ProviderID: providerID,
+			Timestamp:  window,
+		})
+		if err != nil {
+			GetLogger(r).Error("failed to list events", "error", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		render.JSON(w, r, domain.EventsResponse{
+			Version: "2.0.0",
+			Events:  events,
+		})
+	})
+	providerRouter.With(requireScope(domain.ScopeReportsRead)).Get("/reports", func(w http.ResponseWriter, r *http.Request) {
+		providerID, errs := readableProviderID(r)
+		if len(errs) > 0 {
+			renderBadParams(w, r, errs)
+			return
+		}
+
+		ctx := r.Context()
+		repository := GetRepository(r)
+		reports, err := repository.ListReports(ctx, providerID)
+		if err != nil {
+			GetLogger(r).Error("failed to list reports", "error", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		render.JSON(w, r, domain.ReportsResponse{
+			Version: "2.0.0",
+			Reports: reports,
+		})
+	})
+	return providerRouter
+}
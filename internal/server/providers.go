@@ -0,0 +1,176 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+	"github.com/technopolitica/open-transit/internal/db"
+	"github.com/technopolitica/open-transit/internal/domain"
+)
+
+func NewProvidersRouter() *chi.Mux {
+	providersRouter := chi.NewRouter()
+	providersRouter.With(requireScope(domain.ScopeProvidersRead)).Get("/", func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		repository := GetRepository(r)
+		providers, err := repository.ListProviders(ctx)
+		if err != nil {
+			GetLogger(r).Error("failed to list providers", "error", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		render.JSON(w, r, domain.ProvidersResponse{
+			Version:   "2.0.0",
+			Providers: providers,
+		})
+	})
+	providersRouter.With(requireScope(domain.ScopeProvidersRead)).Get("/{providerID}", func(w http.ResponseWriter, r *http.Request) {
+		providerID, err := uuid.Parse(chi.URLParam(r, "providerID"))
+		if err != nil {
+			renderBadParams(w, r, []string{"provider_id: must be a valid UUID"})
+			return
+		}
+
+		ctx := r.Context()
+		repository := GetRepository(r)
+		provider, err := repository.FetchProvider(ctx, providerID)
+
+		if err != nil && errors.Is(err, db.ErrNotFound) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if err != nil {
+			GetLogger(r).Error("failed to fetch provider", "error", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		render.JSON(w, r, domain.ProviderResponse{
+			Version:  "2.0.0",
+			Provider: provider,
+		})
+	})
+	providersRouter.With(requireScope(domain.ScopeProvidersWrite)).Post("/", func(w http.ResponseWriter, r *http.Request) {
+		var provider domain.Provider
+		err := render.DecodeJSON(r.Body, &provider)
+		if err != nil {
+			GetLogger(r).Info("malformed Provider payload", "error", err)
+			renderBadParams(w, r, []string{"provider payload is not valid JSON"})
+			return
+		}
+		defer r.Body.Close()
+
+		errs := domain.ValidateProvider(provider)
+		if len(errs) > 0 {
+			renderBadParams(w, r, errs)
+			return
+		}
+
+		ctx := r.Context()
+		repository := GetRepository(r)
+		err = repository.InsertProvider(ctx, provider)
+
+		if err != nil && errors.Is(err, db.ErrConflict) {
+			w.WriteHeader(http.StatusConflict)
+			render.JSON(w, r, domain.ApiError{
+				Type:    domain.ApiErrorTypeAlreadyRegistered,
+				Details: []string{"A provider with provider_id is already registered"},
+			})
+			return
+		}
+
+		if err != nil {
+			GetLogger(r).Error("failed to insert provider", "error", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		render.JSON(w, r, domain.ProviderResponse{
+			Version:  "2.0.0",
+			Provider: provider,
+		})
+	})
+	providersRouter.With(requireScope(domain.ScopeProvidersWrite)).Put("/{providerID}", func(w http.ResponseWriter, r *http.Request) {
+		providerID, err := uuid.Parse(chi.URLParam(r, "providerID"))
+		if err != nil {
+			renderBadParams(w, r, []string{"provider_id: must be a valid UUID"})
+			return
+		}
+
+		var provider domain.Provider
+		err = render.DecodeJSON(r.Body, &provider)
+		if err != nil {
+			GetLogger(r).Info("malformed Provider payload", "error", err)
+			renderBadParams(w, r, []string{"provider payload is not valid JSON"})
+			return
+		}
+		defer r.Body.Close()
+
+		if provider.ProviderID == (uuid.UUID{}) {
+			provider.ProviderID = providerID
+		}
+		errs := domain.ValidateProvider(provider)
+		if provider.ProviderID != providerID {
+			errs = append(errs, "provider_id: does not match the provider being updated")
+		}
+		if len(errs) > 0 {
+			renderBadParams(w, r, errs)
+			return
+		}
+
+		ctx := r.Context()
+		repository := GetRepository(r)
+		err = repository.UpdateProvider(ctx, provider)
+
+		if err != nil && errors.Is(err, db.ErrNotFound) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if err != nil {
+			GetLogger(r).Error("failed to update provider", "error", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		render.JSON(w, r, domain.ProviderResponse{
+			Version:  "2.0.0",
+			Provider: provider,
+		})
+	})
+	// Deleting a provider only removes it from the registry; the data it submitted is kept.
+	providersRouter.With(requireScope(domain.ScopeProvidersWrite)).Delete("/{providerID}", func(w http.ResponseWriter, r *http.Request) {
+		providerID, err := uuid.Parse(chi.URLParam(r, "providerID"))
+		if err != nil {
+			renderBadParams(w, r, []string{"provider_id: must be a valid UUID"})
+			return
+		}
+
+		ctx := r.Context()
+		repository := GetRepository(r)
+		err = repository.DeleteProvider(ctx, providerID)
+
+		if err != nil && errors.Is(err, db.ErrNotFound) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if err != nil {
+			GetLogger(r).Error("failed to delete provider", "error", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+	return providersRouter
+}
@@ -0,0 +1,44 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/render"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/technopolitica/open-transit/internal/db"
+	"github.com/technopolitica/open-transit/internal/domain"
+)
+
+// CheckReadiness pings the database and compares its schema version with the newest migration
+// embedded in the server.
+func CheckReadiness(ctx context.Context, dbConnPool *pgxpool.Pool) domain.Readiness {
+	latestVersion, latestErr := db.LatestMigrationVersion()
+	conn, err := dbConnPool.Acquire(ctx)
+	if err != nil {
+		return domain.NewReadiness(domain.DatabaseReadiness{Error: err.Error()}, 0, latestVersion, latestErr)
+	}
+	defer conn.Release()
+	err = conn.Ping(ctx)
+	if err != nil {
+		return domain.NewReadiness(domain.DatabaseReadiness{Error: err.Error()}, 0, latestVersion, latestErr)
+	}
+	version, err := db.SchemaVersion(ctx, conn.Conn())
+	return domain.NewReadiness(domain.DatabaseReadiness{Ready: true}, version, latestVersion, errors.Join(err, latestErr))
+}
+
+// readiness responds with HTTP 200 OK if the server is ready to handle requests and HTTP 503
+// Service Unavailable otherwise, along with the result of every check.
+func readiness(dbConnPool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		result := CheckReadiness(r.Context(), dbConnPool)
+		if !result.Ready {
+			GetLogger(r).Warn("not ready", "database", result.Database.Error, "schema", result.Schema.Error)
+			w.WriteHeader(http.StatusServiceUnavailable)
+		} else {
+			w.WriteHeader(http.StatusOK)
+		}
+		render.JSON(w, r, result)
+	}
+}
@@ -0,0 +1,84 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/technopolitica/open-transit/internal/db"
+	"github.com/technopolitica/open-transit/internal/domain"
+)
+
+func NewReportsRouter() *chi.Mux {
+	reportsRouter := chi.NewRouter()
+	reportsRouter.With(requireScope(domain.ScopeReportsWrite), requireActiveProvider).Post("/", func(w http.ResponseWriter, r *http.Request) {
+		var reports []domain.MonthlyReport
+		err := render.DecodeJSON(r.Body, &reports)
+		if err != nil {
+			GetLogger(r).Info("malformed Report payload", "error", err)
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, domain.ApiError{
+				Type:    domain.ApiErrorTypeBadParam,
+				Details: []string{"reports payload is not valid JSON"},
+			})
+			return
+		}
+		defer r.Body.Close()
+
+		ctx := r.Context()
+		repository := GetRepository(r)
+		nServerErrors := 0
+		response := domain.BulkApiResponse[domain.MonthlyReport]{
+			Total: len(reports),
+		}
+		auth := GetAuthInfo(r)
+		for _, report := range reports {
+			errs := domain.ValidateReport(report)
+			if report.ProviderID != auth.ProviderID {
+				errs = append(errs, "provider_id: not allowed to submit reports for another provider")
+			}
+			if len(errs) > 0 {
+				response.Failures = append(response.Failures, domain.FailureDetails[domain.MonthlyReport]{
+					Item: report,
+					ApiError: domain.ApiError{
+						Type:    domain.ApiErrorTypeBadParam,
+						Details: errs,
+					},
+				})
+				continue
+			}
+
+			err := repository.InsertReport(ctx, report)
+
+			if err != nil && errors.Is(err, db.ErrConflict) {
+				response.Failures = append(response.Failures, domain.FailureDetails[domain.MonthlyReport]{
+					Item: report,
+					ApiError: domain.ApiError{
+						Type:    domain.ApiErrorTypeAlreadyRegistered,
+						Details: []string{"A report for this period, special_group_type, geography_id and vehicle_type has already been submitted"},
+					},
+				})
+				continue
+			}
+
+			if err != nil {
+				GetLogger(r).Error("failed to insert report", "error", err)
+				response.Failures = append(response.Failures, domain.FailureDetails[domain.MonthlyReport]{
+					Item: report,
+					ApiError: domain.ApiError{
+						Type:    domain.ApiErrorTypeUnknown,
+						Details: []string{"An unknown error has occurred"},
+					},
+				})
+				nServerErrors += 1
+				continue
+			}
+
+			response.Success += 1
+		}
+
+		renderBulkResponse(w, r, http.StatusCreated, nServerErrors, response)
+	})
+	return reportsRouter
+}
@@ -4,19 +4,22 @@ package server
 
 import (
 	"context"
-	"crypto/rsa"
+	"errors"
 	"fmt"
-	"log"
+	"log/slog"
 	"net/http"
 	"strings"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/technopolitica/open-transit/internal/db"
 	"github.com/technopolitica/open-transit/internal/domain"
+	"github.com/technopolitica/open-transit/internal/keys"
 )
 
 type authClaims struct {
@@ -42,7 +45,14 @@ func GetRepository(r *http.Request) (repo db.Repository) {
 	return
 }
 
-// ENUM(auth, repository)
+// GetProvider returns the registration of the provider or data provider making the request. It is
+// only available behind requireActiveProvider, and only for callers with those roles.
+func GetProvider(r *http.Request) (provider domain.Provider, ok bool) {
+	provider, ok = r.Context().Value(ContextKeyProvider).(domain.Provider)
+	return
+}
+
+// ENUM(auth, repository, provider, log, instruments)
 type contextKey int
 
 func parseBearerToken(r *http.Request) (bearerToken string, err error) {
@@ -64,25 +74,44 @@ func parseBearerToken(r *http.Request) (bearerToken string, err error) {
 	return
 }
 
-func checkAuthentication(r *http.Request, publicKey *rsa.PublicKey) (authInfo domain.AuthInfo, err error) {
+func checkAuthentication(r *http.Request, keySet keys.Set) (authInfo domain.AuthInfo, err error) {
 	bearerToken, err := parseBearerToken(r)
 	if err != nil {
 		return
 	}
-	parser := jwt.NewParser(jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Name, jwt.SigningMethodRS384.Name, jwt.SigningMethodRS512.Name}))
-	var claims authClaims
-	authToken, err := parser.ParseWithClaims(bearerToken, &claims, func(t *jwt.Token) (interface{}, error) {
-		return publicKey, nil
-	})
+	parser := jwt.NewParser(jwt.WithValidMethods(keys.Algorithms))
+	// The header is read before verifying the token to find which keys may have signed it.
+	unverifiedToken, _, err := parser.ParseUnverified(bearerToken, &authClaims{})
+	if err != nil {
+		err = fmt.Errorf("invalid auth token: %w", err)
+		return
+	}
+	kid, _ := unverifiedToken.Header["kid"].(string)
+	candidateKeys, err := keySet.Keys(r.Context(), kid, unverifiedToken.Method.Alg())
 	if err != nil {
 		err = fmt.Errorf("invalid auth token: %w", err)
 		return
 	}
-	if !authToken.Valid {
+	for _, key := range candidateKeys {
+		var claims authClaims
+		var authToken *jwt.Token
+		authToken, err = parser.ParseWithClaims(bearerToken, &claims, func(t *jwt.Token) (interface{}, error) {
+			return key, nil
+		})
+		if err == nil && authToken.Valid {
+			authInfo = claims.AuthInfo.WithDefaultRole()
+			return
+		}
+		// Only a bad signature means that another key may have signed the token.
+		if err != nil && !errors.Is(err, jwt.ErrTokenSignatureInvalid) {
+			break
+		}
+	}
+	if err == nil {
 		err = fmt.Errorf("invalid auth token")
 		return
 	}
-	authInfo = claims.AuthInfo
+	err = fmt.Errorf("invalid auth token: %w", err)
 	return
 }
 
@@ -92,14 +121,14 @@ func database(dbConnPool *pgxpool.Pool) func(http.Handler) http.Handler {
 			ctx := r.Context()
 			conn, err := dbConnPool.Acquire(ctx)
 			if err != nil {
-				log.Printf("failed to acquire database connection: %s", err)
+				GetLogger(r).Error("failed to acquire database connection", "error", err)
 				w.WriteHeader(http.StatusInternalServerError)
 				return
 			}
 			defer conn.Release()
 			repo, err := db.NewRepository(ctx, conn.Conn())
 			if err != nil {
-				log.Printf("failed to construct repository: %s", err)
+				GetLogger(r).Error("failed to construct repository", "error", err)
 				w.WriteHeader(http.StatusInternalServerError)
 				return
 			}
@@ -109,22 +138,174 @@ func database(dbConnPool *pgxpool.Pool) func(http.Handler) http.Handler {
 	}
 }
 
-func authentication(publicKey *rsa.PublicKey) func(http.Handler) http.Handler {
+// errAuthenticationUnavailable means that the caller couldn't be authenticated because of a server
+// error rather than because their credentials are invalid.
+var errAuthenticationUnavailable = errors.New("authentication unavailable")
+
+// checkCertificateAuthentication authenticates a request by the subject of its verified TLS client
+// certificate. The subject must be bound to a provider with a certificate-bound credential. A
+// database connection is only acquired for the lookup, so that unauthenticated requests don't hold
+// on to one.
+func checkCertificateAuthentication(r *http.Request, dbConnPool *pgxpool.Pool) (authInfo domain.AuthInfo, err error) {
+	subject := r.TLS.VerifiedChains[0][0].Subject.String()
+	ctx := r.Context()
+	conn, err := dbConnPool.Acquire(ctx)
+	if err != nil {
+		err = fmt.Errorf("%w: failed to acquire database connection: %w", errAuthenticationUnavailable, err)
+		return
+	}
+	defer conn.Release()
+	repo, err := db.NewRepository(ctx, conn.Conn())
+	if err != nil {
+		err = fmt.Errorf("%w: failed to construct repository: %w", errAuthenticationUnavailable, err)
+		return
+	}
+	credential, err := repo.FetchProviderCredential(ctx, subject)
+	if err != nil && !errors.Is(err, db.ErrNotFound) {
+		err = fmt.Errorf("%w: failed to fetch credential: %w", errAuthenticationUnavailable, err)
+		return
+	}
+	if err != nil || !credential.IsCertificateBound() || credential.IsRevoked() {
+		err = fmt.Errorf("client certificate %s is not bound to a provider", subject)
+		return
+	}
+	authInfo = credential.AuthInfo()
+	return
+}
+
+// hasClientCertificate reports whether the request was made with a client certificate that was
+// verified against the server's client CAs.
+func hasClientCertificate(r *http.Request) bool {
+	return r.TLS != nil && len(r.TLS.VerifiedChains) > 0
+}
+
+// authentication identifies the caller from a bearer token or, for requests without an
+// Authorization header, a verified TLS client certificate.
+func authentication(keySet keys.Set, dbConnPool *pgxpool.Pool) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			authInfo, err := checkAuthentication(r, publicKey)
+			var authInfo domain.AuthInfo
+			var err error
+			if r.Header.Get("Authorization") == "" && hasClientCertificate(r) {
+				authInfo, err = checkCertificateAuthentication(r, dbConnPool)
+			} else {
+				authInfo, err = checkAuthentication(r, keySet)
+			}
+			if err != nil && errors.Is(err, errAuthenticationUnavailable) {
+				GetLogger(r).Error("failed to authenticate", "error", err)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
 			if err != nil {
-				log.Printf("%s", err)
+				GetLogger(r).Info("authentication failed", "error", err)
 				w.Header().Set("WWW-Authenticate", `Bearer, charset="UTF-8"`)
 				w.WriteHeader(401)
 				return
 			}
+			annotateAuthInfo(r, authInfo)
 			r = r.WithContext(context.WithValue(r.Context(), ContextKeyAuth, authInfo))
 			next.ServeHTTP(w, r)
 		})
 	}
 }
 
+// requireScope restricts a route to callers whose token grants scope.
+func requireScope(scope domain.Scope) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !GetAuthInfo(r).HasScope(scope) {
+				renderForbidden(w, r, fmt.Sprintf("missing required scope %s", scope))
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func renderForbidden(w http.ResponseWriter, r *http.Request, details ...string) {
+	w.WriteHeader(http.StatusForbidden)
+	render.JSON(w, r, domain.ApiError{
+		Type:    domain.ApiErrorTypeForbidden,
+		Details: details,
+	})
+}
+
+// requireActiveProvider rejects requests from providers and data providers that aren't registered
+// or have been suspended. Data providers are registered in the provider registry under their own
+// provider_id. Callers with other roles aren't affected.
+func requireActiveProvider(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth := GetAuthInfo(r)
+		if !auth.IsProvider() && auth.Role != domain.RoleDataProvider {
+			next.ServeHTTP(w, r)
+			return
+		}
+		kind := "provider"
+		if auth.Role == domain.RoleDataProvider {
+			kind = "data provider"
+		}
+		ctx := r.Context()
+		provider, err := GetRepository(r).FetchProvider(ctx, auth.ProviderID)
+		if err != nil && errors.Is(err, db.ErrNotFound) {
+			renderForbidden(w, r, fmt.Sprintf("provider_id: %s is not registered", kind))
+			return
+		}
+		if err != nil {
+			GetLogger(r).Error("failed to fetch provider", "error", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		if provider.Status != domain.ProviderStatusActive {
+			renderForbidden(w, r, fmt.Sprintf("provider_id: %s is suspended", kind))
+			return
+		}
+		r = r.WithContext(context.WithValue(ctx, ContextKeyProvider, provider))
+		next.ServeHTTP(w, r)
+	})
+}
+
+// readableProviderID returns the provider whose data the caller may read, or nil for every
+// provider. Providers may only read their own data, while agency staff may read every provider's
+// data or narrow it down with the optional provider_id query parameter.
+func readableProviderID(r *http.Request) (providerID *uuid.UUID, errs []string) {
+	auth := GetAuthInfo(r)
+	if auth.IsProvider() {
+		providerID = &auth.ProviderID
+		return
+	}
+	value := r.URL.Query().Get("provider_id")
+	if value == "" {
+		return
+	}
+	id, err := uuid.Parse(value)
+	if err != nil {
+		errs = append(errs, "provider_id: must be a valid UUID")
+		return
+	}
+	providerID = &id
+	return
+}
+
+// limitRequestBody rejects request bodies larger than maxBytes so that a single request can't
+// exhaust the server's memory while its payload is decoded. Bodies without a Content-Length are
+// cut off once they reach the limit, which makes decoding them fail.
+func limitRequestBody(maxBytes int64) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.ContentLength > maxBytes {
+				w.WriteHeader(http.StatusRequestEntityTooLarge)
+				render.JSON(w, r, domain.ApiError{
+					Type:    domain.ApiErrorTypeBadParam,
+					Details: []string{fmt.Sprintf("request body must not exceed %d bytes", maxBytes)},
+				})
+				return
+			}
+			r.Body = http.MaxBytesReader(w, r.Body, maxBytes)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
 func addHostToRequestURL(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		r.URL.Host = r.Host
@@ -137,19 +318,125 @@ func addHostToRequestURL(next http.Handler) http.Handler {
 	})
 }
 
-// FIXME: probably MUCH better to use JWKS here so we don't have to restart the server to change keys.
-func New(db *pgxpool.Pool, publicKey rsa.PublicKey) *chi.Mux {
+// Options tune which APIs the server exposes and the limits it applies to requests.
+type Options struct {
+	// MaxBodyBytes is the largest request body accepted. Zero means no limit.
+	MaxBodyBytes int64
+	// MaxPageSize is the largest page[limit] accepted by paginated endpoints. Zero means
+	// domain.DefaultMaxPageSize.
+	MaxPageSize int
+	// Modules are the MDS APIs to serve. When empty every module is served. The provider registry
+	// and token endpoint are always served.
+	Modules []domain.Module
+	// LogRequests logs a line for every request.
+	LogRequests bool
+	// Logger receives the server's log messages. Nil means slog.Default().
+	Logger *slog.Logger
+	// Instruments record metrics about requests. Nil disables them.
+	Instruments *Instruments
+	// HandlerTimeout cancels the context of requests that take longer, so that handlers stop
+	// working on responses that can no longer be written. Zero means no timeout.
+	HandlerTimeout time.Duration
+}
+
+// New builds the API router. issuer may be nil to disable the built-in token endpoint.
+func New(db *pgxpool.Pool, keySet keys.Set, issuer *TokenIssuer, options Options) *chi.Mux {
+	if options.MaxPageSize == 0 {
+		options.MaxPageSize = domain.DefaultMaxPageSize
+	}
+	modules := domain.NewSet(options.Modules...)
+	if len(modules) == 0 {
+		modules = domain.NewSet(domain.AllModules()...)
+	}
+
+	logger := options.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+
 	router := chi.NewRouter()
-	router.Use(middleware.Logger)
-	router.Use(middleware.AllowContentType("application/vnd.mds+json"))
+	router.Use(middleware.RequestID)
+	router.Use(logRequests(logger, options.LogRequests))
+	if options.Instruments != nil {
+		router.Use(instrumentRequests(options.Instruments))
+	}
 	router.Use(middleware.Heartbeat("/health"))
-	router.Use(middleware.Timeout(15 * time.Second))
+	if options.HandlerTimeout > 0 {
+		router.Use(middleware.Timeout(options.HandlerTimeout))
+	}
 	router.Use(addHostToRequestURL)
-	router.Use(authentication(&publicKey))
-	router.Use(database(db))
+	if options.MaxBodyBytes > 0 {
+		router.Use(limitRequestBody(options.MaxBodyBytes))
+	}
+
+	// Readiness probes aren't authenticated, like /health.
+	router.Get("/ready", readiness(db))
+
+	// The token endpoint authenticates clients itself and accepts form-encoded requests, as OAuth
+	// 2.0 requires, so it sits outside of the MDS middleware.
+	if issuer != nil {
+		router.Group(func(router chi.Router) {
+			router.Use(database(db))
+			router.Mount("/oauth/token", NewTokenRouter(*issuer))
+		})
+	}
 
-	vehiclesRouter := NewVehiclesRouter()
-	router.Mount("/vehicles", vehiclesRouter)
+	router.Group(func(router chi.Router) {
+		router.Use(middleware.AllowContentType("application/vnd.mds+json"))
+		router.Use(authentication(keySet, db))
+		router.Use(database(db))
+
+		if modules.Contains(domain.ModuleAgency) {
+			vehiclesRouter := NewVehiclesRouter(options.MaxPageSize)
+			router.Mount("/vehicles", vehiclesRouter)
+
+			eventsRouter := NewEventsRouter()
+			router.Mount("/events", eventsRouter)
+
+			telemetryRouter := NewTelemetryRouter()
+			router.Mount("/telemetry", telemetryRouter)
+
+			tripsRouter := NewTripsRouter()
+			router.Mount("/trips", tripsRouter)
+
+			stopsRouter := NewStopsRouter()
+			router.Mount("/stops", stopsRouter)
+
+			reportsRouter := NewReportsRouter()
+			router.Mount("/reports", reportsRouter)
+		}
+
+		if modules.Contains(domain.ModuleProvider) {
+			providerRouter := NewProviderRouter(options.MaxPageSize)
+			router.Mount("/provider", providerRouter)
+		}
+
+		if modules.Contains(domain.ModulePolicy) {
+			policiesRouter := NewPoliciesRouter()
+			router.Mount("/policies", policiesRouter)
+
+			requirementsRouter := NewRequirementsRouter()
+			router.Mount("/requirements", requirementsRouter)
+		}
+
+		if modules.Contains(domain.ModuleGeography) {
+			geographiesRouter := NewGeographiesRouter()
+			router.Mount("/geographies", geographiesRouter)
+		}
+
+		if modules.Contains(domain.ModuleJurisdiction) {
+			jurisdictionsRouter := NewJurisdictionsRouter()
+			router.Mount("/jurisdictions", jurisdictionsRouter)
+		}
+
+		if modules.Contains(domain.ModuleMetrics) {
+			metricsRouter := NewMetricsRouter()
+			router.Mount("/metrics", metricsRouter)
+		}
+
+		providersRouter := NewProvidersRouter()
+		router.Mount("/providers", providersRouter)
+	})
 
 	return router
 }
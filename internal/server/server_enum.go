@@ -16,15 +16,24 @@ const (
 	ContextKeyAuth contextKey = iota
 	// ContextKeyRepository is a contextKey of type Repository.
 	ContextKeyRepository
+	// ContextKeyProvider is a contextKey of type Provider.
+	ContextKeyProvider
+	// ContextKeyLog is a contextKey of type Log.
+	ContextKeyLog
+	// ContextKeyInstruments is a contextKey of type Instruments.
+	ContextKeyInstruments
 )
 
 var ErrInvalidcontextKey = errors.New("not a valid contextKey")
 
-const _contextKeyName = "authrepository"
+const _contextKeyName = "authrepositoryproviderloginstruments"
 
 var _contextKeyMap = map[contextKey]string{
-	ContextKeyAuth:       _contextKeyName[0:4],
-	ContextKeyRepository: _contextKeyName[4:14],
+	ContextKeyAuth:        _contextKeyName[0:4],
+	ContextKeyRepository:  _contextKeyName[4:14],
+	ContextKeyProvider:    _contextKeyName[14:22],
+	ContextKeyLog:         _contextKeyName[22:25],
+	ContextKeyInstruments: _contextKeyName[25:36],
 }
 
 // String implements the Stringer interface.
@@ -43,8 +52,11 @@ func (x contextKey) IsValid() bool {
 }
 
 var _contextKeyValue = map[string]contextKey{
-	_contextKeyName[0:4]:  ContextKeyAuth,
-	_contextKeyName[4:14]: ContextKeyRepository,
+	_contextKeyName[0:4]:   ContextKeyAuth,
+	_contextKeyName[4:14]:  ContextKeyRepository,
+	_contextKeyName[14:22]: ContextKeyProvider,
+	_contextKeyName[22:25]: ContextKeyLog,
+	_contextKeyName[25:36]: ContextKeyInstruments,
 }
 
 // ParsecontextKey attempts to convert a string to a contextKey.
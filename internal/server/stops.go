@@ -0,0 +1,220 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+	"github.com/technopolitica/open-transit/internal/db"
+	"github.com/technopolitica/open-transit/internal/domain"
+)
+
+func listStops(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	repository := GetRepository(r)
+	stops, err := repository.ListStops(ctx)
+	if err != nil {
+		GetLogger(r).Error("failed to list stops", "error", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	render.JSON(w, r, domain.StopsResponse{
+		Version: "2.0.0",
+		Stops:   stops,
+	})
+}
+
+func fetchStop(w http.ResponseWriter, r *http.Request) {
+	stopID, err := uuid.Parse(chi.URLParam(r, "stopID"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		render.JSON(w, r, domain.ApiError{
+			Type:    domain.ApiErrorTypeBadParam,
+			Details: []string{"stop_id: must be a valid UUID"},
+		})
+		return
+	}
+
+	ctx := r.Context()
+	repository := GetRepository(r)
+	stop, err := repository.FetchStop(ctx, stopID)
+
+	if err != nil && errors.Is(err, db.ErrNotFound) {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	if err != nil {
+		GetLogger(r).Error("failed to fetch stop", "error", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	render.JSON(w, r, domain.StopsResponse{
+		Version: "2.0.0",
+		Stops:   []domain.Stop{stop},
+	})
+}
+
+func NewStopsRouter() *chi.Mux {
+	stopsRouter := chi.NewRouter()
+	stopsRouter.With(requireScope(domain.ScopeStopsWrite)).Post("/", func(w http.ResponseWriter, r *http.Request) {
+		var stops []domain.Stop
+		err := render.DecodeJSON(r.Body, &stops)
+		if err != nil {
+			GetLogger(r).Info("malformed Stop payload", "error", err)
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, domain.ApiError{
+				Type:    domain.ApiErrorTypeBadParam,
+				Details: []string{"stops payload is not valid JSON"},
+			})
+			return
+		}
+		defer r.Body.Close()
+
+		ctx := r.Context()
+		repository := GetRepository(r)
+		nServerErrors := 0
+		response := domain.BulkApiResponse[domain.Stop]{
+			Total: len(stops),
+		}
+		for _, stop := range stops {
+			errs := domain.ValidateStop(stop)
+			if len(errs) > 0 {
+				response.Failures = append(response.Failures, domain.FailureDetails[domain.Stop]{
+					Item: stop,
+					ApiError: domain.ApiError{
+						Type:    domain.ApiErrorTypeBadParam,
+						Details: errs,
+					},
+				})
+				continue
+			}
+
+			err := repository.InsertStop(ctx, stop)
+
+			if err != nil && errors.Is(err, db.ErrConflict) {
+				response.Failures = append(response.Failures, domain.FailureDetails[domain.Stop]{
+					Item: stop,
+					ApiError: domain.ApiError{
+						Type:    domain.ApiErrorTypeAlreadyRegistered,
+						Details: []string{"A stop with stop_id is already registered"},
+					},
+				})
+				continue
+			}
+
+			if err != nil && errors.Is(err, db.ErrInvalidReference) {
+				response.Failures = append(response.Failures, domain.FailureDetails[domain.Stop]{
+					Item: stop,
+					ApiError: domain.ApiError{
+						Type:    domain.ApiErrorTypeBadParam,
+						Details: []string{fmt.Sprintf("parent_stop: no stop with stop_id %s is registered", stop.ParentStop)},
+					},
+				})
+				continue
+			}
+
+			if err != nil {
+				GetLogger(r).Error("failed to insert stop", "error", err)
+				response.Failures = append(response.Failures, domain.FailureDetails[domain.Stop]{
+					Item: stop,
+					ApiError: domain.ApiError{
+						Type:    domain.ApiErrorTypeUnknown,
+						Details: []string{"An unknown error has occurred"},
+					},
+				})
+				nServerErrors += 1
+				continue
+			}
+
+			response.Success += 1
+		}
+
+		renderBulkResponse(w, r, http.StatusCreated, nServerErrors, response)
+	})
+	stopsRouter.With(requireScope(domain.ScopeStopsWrite)).Put("/", func(w http.ResponseWriter, r *http.Request) {
+		var stops []domain.Stop
+		err := render.DecodeJSON(r.Body, &stops)
+		if err != nil {
+			GetLogger(r).Info("malformed Stop payload", "error", err)
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, domain.ApiError{
+				Type:    domain.ApiErrorTypeBadParam,
+				Details: []string{"stops payload is not valid JSON"},
+			})
+			return
+		}
+		defer r.Body.Close()
+
+		ctx := r.Context()
+		repository := GetRepository(r)
+		nServerErrors := 0
+		response := domain.BulkApiResponse[domain.Stop]{
+			Total: len(stops),
+		}
+		for _, stop := range stops {
+			errs := domain.ValidateStop(stop)
+			if len(errs) > 0 {
+				response.Failures = append(response.Failures, domain.FailureDetails[domain.Stop]{
+					Item: stop,
+					ApiError: domain.ApiError{
+						Type:    domain.ApiErrorTypeBadParam,
+						Details: errs,
+					},
+				})
+				continue
+			}
+
+			err := repository.UpdateStop(ctx, stop)
+
+			if err != nil && errors.Is(err, db.ErrNotFound) {
+				response.Failures = append(response.Failures, domain.FailureDetails[domain.Stop]{
+					Item: stop,
+					ApiError: domain.ApiError{
+						Type:    domain.ApiErrorTypeUnregistered,
+						Details: []string{},
+					},
+				})
+				continue
+			}
+
+			if err != nil && errors.Is(err, db.ErrInvalidReference) {
+				response.Failures = append(response.Failures, domain.FailureDetails[domain.Stop]{
+					Item: stop,
+					ApiError: domain.ApiError{
+						Type:    domain.ApiErrorTypeBadParam,
+						Details: []string{fmt.Sprintf("parent_stop: no stop with stop_id %s is registered", stop.ParentStop)},
+					},
+				})
+				continue
+			}
+
+			if err != nil {
+				GetLogger(r).Error("failed to update stop", "error", err)
+				response.Failures = append(response.Failures, domain.FailureDetails[domain.Stop]{
+					Item: stop,
+					ApiError: domain.ApiError{
+						Type:    domain.ApiErrorTypeUnknown,
+						Details: []string{"An unknown error has occurred"},
+					},
+				})
+				nServerErrors += 1
+				continue
+			}
+
+			response.Success += 1
+		}
+
+		renderBulkResponse(w, r, http.StatusOK, nServerErrors, response)
+	})
+	stopsRouter.With(requireScope(domain.ScopeStopsRead)).Get("/", listStops)
+	stopsRouter.With(requireScope(domain.ScopeStopsRead)).Get("/{stopID}", fetchStop)
+	return stopsRouter
+}
@@ -0,0 +1,113 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/technopolitica/open-transit/internal/db"
+	"github.com/technopolitica/open-transit/internal/domain"
+)
+
+func NewTelemetryRouter() *chi.Mux {
+	telemetryRouter := chi.NewRouter()
+	telemetryRouter.With(requireScope(domain.ScopeTelemetryWrite), requireActiveProvider).Post("/", func(w http.ResponseWriter, r *http.Request) {
+		var telemetry []domain.Telemetry
+		err := render.DecodeJSON(r.Body, &telemetry)
+		if err != nil {
+			GetLogger(r).Info("malformed Telemetry payload", "error", err)
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, domain.ApiError{
+				Type:    domain.ApiErrorTypeBadParam,
+				Details: []string{"telemetry payload is not valid JSON"},
+			})
+			return
+		}
+		defer r.Body.Close()
+
+		ctx := r.Context()
+		repository := GetRepository(r)
+		nServerErrors := 0
+		response := domain.BulkApiResponse[domain.Telemetry]{
+			Total: len(telemetry),
+		}
+		addServerError := func(point domain.Telemetry, err error) {
+			GetLogger(r).Error("failed to process telemetry", "device_id", point.DeviceID, "error", err)
+			response.Failures = append(response.Failures, domain.FailureDetails[domain.Telemetry]{
+				Item: point,
+				ApiError: domain.ApiError{
+					Type:    domain.ApiErrorTypeUnknown,
+					Details: []string{"An unknown error has occurred"},
+				},
+			})
+			nServerErrors += 1
+		}
+		access := newWriteAccess(r)
+		for _, point := range telemetry {
+			errs := domain.ValidateTelemetry(point)
+			_, accessErrs, err := access.authorize(ctx, point.ProviderID, &point.DataProviderID, "provider_id: not allowed to submit telemetry for another provider")
+			if err != nil {
+				addServerError(point, err)
+				continue
+			}
+			errs = append(errs, accessErrs...)
+			if len(errs) > 0 {
+				response.Failures = append(response.Failures, domain.FailureDetails[domain.Telemetry]{
+					Item: point,
+					ApiError: domain.ApiError{
+						Type:    domain.ApiErrorTypeBadParam,
+						Details: errs,
+					},
+				})
+				continue
+			}
+
+			// Vehicles owned by other providers are reported as unregistered so that we don't
+			// confirm the existence of another provider's vehicle.
+			_, err = repository.FetchVehicle(ctx, domain.FetchVehicleParams{
+				VehicleID:  point.DeviceID,
+				ProviderID: &point.ProviderID,
+			})
+			if err != nil && errors.Is(err, db.ErrNotFound) {
+				response.Failures = append(response.Failures, domain.FailureDetails[domain.Telemetry]{
+					Item: point,
+					ApiError: domain.ApiError{
+						Type:    domain.ApiErrorTypeUnregistered,
+						Details: []string{},
+					},
+				})
+				continue
+			}
+			if err != nil {
+				addServerError(point, fmt.Errorf("failed to fetch vehicle: %w", err))
+				continue
+			}
+
+			err = repository.InsertTelemetry(ctx, point)
+
+			if err != nil && errors.Is(err, db.ErrConflict) {
+				response.Failures = append(response.Failures, domain.FailureDetails[domain.Telemetry]{
+					Item: point,
+					ApiError: domain.ApiError{
+						Type:    domain.ApiErrorTypeAlreadyRegistered,
+						Details: []string{"Telemetry with telemetry_id is already registered"},
+					},
+				})
+				continue
+			}
+
+			if err != nil {
+				addServerError(point, fmt.Errorf("failed to insert telemetry: %w", err))
+				continue
+			}
+
+			response.Success += 1
+			observeIngestionLag(r, "telemetry", point.ProviderID, point.Timestamp)
+		}
+
+		renderBulkResponse(w, r, http.StatusCreated, nServerErrors, response)
+	})
+	return telemetryRouter
+}
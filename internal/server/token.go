@@ -0,0 +1,163 @@
+package server
+
+import (
+	"crypto"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/technopolitica/open-transit/internal/db"
+	"github.com/technopolitica/open-transit/internal/domain"
+	"github.com/technopolitica/open-transit/internal/keys"
+)
+
+// TokenIssuer signs the access tokens handed out by the token endpoint. Tokens are signed with Key,
+// so its public key must be part of the key set that requests are authenticated against.
+type TokenIssuer struct {
+	Key crypto.Signer
+	TTL time.Duration
+}
+
+func (issuer TokenIssuer) Issue(credential domain.ProviderCredential, scopes []domain.Scope) (token string, err error) {
+	alg, err := keys.SigningAlgorithm(issuer.Key.Public())
+	if err != nil {
+		return
+	}
+	now := time.Now()
+	claims := authClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   credential.ClientID,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(issuer.TTL)),
+			ID:        uuid.NewString(),
+		},
+		AuthInfo: domain.AuthInfo{
+			ProviderID: credential.ProviderID,
+			Role:       domain.RoleProvider,
+			Scope:      domain.FormatScopes(scopes),
+		},
+	}
+	return jwt.NewWithClaims(jwt.GetSigningMethod(alg), claims).SignedString(issuer.Key)
+}
+
+func renderOAuthError(w http.ResponseWriter, r *http.Request, status int, code domain.OAuthErrorCode, description string) {
+	if status == http.StatusUnauthorized {
+		w.Header().Set("WWW-Authenticate", `Basic realm="open-transit", charset="UTF-8"`)
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	render.JSON(w, r, domain.OAuthError{Code: code, Description: description})
+}
+
+// clientCredentials reads the client ID and secret from either HTTP Basic authentication or the
+// request body, as described in RFC 6749 section 2.3.1.
+func clientCredentials(r *http.Request) (clientID string, secret string, err error) {
+	basicID, basicSecret, hasBasic := r.BasicAuth()
+	formID, formSecret := r.PostForm.Get("client_id"), r.PostForm.Get("client_secret")
+	if hasBasic && (formID != "" || formSecret != "") {
+		err = fmt.Errorf("only one client authentication method may be used")
+		return
+	}
+	if !hasBasic {
+		clientID, secret = formID, formSecret
+	} else {
+		// Basic credentials are form-encoded before being base64-encoded.
+		clientID, err = url.QueryUnescape(basicID)
+		if err != nil {
+			err = fmt.Errorf("malformed client_id")
+			return
+		}
+		secret, err = url.QueryUnescape(basicSecret)
+		if err != nil {
+			err = fmt.Errorf("malformed client_secret")
+			return
+		}
+	}
+	if clientID == "" || secret == "" {
+		err = fmt.Errorf("missing client credentials")
+	}
+	return
+}
+
+// NewTokenRouter serves an OAuth 2.0 token endpoint supporting only the client credentials grant,
+// so that providers can obtain access tokens without the agency running an authorization server.
+func NewTokenRouter(issuer TokenIssuer) *chi.Mux {
+	tokenRouter := chi.NewRouter()
+	tokenRouter.Post("/", func(w http.ResponseWriter, r *http.Request) {
+		err := r.ParseForm()
+		if err != nil {
+			renderOAuthError(w, r, http.StatusBadRequest, domain.OAuthErrorCodeInvalidRequest, "request body must be form-encoded")
+			return
+		}
+		defer r.Body.Close()
+
+		grantType := r.PostForm.Get("grant_type")
+		if grantType == "" {
+			renderOAuthError(w, r, http.StatusBadRequest, domain.OAuthErrorCodeInvalidRequest, "missing required parameter grant_type")
+			return
+		}
+		if grantType != "client_credentials" {
+			renderOAuthError(w, r, http.StatusBadRequest, domain.OAuthErrorCodeUnsupportedGrantType, "only the client_credentials grant is supported")
+			return
+		}
+		clientID, secret, err := clientCredentials(r)
+		if err != nil {
+			renderOAuthError(w, r, http.StatusBadRequest, domain.OAuthErrorCodeInvalidRequest, err.Error())
+			return
+		}
+
+		ctx := r.Context()
+		repository := GetRepository(r)
+		credential, err := repository.FetchProviderCredential(ctx, clientID)
+		if err != nil && !errors.Is(err, db.ErrNotFound) {
+			GetLogger(r).Error("failed to fetch provider credential", "error", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		if err != nil || credential.IsRevoked() || !credential.CheckSecret(secret) {
+			renderOAuthError(w, r, http.StatusUnauthorized, domain.OAuthErrorCodeInvalidClient, "unknown client or invalid client secret")
+			return
+		}
+		annotateRequestLog(r, "client_id", credential.ClientID, "provider_id", credential.ProviderID)
+
+		provider, err := repository.FetchProvider(ctx, credential.ProviderID)
+		if err != nil && !errors.Is(err, db.ErrNotFound) {
+			GetLogger(r).Error("failed to fetch provider", "error", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		if err != nil || provider.Status != domain.ProviderStatusActive {
+			renderOAuthError(w, r, http.StatusBadRequest, domain.OAuthErrorCodeUnauthorizedClient, "provider is not registered or is suspended")
+			return
+		}
+
+		scopes, err := credential.GrantScopes(domain.ParseScopes(r.PostForm.Get("scope")))
+		if err != nil {
+			renderOAuthError(w, r, http.StatusBadRequest, domain.OAuthErrorCodeInvalidScope, err.Error())
+			return
+		}
+
+		token, err := issuer.Issue(credential, scopes)
+		if err != nil {
+			GetLogger(r).Error("failed to sign access token", "error", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Cache-Control", "no-store")
+		w.WriteHeader(http.StatusOK)
+		render.JSON(w, r, domain.TokenResponse{
+			AccessToken: token,
+			TokenType:   "Bearer",
+			ExpiresIn:   int(issuer.TTL.Seconds()),
+			Scope:       domain.FormatScopes(scopes),
+		})
+	})
+	return tokenRouter
+}
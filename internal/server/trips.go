@@ -0,0 +1,110 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/technopolitica/open-transit/internal/db"
+	"github.com/technopolitica/open-transit/internal/domain"
+)
+
+func NewTripsRouter() *chi.Mux {
+	tripsRouter := chi.NewRouter()
+	tripsRouter.With(requireScope(domain.ScopeTripsWrite), requireActiveProvider).Post("/", func(w http.ResponseWriter, r *http.Request) {
+		var trips []domain.Trip
+		err := render.DecodeJSON(r.Body, &trips)
+		if err != nil {
+			GetLogger(r).Info("malformed Trip payload", "error", err)
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, domain.ApiError{
+				Type:    domain.ApiErrorTypeBadParam,
+				Details: []string{"trips payload is not valid JSON"},
+			})
+			return
+		}
+		defer r.Body.Close()
+
+		ctx := r.Context()
+		repository := GetRepository(r)
+		nServerErrors := 0
+		response := domain.BulkApiResponse[domain.Trip]{
+			Total: len(trips),
+		}
+		addServerError := func(trip domain.Trip, err error) {
+			GetLogger(r).Error("failed to process trip", "trip_id", trip.TripID, "error", err)
+			response.Failures = append(response.Failures, domain.FailureDetails[domain.Trip]{
+				Item: trip,
+				ApiError: domain.ApiError{
+					Type:    domain.ApiErrorTypeUnknown,
+					Details: []string{"An unknown error has occurred"},
+				},
+			})
+			nServerErrors += 1
+		}
+		access := newWriteAccess(r)
+		for _, trip := range trips {
+			errs := domain.ValidateTrip(trip)
+			_, accessErrs, err := access.authorize(ctx, trip.ProviderID, &trip.DataProviderID, "provider_id: not allowed to submit trips for another provider")
+			if err != nil {
+				addServerError(trip, err)
+				continue
+			}
+			errs = append(errs, accessErrs...)
+			if len(errs) > 0 {
+				response.Failures = append(response.Failures, domain.FailureDetails[domain.Trip]{
+					Item: trip,
+					ApiError: domain.ApiError{
+						Type:    domain.ApiErrorTypeBadParam,
+						Details: errs,
+					},
+				})
+				continue
+			}
+
+			_, err = repository.FetchVehicle(ctx, domain.FetchVehicleParams{
+				VehicleID:  trip.DeviceID,
+				ProviderID: &trip.ProviderID,
+			})
+			if err != nil && errors.Is(err, db.ErrNotFound) {
+				response.Failures = append(response.Failures, domain.FailureDetails[domain.Trip]{
+					Item: trip,
+					ApiError: domain.ApiError{
+						Type:    domain.ApiErrorTypeUnregistered,
+						Details: []string{},
+					},
+				})
+				continue
+			}
+			if err != nil {
+				addServerError(trip, fmt.Errorf("failed to fetch vehicle: %w", err))
+				continue
+			}
+
+			err = repository.InsertTrip(ctx, trip)
+
+			if err != nil && errors.Is(err, db.ErrConflict) {
+				response.Failures = append(response.Failures, domain.FailureDetails[domain.Trip]{
+					Item: trip,
+					ApiError: domain.ApiError{
+						Type:    domain.ApiErrorTypeAlreadyRegistered,
+						Details: []string{"A trip with trip_id is already registered"},
+					},
+				})
+				continue
+			}
+
+			if err != nil {
+				addServerError(trip, fmt.Errorf("failed to insert trip: %w", err))
+				continue
+			}
+
+			response.Success += 1
+		}
+
+		renderBulkResponse(w, r, http.StatusCreated, nServerErrors, response)
+	})
+	return tripsRouter
+}
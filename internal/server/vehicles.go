@@ -1,12 +1,9 @@
 package server
 
 import (
-	"encoding/json"
 	"errors"
 	"fmt"
-	"log"
 	"net/http"
-	"net/url"
 	"strconv"
 
 	"github.com/go-chi/chi/v5"
@@ -16,14 +13,12 @@ import (
 	"github.com/technopolitica/open-transit/internal/domain"
 )
 
-const MAX_RESULTS_LIMIT = 20
-
 type ListVehiclesParams struct {
 	Limit  int
 	Offset int
 }
 
-func parseListVehiclesParams(r *http.Request) (params ListVehiclesParams, errs []string) {
+func parseListVehiclesParams(r *http.Request, maxPageSize int) (params ListVehiclesParams, errs []string) {
 	var err error
 	offset := r.URL.Query().Get("page[offset]")
 	if offset != "" {
@@ -41,22 +36,180 @@ func parseListVehiclesParams(r *http.Request) (params ListVehiclesParams, errs [
 		if err != nil || params.Limit <= 0 {
 			errs = append(errs, "page[limit]: must be a positive integer")
 		}
-		if params.Limit > MAX_RESULTS_LIMIT {
-			params.Limit = MAX_RESULTS_LIMIT
-			errs = append(errs, fmt.Sprintf("page[limit]: must be less than or equal to %d", MAX_RESULTS_LIMIT))
+		if params.Limit > maxPageSize {
+			params.Limit = maxPageSize
+			errs = append(errs, fmt.Sprintf("page[limit]: must be less than or equal to %d", maxPageSize))
 		}
 	}
 
 	return
 }
 
-func NewVehiclesRouter() *chi.Mux {
+func listVehicles(maxPageSize int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		params, errs := parseListVehiclesParams(r, maxPageSize)
+		providerID, providerErrs := readableProviderID(r)
+		errs = append(errs, providerErrs...)
+		if len(errs) > 0 {
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, domain.ApiError{
+				Type:    domain.ApiErrorTypeBadParam,
+				Details: errs,
+			})
+			return
+		}
+
+		ctx := r.Context()
+		repository := GetRepository(r)
+		page, err := repository.ListVehicles(ctx, domain.ListVehiclesParams{
+			ProviderID: providerID,
+			Limit:      int32(params.Limit),
+			Offset:     int32(params.Offset),
+		})
+		if err != nil {
+			GetLogger(r).Error("failed to execute query", "error", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		err = renderPaginatedJSON(w, http.StatusOK, domain.PaginatedVehiclesResponse{
+			PaginatedResponse: domain.PaginatedResponse{
+				Version: "2.0.0",
+				Links:   paginationLinks(r, params, page.Total),
+			},
+			Vehicles: page.Items,
+		})
+		if err != nil {
+			panic(err)
+		}
+	}
+}
+
+func listVehicleStatuses(maxPageSize int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		params, errs := parseListVehiclesParams(r, maxPageSize)
+		providerID, providerErrs := readableProviderID(r)
+		errs = append(errs, providerErrs...)
+		if len(errs) > 0 {
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, domain.ApiError{
+				Type:    domain.ApiErrorTypeBadParam,
+				Details: errs,
+			})
+			return
+		}
+
+		ctx := r.Context()
+		repository := GetRepository(r)
+		page, err := repository.ListVehicleStatuses(ctx, domain.ListVehiclesParams{
+			ProviderID: providerID,
+			Limit:      int32(params.Limit),
+			Offset:     int32(params.Offset),
+		})
+		if err != nil {
+			GetLogger(r).Error("failed to execute query", "error", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		err = renderPaginatedJSON(w, http.StatusOK, domain.PaginatedVehicleStatusesResponse{
+			PaginatedResponse: domain.PaginatedResponse{
+				Version: "2.0.0",
+				Links:   paginationLinks(r, params, page.Total),
+			},
+			VehiclesStatus: page.Items,
+		})
+		if err != nil {
+			panic(err)
+		}
+	}
+}
+
+func fetchVehicleStatus(w http.ResponseWriter, r *http.Request) {
+	vid, err := uuid.Parse(chi.URLParam(r, "vid"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		render.JSON(w, r, domain.ApiError{
+			Type:    domain.ApiErrorTypeBadParam,
+			Details: []string{"device_id: must be a valid UUID"},
+		})
+		return
+	}
+
+	providerID, errs := readableProviderID(r)
+	if len(errs) > 0 {
+		renderBadParams(w, r, errs)
+		return
+	}
+
+	ctx := r.Context()
+	repository := GetRepository(r)
+	status, err := repository.FetchVehicleStatus(ctx, domain.FetchVehicleParams{
+		VehicleID:  vid,
+		ProviderID: providerID,
+	})
+
+	if err != nil && errors.Is(err, db.ErrNotFound) {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	if err != nil {
+		GetLogger(r).Error("failed to fetch vehicle status", "error", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	render.JSON(w, r, status)
+}
+
+func fetchVehicle(w http.ResponseWriter, r *http.Request) {
+	vid, err := uuid.Parse(chi.URLParam(r, "vid"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		render.JSON(w, r, domain.ApiError{
+			Type:    domain.ApiErrorTypeBadParam,
+			Details: []string{"device_id: must be a valid UUID"},
+		})
+		return
+	}
+
+	providerID, errs := readableProviderID(r)
+	if len(errs) > 0 {
+		renderBadParams(w, r, errs)
+		return
+	}
+
+	ctx := r.Context()
+	repository := GetRepository(r)
+	vehicle, err := repository.FetchVehicle(ctx, domain.FetchVehicleParams{
+		VehicleID:  vid,
+		ProviderID: providerID,
+	})
+
+	if err != nil && errors.Is(err, db.ErrNotFound) {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	if err != nil {
+		GetLogger(r).Error("failed to fetch vehicle", "error", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	render.JSON(w, r, vehicle)
+}
+
+func NewVehiclesRouter(maxPageSize int) *chi.Mux {
 	vehiclesRouter := chi.NewRouter()
-	vehiclesRouter.Post("/", func(w http.ResponseWriter, r *http.Request) {
+	vehiclesRouter.With(requireScope(domain.ScopeVehiclesWrite), requireActiveProvider).Post("/", func(w http.ResponseWriter, r *http.Request) {
 		var vehicles []domain.Vehicle
 		err := render.DecodeJSON(r.Body, &vehicles)
 		if err != nil {
-			log.Printf("malformed Vehicle payload: %s", err)
+			GetLogger(r).Info("malformed Vehicle payload", "error", err)
 			w.WriteHeader(http.StatusBadRequest)
 			render.JSON(w, r, domain.ApiError{
 				Type:    domain.ApiErrorTypeBadParam,
@@ -72,11 +225,28 @@ func NewVehiclesRouter() *chi.Mux {
 		response := domain.BulkApiResponse[domain.Vehicle]{
 			Total: len(vehicles),
 		}
-		auth := GetAuthInfo(r)
+		addServerError := func(vehicle domain.Vehicle, err error) {
+			GetLogger(r).Error("failed to process vehicle", "device_id", vehicle.DeviceID, "error", err)
+			response.Failures = append(response.Failures, domain.FailureDetails[domain.Vehicle]{
+				Item: vehicle,
+				ApiError: domain.ApiError{
+					Type:    domain.ApiErrorTypeUnknown,
+					Details: []string{"An unknown error has occurred"},
+				},
+			})
+			nServerErrors += 1
+		}
+		access := newWriteAccess(r)
 		for _, vehicle := range vehicles {
 			errs := domain.ValidateVehicle(vehicle)
-			if vehicle.ProviderID != auth.ProviderID {
-				errs = append(errs, "provider_id: not allowed to register vehicle for another provider")
+			provider, accessErrs, err := access.authorize(ctx, vehicle.ProviderID, &vehicle.DataProviderID, "provider_id: not allowed to register vehicle for another provider")
+			if err != nil {
+				addServerError(vehicle, err)
+				continue
+			}
+			errs = append(errs, accessErrs...)
+			if len(accessErrs) == 0 && !provider.AllowsVehicleType(vehicle.VehicleType) {
+				errs = append(errs, fmt.Sprintf("vehicle_type: provider is not allowed to operate %s vehicles", vehicle.VehicleType))
 			}
 			if len(errs) > 0 {
 				response.Failures = append(response.Failures, domain.FailureDetails[domain.Vehicle]{
@@ -88,7 +258,7 @@ func NewVehiclesRouter() *chi.Mux {
 				})
 				continue
 			}
-			err := repository.InsertVehicle(ctx, vehicle)
+			err = repository.InsertVehicle(ctx, vehicle)
 
 			if err != nil && errors.Is(err, db.ErrConflict) {
 				response.Failures = append(response.Failures, domain.FailureDetails[domain.Vehicle]{
@@ -102,37 +272,20 @@ func NewVehiclesRouter() *chi.Mux {
 			}
 
 			if err != nil {
-				log.Printf("failed to insert vehicle: %s", err)
-				response.Failures = append(response.Failures, domain.FailureDetails[domain.Vehicle]{
-					Item: vehicle,
-					ApiError: domain.ApiError{
-						Type:    domain.ApiErrorTypeUnknown,
-						Details: []string{"An unknown error has occurred"},
-					},
-				})
-				nServerErrors += 1
+				addServerError(vehicle, fmt.Errorf("failed to insert vehicle: %w", err))
 				continue
 			}
 
 			response.Success += 1
 		}
 
-		httpStatus := http.StatusCreated
-		// If all of the inserts failed and all of the errors were classified as server errors,
-		// return a http.StatusInternalServerError Internal Server Error response to notify the client.
-		if nServerErrors == response.Total {
-			httpStatus = http.StatusInternalServerError
-		} else if response.Success == 0 { // Otherwise if no inserts were successful at least some of them were bad requests
-			httpStatus = http.StatusBadRequest
-		}
-		w.WriteHeader(httpStatus)
-		render.JSON(w, r, response)
+		renderBulkResponse(w, r, http.StatusCreated, nServerErrors, response)
 	})
-	vehiclesRouter.Put("/", func(w http.ResponseWriter, r *http.Request) {
+	vehiclesRouter.With(requireScope(domain.ScopeVehiclesWrite), requireActiveProvider).Put("/", func(w http.ResponseWriter, r *http.Request) {
 		var vehicles []domain.Vehicle
 		err := render.DecodeJSON(r.Body, &vehicles)
 		if err != nil {
-			log.Printf("malformed Vehicle payload: %s", err)
+			GetLogger(r).Info("malformed Vehicle payload", "error", err)
 			w.WriteHeader(http.StatusBadRequest)
 			render.JSON(w, r, domain.ApiError{
 				Type:    domain.ApiErrorTypeBadParam,
@@ -148,9 +301,28 @@ func NewVehiclesRouter() *chi.Mux {
 		response := domain.BulkApiResponse[domain.Vehicle]{
 			Total: len(vehicles),
 		}
-		auth := GetAuthInfo(r)
+		addServerError := func(vehicle domain.Vehicle, err error) {
+			GetLogger(r).Error("failed to process vehicle", "device_id", vehicle.DeviceID, "error", err)
+			response.Failures = append(response.Failures, domain.FailureDetails[domain.Vehicle]{
+				Item: vehicle,
+				ApiError: domain.ApiError{
+					Type:    domain.ApiErrorTypeUnknown,
+					Details: []string{"An unknown error has occurred"},
+				},
+			})
+			nServerErrors += 1
+		}
+		access := newWriteAccess(r)
 		for _, vehicle := range vehicles {
 			errs := domain.ValidateVehicle(vehicle)
+			provider, accessErrs, err := access.authorize(ctx, vehicle.ProviderID, &vehicle.DataProviderID, "provider_id: does not match user's provider ID")
+			if err != nil {
+				addServerError(vehicle, err)
+				continue
+			}
+			if len(accessErrs) == 0 && !provider.AllowsVehicleType(vehicle.VehicleType) {
+				errs = append(errs, fmt.Sprintf("vehicle_type: provider is not allowed to operate %s vehicles", vehicle.VehicleType))
+			}
 			if len(errs) > 0 {
 				response.Failures = append(response.Failures, domain.FailureDetails[domain.Vehicle]{
 					Item: vehicle,
@@ -161,18 +333,18 @@ func NewVehiclesRouter() *chi.Mux {
 				})
 				continue
 			}
-			if vehicle.ProviderID != auth.ProviderID {
+			if len(accessErrs) > 0 {
 				response.Failures = append(response.Failures, domain.FailureDetails[domain.Vehicle]{
 					Item: vehicle,
 					ApiError: domain.ApiError{
 						Type:    domain.ApiErrorTypeBadParam,
-						Details: []string{"provider_id: does not match user's provider ID"},
+						Details: accessErrs,
 					},
 				})
 				continue
 			}
 
-			err := repository.UpdateVehicle(ctx, vehicle)
+			err = repository.UpdateVehicle(ctx, vehicle)
 
 			if err != nil && errors.Is(err, db.ErrNotFound) {
 				response.Failures = append(response.Failures, domain.FailureDetails[domain.Vehicle]{
@@ -186,134 +358,18 @@ func NewVehiclesRouter() *chi.Mux {
 			}
 
 			if err != nil {
-				log.Printf("failed to update vehicle: %s", err)
-				response.Failures = append(response.Failures, domain.FailureDetails[domain.Vehicle]{
-					Item: vehicle,
-					ApiError: domain.ApiError{
-						Type:    domain.ApiErrorTypeUnknown,
-						Details: []string{"An unknown error has occurred"},
-					},
-				})
-				nServerErrors += 1
+				addServerError(vehicle, fmt.Errorf("failed to update vehicle: %w", err))
 				continue
 			}
 
 			response.Success += 1
 		}
 
-		httpStatus := http.StatusOK
-		// If all of the inserts failed and all of the errors were classified as server errors,
-		// return a http.StatusInternalServerError Internal Server Error response to notify the client.
-		if nServerErrors == response.Total {
-			httpStatus = http.StatusInternalServerError
-		} else if response.Success == 0 { // Otherwise if no inserts were successful at least some of them were bad requests
-			httpStatus = http.StatusBadRequest
-		}
-		w.WriteHeader(httpStatus)
-		render.JSON(w, r, response)
-	})
-	vehiclesRouter.Get("/", func(w http.ResponseWriter, r *http.Request) {
-		params, errs := parseListVehiclesParams(r)
-		if len(errs) > 0 {
-			w.WriteHeader(http.StatusBadRequest)
-			render.JSON(w, r, domain.ApiError{
-				Type:    domain.ApiErrorTypeBadParam,
-				Details: errs,
-			})
-			return
-		}
-
-		ctx := r.Context()
-		repository := GetRepository(r)
-		auth := GetAuthInfo(r)
-		page, err := repository.ListVehicles(ctx, domain.ListVehiclesParams{
-			ProviderID: auth.ProviderID,
-			Limit:      int32(params.Limit),
-			Offset:     int32(params.Offset),
-		})
-		if err != nil {
-			log.Printf("failed execute query: %s", err)
-			w.WriteHeader(http.StatusInternalServerError)
-			return
-		}
-
-		baseURL := domain.URL{URL: r.URL}
-		first := baseURL.ModifyQuery(func(query *url.Values) {
-			query.Set("page[offset]", "0")
-		})
-		lastOffset := (int(page.Total) / params.Limit) * params.Limit
-		last := baseURL.ModifyQuery(func(query *url.Values) {
-			query.Set("page[offset]", fmt.Sprint(lastOffset))
-		})
-		prevOffset := params.Offset - params.Limit
-		// If we get a nonsensical offset that's greater than the last offset, we'll point
-		// the prev link to the last offset.
-		if prevOffset > lastOffset {
-			prevOffset = lastOffset
-		}
-		hasPrev := prevOffset >= 0
-		var prev domain.URL
-		if hasPrev {
-			prev = baseURL.ModifyQuery(func(query *url.Values) {
-				query.Set("page[offset]", fmt.Sprint(prevOffset))
-			})
-		}
-		nextOffset := params.Offset + params.Limit
-		hasNext := nextOffset <= lastOffset
-		var next domain.URL
-		if hasNext {
-			next = baseURL.ModifyQuery(func(query *url.Values) {
-				query.Set("page[offset]", fmt.Sprint(nextOffset))
-			})
-		}
-
-		w.WriteHeader(http.StatusOK)
-		// FIXME: we can't use render.JSON here because the default json.Marshal implementation
-		// escapes HTML characters by default (including the ampersand '&'), which breaks
-		// the rendering of URLs...
-		w.Header().Set("Content-Type", "application/json")
-		encoder := json.NewEncoder(w)
-		encoder.SetEscapeHTML(false)
-		err = encoder.Encode(domain.PaginatedVehiclesResponse{
-			PaginatedResponse: domain.PaginatedResponse{
-				Version: "2.0.0",
-				Links: domain.PaginationLinks{
-					First: first.String(),
-					Last:  last.String(),
-					Prev:  prev.String(),
-					Next:  next.String(),
-				},
-			},
-			Vehicles: page.Items,
-		})
-		if err != nil {
-			panic(err)
-		}
-	})
-	vehiclesRouter.Get("/{vid:.+}", func(w http.ResponseWriter, r *http.Request) {
-		vid := uuid.MustParse(chi.URLParam(r, "vid"))
-
-		ctx := r.Context()
-		repository := GetRepository(r)
-		auth := GetAuthInfo(r)
-		vehicle, err := repository.FetchVehicle(ctx, domain.FetchVehicleParams{
-			VehicleID:  vid,
-			ProviderID: auth.ProviderID,
-		})
-
-		if err != nil && errors.Is(err, db.ErrNotFound) {
-			w.WriteHeader(http.StatusNotFound)
-			return
-		}
-
-		if err != nil {
-			log.Printf("failed to fetch vehicle: %s", err)
-			w.WriteHeader(http.StatusInternalServerError)
-			return
-		}
-
-		w.WriteHeader(http.StatusOK)
-		render.JSON(w, r, vehicle)
+		renderBulkResponse(w, r, http.StatusOK, nServerErrors, response)
 	})
+	vehiclesRouter.With(requireScope(domain.ScopeVehiclesRead)).Get("/", listVehicles(maxPageSize))
+	vehiclesRouter.With(requireScope(domain.ScopeVehiclesRead)).Get("/status", listVehicleStatuses(maxPageSize))
+	vehiclesRouter.With(requireScope(domain.ScopeVehiclesRead)).Get("/status/{vid}", fetchVehicleStatus)
+	vehiclesRouter.With(requireScope(domain.ScopeVehiclesRead)).Get("/{vid:.+}", fetchVehicle)
 	return vehiclesRouter
 }
@@ -0,0 +1,160 @@
+package acceptance
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	"github.com/technopolitica/open-transit/internal/domain"
+	. "github.com/technopolitica/open-transit/test/acceptance/matchers"
+	"github.com/technopolitica/open-transit/test/acceptance/testutils"
+)
+
+var _ = Describe("data providers", func() {
+	var providerID uuid.UUID
+	var dataProviderID uuid.UUID
+	BeforeEach(func() {
+		providerID = testutils.GenerateRandomUUID()
+		dataProviderID = testutils.MakeUUIDExcluding(providerID)
+		provider := testutils.MakeValidProvider(providerID)
+		provider.DataProviders = []uuid.UUID{dataProviderID}
+		apiClient.AuthenticateAsAdmin()
+		Expect(apiClient.CreateProvider(provider)).To(HaveHTTPStatus(http.StatusCreated))
+	})
+
+	Context("authenticated as a data provider the provider has delegated to", func() {
+		BeforeEach(func() {
+			apiClient.AuthenticateAsDataProvider(dataProviderID)
+		})
+
+		It("can submit vehicles, events, telemetry and trips on behalf of the provider", func() {
+			vehicle := testutils.MakeValidVehicle(providerID)
+			success := HaveHTTPBody(MatchJSONObject(MatchKeys(IgnoreExtras, Keys{
+				"success":  Equal(float64(1)),
+				"failures": BeEmpty(),
+			})))
+			Expect(apiClient.RegisterVehicles([]any{vehicle})).To(SatisfyAll(HaveHTTPStatus(http.StatusCreated), success))
+			Expect(apiClient.UpdateVehicles([]any{vehicle})).To(SatisfyAll(HaveHTTPStatus(http.StatusOK), success))
+			event := testutils.MakeValidEvent(vehicle, domain.VehicleStateAvailable, domain.EventTypeProviderDropOff)
+			Expect(apiClient.SubmitEvents([]any{event})).To(SatisfyAll(HaveHTTPStatus(http.StatusCreated), success))
+			Expect(apiClient.SubmitTelemetry([]any{testutils.MakeValidTelemetry(vehicle)})).To(SatisfyAll(HaveHTTPStatus(http.StatusCreated), success))
+			Expect(apiClient.SubmitTrips([]any{testutils.MakeValidTrip(vehicle)})).To(SatisfyAll(HaveHTTPStatus(http.StatusCreated), success))
+
+			apiClient.AuthenticateAsProvider(providerID)
+			Expect(apiClient.GetVehicle(vehicle.DeviceID.String())).To(HaveHTTPBody(MatchJSONObject(MatchKeys(IgnoreExtras, Keys{
+				"data_provider_id": Equal(dataProviderID.String()),
+			}))))
+		})
+
+		It("cannot attribute submissions to another data provider", func() {
+			vehicle := testutils.MakeValidVehicle(providerID)
+			vehicle.DataProviderID = testutils.MakeUUIDExcluding(providerID, dataProviderID)
+			Expect(apiClient.RegisterVehicles([]any{vehicle})).To(HaveHTTPBody(MatchJSONObject(MatchKeys(IgnoreExtras, Keys{
+				"success": Equal(float64(0)),
+				"failures": ConsistOf(MatchKeys(IgnoreExtras, Keys{
+					"error_details": ConsistOf("data_provider_id: does not match user's data provider ID"),
+				})),
+			}))))
+		})
+
+		It("is not allowed to read the provider's data", func() {
+			Expect(apiClient.ListVehicles(testutils.ListVehiclesOptions{Limit: 10})).To(HaveHTTPStatus(http.StatusForbidden))
+		})
+
+		It("is not allowed to submit reports", func() {
+			Expect(apiClient.SubmitReports([]any{testutils.MakeValidReport(providerID)})).To(HaveHTTPStatus(http.StatusForbidden))
+		})
+	})
+
+	When("the provider has been suspended", func() {
+		BeforeEach(func() {
+			provider := testutils.MakeValidProvider(providerID)
+			provider.DataProviders = []uuid.UUID{dataProviderID}
+			provider.Status = domain.ProviderStatusSuspended
+			Expect(apiClient.UpdateProvider(providerID.String(), provider)).To(HaveHTTPStatus(http.StatusOK))
+			apiClient.AuthenticateAsDataProvider(dataProviderID)
+		})
+
+		It("rejects submissions from its data providers", func() {
+			Expect(apiClient.RegisterVehicles([]any{testutils.MakeValidVehicle(providerID)})).To(HaveHTTPBody(MatchJSONObject(MatchKeys(IgnoreExtras, Keys{
+				"success": Equal(float64(0)),
+				"failures": ConsistOf(MatchKeys(IgnoreExtras, Keys{
+					"error_details": ConsistOf("provider_id: provider is suspended"),
+				})),
+			}))))
+		})
+	})
+
+	When("the data provider isn't registered", func() {
+		BeforeEach(func() {
+			apiClient.AuthenticateAsDataProviderWithoutRegistering(dataProviderID)
+		})
+
+		It("rejects its submissions", func() {
+			Expect(apiClient.RegisterVehicles([]any{testutils.MakeValidVehicle(providerID)})).To(SatisfyAll(
+				HaveHTTPStatus(http.StatusForbidden),
+				HaveHTTPBody(MatchJSONObject(MatchKeys(IgnoreExtras, Keys{
+					"error_details": ConsistOf("provider_id: data provider is not registered"),
+				}))),
+			))
+		})
+	})
+
+	When("the data provider has been suspended", func() {
+		BeforeEach(func() {
+			apiClient.AuthenticateAsDataProvider(dataProviderID)
+			dataProvider := testutils.MakeValidProvider(dataProviderID)
+			dataProvider.Status = domain.ProviderStatusSuspended
+			apiClient.AuthenticateAsAdmin()
+			Expect(apiClient.UpdateProvider(dataProviderID.String(), dataProvider)).To(HaveHTTPStatus(http.StatusOK))
+			apiClient.AuthenticateAsDataProviderWithoutRegistering(dataProviderID)
+		})
+
+		It("rejects its submissions", func() {
+			vehicle := testutils.MakeValidVehicle(providerID)
+			Expect(apiClient.RegisterVehicles([]any{vehicle})).To(HaveHTTPStatus(http.StatusForbidden))
+			Expect(apiClient.SubmitEvents([]any{testutils.MakeValidEvent(vehicle, domain.VehicleStateAvailable, domain.EventTypeProviderDropOff)})).To(SatisfyAll(
+				HaveHTTPStatus(http.StatusForbidden),
+				HaveHTTPBody(MatchJSONObject(MatchKeys(IgnoreExtras, Keys{
+					"error_details": ConsistOf("provider_id: data provider is suspended"),
+				}))),
+			))
+		})
+	})
+
+	Context("authenticated as a data provider the provider has not delegated to", func() {
+		BeforeEach(func() {
+			apiClient.AuthenticateAsDataProvider(testutils.MakeUUIDExcluding(providerID, dataProviderID))
+		})
+
+		It("rejects submissions on behalf of the provider", func() {
+			vehicle := testutils.MakeValidVehicle(providerID)
+			Expect(apiClient.RegisterVehicles([]any{vehicle})).To(SatisfyAll(
+				HaveHTTPStatus(http.StatusBadRequest),
+				HaveHTTPBody(MatchJSONObject(MatchKeys(IgnoreExtras, Keys{
+					"success": Equal(float64(0)),
+					"failures": ConsistOf(MatchKeys(IgnoreExtras, Keys{
+						"error":         Equal("bad_param"),
+						"error_details": ConsistOf("provider_id: not authorized to submit data on behalf of this provider"),
+					})),
+				}))),
+			))
+			Expect(apiClient.SubmitTelemetry([]any{testutils.MakeValidTelemetry(vehicle)})).To(HaveHTTPBody(MatchJSONObject(MatchKeys(IgnoreExtras, Keys{
+				"failures": ConsistOf(MatchKeys(IgnoreExtras, Keys{
+					"error_details": ConsistOf("provider_id: not authorized to submit data on behalf of this provider"),
+				})),
+			}))))
+		})
+
+		It("rejects submissions on behalf of unregistered providers the same way", func() {
+			vehicle := testutils.MakeValidVehicle(testutils.MakeUUIDExcluding(providerID, dataProviderID))
+			Expect(apiClient.RegisterVehicles([]any{vehicle})).To(HaveHTTPBody(MatchJSONObject(MatchKeys(IgnoreExtras, Keys{
+				"failures": ConsistOf(MatchKeys(IgnoreExtras, Keys{
+					"error_details": ConsistOf("provider_id: not authorized to submit data on behalf of this provider"),
+				})),
+			}))))
+		})
+	})
+})
@@ -44,6 +44,7 @@ func TestE2E(t *testing.T) {
 	RunSpecs(t, "Acceptance Tests", decorators.Label("integration"))
 }
 
+var apiServer testutils.APIServer
 var apiClient *testutils.TestClient
 var dbClient *testutils.DBClient
 var testDBName string
@@ -104,7 +105,7 @@ var _ = SynchronizedBeforeSuite(func(ctx context.Context) {
 		return dbClient.CleanupTestDB(ctx, testDBName)
 	})
 
-	apiServer, err := testutils.StartAPIServer(ctx, serverBinaryPath, testDB.ConnectionString)
+	apiServer, err = testutils.StartAPIServer(ctx, serverBinaryPath, testDB.ConnectionString)
 	Expect(err).NotTo(HaveOccurred(), "failed to start server")
 	DeferCleanup(apiServer.Terminate)
 
@@ -0,0 +1,224 @@
+package acceptance
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	"github.com/technopolitica/open-transit/internal/domain"
+	. "github.com/technopolitica/open-transit/test/acceptance/matchers"
+	"github.com/technopolitica/open-transit/test/acceptance/testutils"
+)
+
+var _ = Describe("/events", func() {
+	Context("unauthenticated", func() {
+		When("user attempts to submit a valid event", func() {
+			var validEvent *domain.Event
+			BeforeEach(func() {
+				providerID := testutils.GenerateRandomUUID()
+				vehicle := testutils.MakeValidVehicle(providerID)
+				validEvent = testutils.MakeValidEvent(vehicle, domain.VehicleStateAvailable, domain.EventTypeProviderDropOff)
+			})
+
+			AssertHasStandardUnauthorizedResponse(func() *http.Response {
+				return apiClient.SubmitEvents([]any{validEvent})
+			})
+		})
+	})
+
+	Context("authenticated as provider", func() {
+		var providerID uuid.UUID
+		var vehicle *domain.Vehicle
+		BeforeEach(OncePerOrdered, func() {
+			providerID = testutils.GenerateRandomUUID()
+			apiClient.AuthenticateAsProvider(providerID)
+			vehicle = testutils.MakeValidVehicle(providerID)
+			Expect(apiClient.RegisterVehicles([]any{vehicle})).To(HaveHTTPStatus(http.StatusCreated))
+		})
+
+		When("provider submits a valid event for a vehicle they own", func() {
+			var validEvent *domain.Event
+			BeforeEach(func() {
+				validEvent = testutils.MakeValidEvent(vehicle, domain.VehicleStateAvailable, domain.EventTypeProviderDropOff)
+			})
+
+			It("returns HTTP 201 Created status", func() {
+				Expect(apiClient.SubmitEvents([]any{validEvent})).To(HaveHTTPStatus(http.StatusCreated))
+			})
+
+			It("returns a bulk success response", func() {
+				Expect(apiClient.SubmitEvents([]any{validEvent})).To(HaveHTTPBody(MatchJSONObject(MatchKeys(IgnoreExtras, Keys{
+					"success":  Equal(float64(1)),
+					"total":    Equal(float64(1)),
+					"failures": BeEmpty(),
+				}))))
+			})
+		})
+
+		When("provider submits an empty array of events", func() {
+			It("returns HTTP 400 Bad Request w/ bad_param error", func() {
+				Expect(apiClient.SubmitEvents([]any{})).To(And(
+					HaveHTTPStatus(http.StatusBadRequest),
+					HaveHTTPBody(MatchJSONObject(MatchKeys(IgnoreExtras, Keys{
+						"error":         Equal("bad_param"),
+						"error_details": ConsistOf("payload: must contain at least one item"),
+					}))),
+				))
+			})
+		})
+
+		When("provider submits the same event twice", func() {
+			var validEvent *domain.Event
+			BeforeEach(func() {
+				validEvent = testutils.MakeValidEvent(vehicle, domain.VehicleStateAvailable, domain.EventTypeProviderDropOff)
+				Expect(apiClient.SubmitEvents([]any{validEvent})).To(HaveHTTPStatus(http.StatusCreated))
+			})
+
+			It("returns a bulk error response w/ already_registered failure", func() {
+				Expect(apiClient.SubmitEvents([]any{validEvent})).To(HaveHTTPBody(MatchJSONObject(MatchKeys(IgnoreExtras, Keys{
+					"success": Equal(float64(0)),
+					"total":   Equal(float64(1)),
+					"failures": ConsistOf(MatchKeys(IgnoreExtras, Keys{
+						"error":         Equal("already_registered"),
+						"error_details": ConsistOf("An event with event_id is already registered"),
+						"item":          MatchJSONObject(validEvent),
+					})),
+				}))))
+			})
+		})
+
+		When("provider submits the same trip_start event twice", func() {
+			var tripStart *domain.Event
+			BeforeEach(func() {
+				dropOff := testutils.MakeValidEvent(vehicle, domain.VehicleStateAvailable, domain.EventTypeProviderDropOff)
+				tripStart = testutils.MakeValidEvent(vehicle, domain.VehicleStateOnTrip, domain.EventTypeTripStart)
+				tripStart.Timestamp = domain.NewTimestamp(dropOff.Timestamp.Add(time.Minute))
+				tripStart.TripIDs = []uuid.UUID{testutils.GenerateRandomUUID()}
+				Expect(apiClient.SubmitEvents([]any{dropOff, tripStart})).To(HaveHTTPStatus(http.StatusCreated))
+			})
+
+			It("returns a bulk error response w/ already_registered failure", func() {
+				Expect(apiClient.SubmitEvents([]any{tripStart})).To(HaveHTTPBody(MatchJSONObject(MatchKeys(IgnoreExtras, Keys{
+					"success": Equal(float64(0)),
+					"failures": ConsistOf(MatchKeys(IgnoreExtras, Keys{
+						"error": Equal("already_registered"),
+					})),
+				}))))
+			})
+		})
+
+		When("provider submits an event that is not a valid transition from the vehicle's current state", func() {
+			var invalidEvent *domain.Event
+			BeforeEach(func() {
+				invalidEvent = testutils.MakeValidEvent(vehicle, domain.VehicleStateOnTrip, domain.EventTypeTripStart)
+				invalidEvent.TripIDs = []uuid.UUID{testutils.GenerateRandomUUID()}
+			})
+
+			It("returns HTTP 400 Bad Request status", func() {
+				Expect(apiClient.SubmitEvents([]any{invalidEvent})).To(HaveHTTPStatus(http.StatusBadRequest))
+			})
+
+			It("returns a bulk error response w/ bad_param failure", func() {
+				Expect(apiClient.SubmitEvents([]any{invalidEvent})).To(HaveHTTPBody(MatchJSONObject(MatchKeys(IgnoreExtras, Keys{
+					"success": Equal(float64(0)),
+					"total":   Equal(float64(1)),
+					"failures": ConsistOf(MatchKeys(IgnoreExtras, Keys{
+						"error":         Equal("bad_param"),
+						"error_details": ConsistOf("vehicle_state: invalid transition from removed to on_trip via event_types [trip_start]"),
+						"item":          MatchJSONObject(invalidEvent),
+					})),
+				}))))
+			})
+		})
+
+		When("provider submits a sequence of events that follow the state machine", func() {
+			It("accepts every event", func() {
+				dropOff := testutils.MakeValidEvent(vehicle, domain.VehicleStateAvailable, domain.EventTypeProviderDropOff)
+				tripStart := testutils.MakeValidEvent(vehicle, domain.VehicleStateOnTrip, domain.EventTypeTripStart)
+				tripStart.Timestamp = domain.NewTimestamp(dropOff.Timestamp.Add(time.Minute))
+				tripStart.TripIDs = []uuid.UUID{testutils.GenerateRandomUUID()}
+				tripEnd := testutils.MakeValidEvent(vehicle, domain.VehicleStateAvailable, domain.EventTypeTripEnd)
+				tripEnd.Timestamp = domain.NewTimestamp(tripStart.Timestamp.Add(time.Minute))
+				tripEnd.TripIDs = tripStart.TripIDs
+
+				Expect(apiClient.SubmitEvents([]any{dropOff, tripStart, tripEnd})).To(HaveHTTPBody(MatchJSONObject(MatchKeys(IgnoreExtras, Keys{
+					"success":  Equal(float64(3)),
+					"total":    Equal(float64(3)),
+					"failures": BeEmpty(),
+				}))))
+			})
+		})
+
+		When("provider submits an event that occurred before the vehicle's latest event", func() {
+			It("validates the event against the state the vehicle was in at the time", func() {
+				dropOff := testutils.MakeValidEvent(vehicle, domain.VehicleStateAvailable, domain.EventTypeProviderDropOff)
+				tripStart := testutils.MakeValidEvent(vehicle, domain.VehicleStateOnTrip, domain.EventTypeTripStart)
+				tripStart.Timestamp = domain.NewTimestamp(dropOff.Timestamp.Add(2 * time.Minute))
+				tripStart.TripIDs = []uuid.UUID{testutils.GenerateRandomUUID()}
+				Expect(apiClient.SubmitEvents([]any{dropOff, tripStart})).To(HaveHTTPStatus(http.StatusCreated))
+
+				// Only valid while the vehicle was available, not once its trip had started.
+				batteryCharged := testutils.MakeValidEvent(vehicle, domain.VehicleStateAvailable, domain.EventTypeBatteryCharged)
+				batteryCharged.Timestamp = domain.NewTimestamp(dropOff.Timestamp.Add(time.Minute))
+				Expect(apiClient.SubmitEvents([]any{batteryCharged})).To(HaveHTTPBody(MatchJSONObject(MatchKeys(IgnoreExtras, Keys{
+					"success":  Equal(float64(1)),
+					"failures": BeEmpty(),
+				}))))
+			})
+		})
+
+		When("provider submits an event that the vehicle's next event can't follow", func() {
+			It("returns a bulk error response w/ bad_param failure", func() {
+				dropOff := testutils.MakeValidEvent(vehicle, domain.VehicleStateAvailable, domain.EventTypeProviderDropOff)
+				tripStart := testutils.MakeValidEvent(vehicle, domain.VehicleStateOnTrip, domain.EventTypeTripStart)
+				tripStart.Timestamp = domain.NewTimestamp(dropOff.Timestamp.Add(2 * time.Minute))
+				tripStart.TripIDs = []uuid.UUID{testutils.GenerateRandomUUID()}
+				Expect(apiClient.SubmitEvents([]any{dropOff, tripStart})).To(HaveHTTPStatus(http.StatusCreated))
+
+				batteryLow := testutils.MakeValidEvent(vehicle, domain.VehicleStateNonOperational, domain.EventTypeBatteryLow)
+				batteryLow.Timestamp = domain.NewTimestamp(dropOff.Timestamp.Add(time.Minute))
+				Expect(apiClient.SubmitEvents([]any{batteryLow})).To(HaveHTTPBody(MatchJSONObject(MatchKeys(IgnoreExtras, Keys{
+					"success": Equal(float64(0)),
+					"failures": ConsistOf(MatchKeys(IgnoreExtras, Keys{
+						"error":         Equal("bad_param"),
+						"error_details": ConsistOf(ContainSubstring("the vehicle's next event")),
+					})),
+				}))))
+			})
+		})
+
+		When("provider submits an event for an unregistered vehicle", func() {
+			It("returns a bulk error response w/ unregistered failure", func() {
+				unregisteredVehicle := testutils.MakeValidVehicle(providerID)
+				event := testutils.MakeValidEvent(unregisteredVehicle, domain.VehicleStateAvailable, domain.EventTypeProviderDropOff)
+				Expect(apiClient.SubmitEvents([]any{event})).To(HaveHTTPBody(MatchJSONObject(MatchKeys(IgnoreExtras, Keys{
+					"success": Equal(float64(0)),
+					"total":   Equal(float64(1)),
+					"failures": ConsistOf(MatchKeys(IgnoreExtras, Keys{
+						"error": Equal("unregistered"),
+						"item":  MatchJSONObject(event),
+					})),
+				}))))
+			})
+		})
+
+		When("provider submits an event for a vehicle owned by another provider", func() {
+			It("returns a bulk error response w/ bad_param failure", func() {
+				otherProvidersID := testutils.MakeUUIDExcluding(providerID)
+				otherProvidersVehicle := testutils.MakeValidVehicle(otherProvidersID)
+				event := testutils.MakeValidEvent(otherProvidersVehicle, domain.VehicleStateAvailable, domain.EventTypeProviderDropOff)
+				Expect(apiClient.SubmitEvents([]any{event})).To(HaveHTTPBody(MatchJSONObject(MatchKeys(IgnoreExtras, Keys{
+					"success": Equal(float64(0)),
+					"total":   Equal(float64(1)),
+					"failures": ConsistOf(MatchKeys(IgnoreExtras, Keys{
+						"error":         Equal("bad_param"),
+						"error_details": ConsistOf("provider_id: not allowed to submit events for another provider"),
+					})),
+				}))))
+			})
+		})
+	})
+})
@@ -0,0 +1,112 @@
+package acceptance
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	. "github.com/technopolitica/open-transit/test/acceptance/matchers"
+	"github.com/technopolitica/open-transit/test/acceptance/testutils"
+)
+
+var _ = Describe("/geographies", func() {
+	Context("unauthenticated", func() {
+		When("user attempts to list geographies", func() {
+			AssertHasStandardUnauthorizedResponse(func() *http.Response {
+				return apiClient.ListGeographies()
+			})
+		})
+	})
+
+	Context("authenticated as provider", func() {
+		BeforeEach(func() {
+			apiClient.AuthenticateAsProvider(testutils.GenerateRandomUUID())
+		})
+
+		It("is not allowed to create geographies", func() {
+			Expect(apiClient.CreateGeography(testutils.MakeValidGeography())).To(HaveHTTPStatus(http.StatusForbidden))
+		})
+
+		It("returns HTTP 404 Not Found for unknown geographies", func() {
+			Expect(apiClient.GetGeography(testutils.GenerateRandomUUID().String())).To(HaveHTTPStatus(http.StatusNotFound))
+		})
+	})
+
+	Context("authenticated as agency", func() {
+		BeforeEach(func() {
+			apiClient.AuthenticateAsAgency()
+		})
+
+		When("agency creates a valid geography", func() {
+			It("publishes the geography", func() {
+				geography := testutils.MakeValidGeography()
+				Expect(apiClient.CreateGeography(geography)).To(SatisfyAll(
+					HaveHTTPStatus(http.StatusCreated),
+					HaveHTTPBody(MatchJSONObject(MatchKeys(IgnoreExtras, Keys{
+						"geography": MatchKeys(IgnoreExtras, Keys{
+							"geography_id":   Equal(geography.GeographyID.String()),
+							"published_date": BeNumerically(">", 0),
+						}),
+					}))),
+				))
+
+				apiClient.AuthenticateAsProvider(testutils.GenerateRandomUUID())
+				Expect(apiClient.GetGeography(geography.GeographyID.String())).To(SatisfyAll(
+					HaveHTTPStatus(http.StatusOK),
+					HaveHTTPBody(MatchJSONObject(MatchAllKeys(Keys{
+						"version": Equal("2.0.0"),
+						"geography": MatchKeys(IgnoreExtras, Keys{
+							"geography_id":   Equal(geography.GeographyID.String()),
+							"name":           Equal(geography.Name),
+							"geography_type": Equal(geography.GeographyType),
+							"geography_json": Equal(JSONValue(geography.GeographyJSON)),
+						}),
+					}))),
+				))
+				Expect(apiClient.ListGeographies()).To(HaveHTTPBody(MatchJSONObject(MatchKeys(IgnoreExtras, Keys{
+					"geographies": ContainElement(MatchKeys(IgnoreExtras, Keys{
+						"geography_id": Equal(geography.GeographyID.String()),
+					})),
+				}))))
+			})
+		})
+
+		When("agency creates a geography w/ invalid GeoJSON", func() {
+			It("returns HTTP 400 Bad Request w/ the validation errors", func() {
+				geography := testutils.MakeValidGeography()
+				geography.GeographyJSON.Features[0].Geometry.Type = "Circle"
+				Expect(apiClient.CreateGeography(geography)).To(SatisfyAll(
+					HaveHTTPStatus(http.StatusBadRequest),
+					HaveHTTPBody(MatchJSONObject(MatchKeys(IgnoreExtras, Keys{
+						"error":         Equal("bad_param"),
+						"error_details": ConsistOf(`geography_json.features[0].geometry.type: unsupported geometry type "Circle"`),
+					}))),
+				))
+			})
+		})
+
+		When("agency creates a geography that replaces an unknown geography", func() {
+			It("returns HTTP 400 Bad Request", func() {
+				geography := testutils.MakeValidGeography()
+				unknownID := testutils.GenerateRandomUUID()
+				geography.PrevGeographies = []uuid.UUID{unknownID}
+				Expect(apiClient.CreateGeography(geography)).To(SatisfyAll(
+					HaveHTTPStatus(http.StatusBadRequest),
+					HaveHTTPBody(MatchJSONObject(MatchKeys(IgnoreExtras, Keys{
+						"error_details": ConsistOf("prev_geographies: " + unknownID.String() + " is not a published geography"),
+					}))),
+				))
+			})
+		})
+
+		When("agency creates the same geography twice", func() {
+			It("returns HTTP 409 Conflict", func() {
+				geography := testutils.MakeValidGeography()
+				Expect(apiClient.CreateGeography(geography)).To(HaveHTTPStatus(http.StatusCreated))
+				Expect(apiClient.CreateGeography(geography)).To(HaveHTTPStatus(http.StatusConflict))
+			})
+		})
+	})
+})
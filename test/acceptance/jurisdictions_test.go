@@ -0,0 +1,106 @@
+package acceptance
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	"github.com/technopolitica/open-transit/internal/domain"
+	. "github.com/technopolitica/open-transit/test/acceptance/matchers"
+	"github.com/technopolitica/open-transit/test/acceptance/testutils"
+)
+
+var _ = Describe("/jurisdictions", func() {
+	Context("unauthenticated", func() {
+		When("user attempts to list jurisdictions", func() {
+			AssertHasStandardUnauthorizedResponse(func() *http.Response {
+				return apiClient.ListJurisdictions()
+			})
+		})
+	})
+
+	Context("authenticated as provider", func() {
+		It("is not allowed to create jurisdictions", func() {
+			apiClient.AuthenticateAsProvider(testutils.GenerateRandomUUID())
+			Expect(apiClient.CreateJurisdiction(testutils.MakeValidJurisdiction(testutils.GenerateRandomUUID()))).To(HaveHTTPStatus(http.StatusForbidden))
+		})
+	})
+
+	Context("authenticated as agency", func() {
+		var geographyID uuid.UUID
+		BeforeEach(func() {
+			apiClient.AuthenticateAsAgency()
+			geography := testutils.MakeValidGeography()
+			Expect(apiClient.CreateGeography(geography)).To(HaveHTTPStatus(http.StatusCreated))
+			geographyID = geography.GeographyID
+		})
+
+		It("rejects jurisdictions for unknown geographies", func() {
+			jurisdiction := testutils.MakeValidJurisdiction(testutils.GenerateRandomUUID())
+			Expect(apiClient.CreateJurisdiction(jurisdiction)).To(SatisfyAll(
+				HaveHTTPStatus(http.StatusBadRequest),
+				HaveHTTPBody(MatchJSONObject(MatchKeys(IgnoreExtras, Keys{
+					"error_details": ConsistOf("geography_id: no geography with geography_id " + jurisdiction.GeographyID.String() + " is registered"),
+				}))),
+			))
+		})
+
+		It("returns HTTP 404 Not Found when updating an unknown jurisdiction", func() {
+			jurisdiction := testutils.MakeValidJurisdiction(geographyID)
+			Expect(apiClient.UpdateJurisdiction(jurisdiction.JurisdictionID.String(), jurisdiction)).To(HaveHTTPStatus(http.StatusNotFound))
+		})
+
+		When("a jurisdiction has been updated", func() {
+			var jurisdiction *domain.Jurisdiction
+			var beforeUpdate time.Time
+			BeforeEach(func() {
+				jurisdiction = testutils.MakeValidJurisdiction(geographyID)
+				Expect(apiClient.CreateJurisdiction(jurisdiction)).To(HaveHTTPStatus(http.StatusCreated))
+				time.Sleep(10 * time.Millisecond)
+				beforeUpdate = time.Now()
+				time.Sleep(10 * time.Millisecond)
+				jurisdiction.AgencyName = "Portland Bureau of Transportation"
+				Expect(apiClient.UpdateJurisdiction(jurisdiction.JurisdictionID.String(), jurisdiction)).To(HaveHTTPStatus(http.StatusOK))
+			})
+
+			It("returns the latest version by default", func() {
+				Expect(apiClient.GetJurisdiction(jurisdiction.JurisdictionID.String(), nil)).To(SatisfyAll(
+					HaveHTTPStatus(http.StatusOK),
+					HaveHTTPBody(MatchJSONObject(MatchAllKeys(Keys{
+						"version": Equal("2.0.0"),
+						"jurisdiction": MatchKeys(IgnoreExtras, Keys{
+							"jurisdiction_id": Equal(jurisdiction.JurisdictionID.String()),
+							"agency_name":     Equal("Portland Bureau of Transportation"),
+							"geography_id":    Equal(geographyID.String()),
+						}),
+					}))),
+				))
+			})
+
+			It("returns the version in effect at the effective time", func() {
+				Expect(apiClient.GetJurisdiction(jurisdiction.JurisdictionID.String(), &beforeUpdate)).To(HaveHTTPBody(MatchJSONObject(MatchKeys(IgnoreExtras, Keys{
+					"jurisdiction": MatchKeys(IgnoreExtras, Keys{
+						"agency_name": Equal("City of Portland"),
+					}),
+				}))))
+			})
+
+			It("returns HTTP 404 Not Found before the jurisdiction existed", func() {
+				beforeCreation := time.Now().Add(-time.Hour)
+				Expect(apiClient.GetJurisdiction(jurisdiction.JurisdictionID.String(), &beforeCreation)).To(HaveHTTPStatus(http.StatusNotFound))
+			})
+
+			It("lists the latest version of each jurisdiction", func() {
+				Expect(apiClient.ListJurisdictions()).To(HaveHTTPBody(MatchJSONObject(MatchKeys(IgnoreExtras, Keys{
+					"jurisdictions": ContainElement(MatchKeys(IgnoreExtras, Keys{
+						"jurisdiction_id": Equal(jurisdiction.JurisdictionID.String()),
+						"agency_name":     Equal("Portland Bureau of Transportation"),
+					})),
+				}))))
+			})
+		})
+	})
+})
@@ -0,0 +1,45 @@
+package acceptance
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/technopolitica/open-transit/test/acceptance/testutils"
+)
+
+var _ = Describe("Request logs", func() {
+	var providerID uuid.UUID
+	BeforeEach(func() {
+		providerID = testutils.GenerateRandomUUID()
+		apiClient.AuthenticateAsProvider(providerID)
+	})
+
+	It("returns the request ID in the X-Request-Id header", func() {
+		res := apiClient.ListVehicles(testutils.ListVehiclesOptions{Limit: 10})
+		Expect(res).To(HaveHTTPHeaderWithValue("X-Request-Id", Not(BeEmpty())))
+	})
+
+	It("logs the provider, route, status and bulk counts of a request", func() {
+		valid := testutils.MakeValidVehicle(providerID)
+		invalid := testutils.MakeValidVehicle(providerID)
+		invalid.DeviceID = uuid.Nil
+		res := apiClient.RegisterVehicles([]any{valid, invalid})
+		Expect(res).To(HaveHTTPStatus(http.StatusCreated))
+
+		requestID := res.Header.Get("X-Request-Id")
+		Eventually(func() map[string]any { return apiServer.RequestLog(requestID) }).Should(SatisfyAll(
+			HaveKeyWithValue("level", "INFO"),
+			HaveKeyWithValue("provider_id", providerID.String()),
+			HaveKeyWithValue("role", "provider"),
+			HaveKeyWithValue("method", "POST"),
+			HaveKeyWithValue("route", "/vehicles"),
+			HaveKeyWithValue("status", BeNumerically("==", http.StatusCreated)),
+			HaveKeyWithValue("bulk_total", BeNumerically("==", 2)),
+			HaveKeyWithValue("bulk_success", BeNumerically("==", 1)),
+			HaveKeyWithValue("bulk_failures", BeNumerically("==", 1)),
+			HaveKey("latency_ms"),
+		))
+	})
+})
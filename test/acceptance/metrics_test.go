@@ -0,0 +1,91 @@
+package acceptance
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	"github.com/technopolitica/open-transit/internal/domain"
+	. "github.com/technopolitica/open-transit/test/acceptance/matchers"
+	"github.com/technopolitica/open-transit/test/acceptance/testutils"
+)
+
+var _ = Describe("/metrics", func() {
+	Context("unauthenticated", func() {
+		When("user attempts to discover metrics", func() {
+			AssertHasStandardUnauthorizedResponse(func() *http.Response {
+				return apiClient.GetMetricsDiscovery()
+			})
+		})
+	})
+
+	Context("authenticated", func() {
+		var providerID uuid.UUID
+		var vehicle *domain.Vehicle
+		var query domain.MetricsQuery
+		BeforeEach(func() {
+			providerID = testutils.GenerateRandomUUID()
+			apiClient.AuthenticateAsProvider(providerID)
+			vehicle = testutils.MakeValidVehicle(providerID)
+			Expect(apiClient.RegisterVehicles([]any{vehicle})).To(HaveHTTPStatus(http.StatusCreated))
+			Expect(apiClient.SubmitTrips([]any{testutils.MakeValidTrip(vehicle)})).To(HaveHTTPStatus(http.StatusCreated))
+
+			start := time.Now().Add(-12 * time.Hour).Truncate(time.Millisecond)
+			query = domain.MetricsQuery{
+				Measures:  []string{domain.MeasureTripsCount},
+				Interval:  "P1D",
+				StartDate: domain.NewTimestamp(start),
+				EndDate:   domain.NewTimestamp(start.Add(24 * time.Hour)),
+			}
+		})
+
+		It("lists the supported metrics", func() {
+			Expect(apiClient.GetMetricsDiscovery()).To(SatisfyAll(
+				HaveHTTPStatus(http.StatusOK),
+				HaveHTTPBody(MatchJSONObject(MatchKeys(IgnoreExtras, Keys{
+					"version":    Equal("2.0.0"),
+					"metrics":    ContainElement(MatchKeys(IgnoreExtras, Keys{"name": Equal("trips.count")})),
+					"intervals":  ContainElement("PT1H"),
+					"dimensions": ConsistOf("provider_id", "vehicle_type", "geography_id"),
+				}))),
+			))
+		})
+
+		It("rejects invalid queries", func() {
+			query.Interval = "P1M"
+			Expect(apiClient.QueryMetrics(query)).To(SatisfyAll(
+				HaveHTTPStatus(http.StatusBadRequest),
+				HaveHTTPBody(MatchJSONObject(MatchKeys(IgnoreExtras, Keys{
+					"error_details": ConsistOf(`interval: unsupported interval "P1M"`),
+				}))),
+			))
+		})
+
+		It("only counts the provider's own trips", func() {
+			otherProviderID := testutils.MakeUUIDExcluding(providerID)
+			query.Filters = []domain.MetricsFilter{{Name: domain.MetricDimensionProviderId, Values: []string{otherProviderID.String()}}}
+			Expect(apiClient.QueryMetrics(query)).To(SatisfyAll(
+				HaveHTTPStatus(http.StatusOK),
+				HaveHTTPBody(MatchJSONObject(MatchKeys(IgnoreExtras, Keys{
+					"columns": HaveLen(2),
+					"rows":    ConsistOf(ConsistOf(float64(query.StartDate.UnixMilli()), float64(1))),
+				}))),
+			))
+		})
+
+		It("groups by the requested dimensions", func() {
+			apiClient.AuthenticateAsAgency()
+			query.Dimensions = []domain.MetricDimension{domain.MetricDimensionProviderId, domain.MetricDimensionVehicleType}
+			query.Filters = []domain.MetricsFilter{{Name: domain.MetricDimensionProviderId, Values: []string{providerID.String()}}}
+			Expect(apiClient.QueryMetrics(query)).To(SatisfyAll(
+				HaveHTTPStatus(http.StatusOK),
+				HaveHTTPBody(MatchJSONObject(MatchKeys(IgnoreExtras, Keys{
+					"rows": ConsistOf(ConsistOf(float64(query.StartDate.UnixMilli()), providerID.String(), "moped", float64(1))),
+				}))),
+			))
+		})
+	})
+})
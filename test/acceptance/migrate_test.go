@@ -0,0 +1,131 @@
+package acceptance
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gexec"
+	"github.com/technopolitica/open-transit/internal/db"
+	"github.com/technopolitica/open-transit/test/acceptance/testutils"
+)
+
+var _ = Describe("open-transit-migrate", func() {
+	var emptyDB testutils.TestDB
+	var latestVersion int64
+	BeforeEach(func(ctx context.Context) {
+		var err error
+		emptyDB, err = dbClient.CreateEmptyDB(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(func(ctx context.Context) error {
+			return dbClient.CleanupTestDB(ctx, emptyDB.Name)
+		})
+		latestVersion, err = db.LatestMigrationVersion()
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	migrate := func(args ...string) *gexec.Session {
+		args = append([]string{"-db-url", emptyDB.ConnectionString}, args...)
+		session, err := gexec.Start(exec.Command(migrateBinaryPath, args...), GinkgoWriter, GinkgoWriter)
+		Expect(err).NotTo(HaveOccurred())
+		return session.Wait(time.Minute)
+	}
+
+	It("reports version 0 and every migration as pending on an empty database", func() {
+		Expect(migrate("version")).To(gexec.Exit(0))
+		Expect(strings.TrimSpace(string(migrate("version").Out.Contents()))).To(Equal("0"))
+		status := migrate("status")
+		Expect(status).To(gexec.Exit(0))
+		Expect(string(status.Out.Contents())).To(ContainSubstring("Pending                       20230720134017_initial_schema.sql"))
+	})
+
+	It("prints the SQL that would run w/o applying it in a dry run", func() {
+		session := migrate("migrate", "-to", "latest", "-dry-run")
+		Expect(session).To(gexec.Exit(0))
+		Expect(string(session.Out.Contents())).To(ContainSubstring("-- 20230720134017_initial_schema.sql (up)"))
+		Expect(strings.TrimSpace(string(migrate("version").Out.Contents()))).To(Equal("0"))
+	})
+
+	It("migrates to the latest version and exits w/ 3 when there is nothing to do", func() {
+		Expect(migrate("migrate", "-to", "latest")).To(gexec.Exit(0))
+		Expect(strings.TrimSpace(string(migrate("version").Out.Contents()))).To(Equal(fmt.Sprint(latestVersion)))
+		Expect(migrate("migrate", "-to", "latest")).To(gexec.Exit(3))
+		Expect(string(migrate("status").Out.Contents())).NotTo(ContainSubstring("Pending"))
+	})
+
+	It("migrates up to and including a version", func() {
+		Expect(migrate("migrate", "-to", "20230722194028")).To(gexec.Exit(0))
+		Expect(strings.TrimSpace(string(migrate("version").Out.Contents()))).To(Equal("20230722194028"))
+	})
+
+	It("exits w/ 3 when rolling back a database w/o migrations", func() {
+		Expect(migrate("down", "-to", "0")).To(gexec.Exit(3))
+		Expect(migrate("redo")).To(gexec.Exit(3))
+	})
+
+	It("rolls back every migration and applies them again", func(ctx context.Context) {
+		conn, err := pgx.Connect(ctx, emptyDB.ConnectionString)
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(conn.Close)
+		relations := func() []string {
+			rows, err := conn.Query(ctx, `
+				SELECT c.relkind::text || ' ' || c.relname
+				FROM pg_class AS c
+				INNER JOIN pg_namespace AS n ON n.oid = c.relnamespace
+				WHERE n.nspname = 'public' AND c.relname NOT LIKE 'goose_db_version%'
+				UNION ALL
+				SELECT 'f ' || p.proname
+				FROM pg_proc AS p
+				INNER JOIN pg_namespace AS n ON n.oid = p.pronamespace
+				WHERE n.nspname = 'public'
+				ORDER BY 1`)
+			Expect(err).NotTo(HaveOccurred())
+			names, err := pgx.CollectRows(rows, pgx.RowTo[string])
+			Expect(err).NotTo(HaveOccurred())
+			return names
+		}
+
+		Expect(migrate("migrate", "-to", "latest")).To(gexec.Exit(0))
+		migrated := relations()
+		Expect(migrated).To(ContainElements("v vehicle_denormalized", "f insert_denormalized_vehicle"))
+
+		Expect(migrate("down", "-to", "0")).To(gexec.Exit(0))
+		Expect(strings.TrimSpace(string(migrate("version").Out.Contents()))).To(Equal("0"))
+		Expect(relations()).To(BeEmpty())
+
+		Expect(migrate("migrate", "-to", "latest")).To(gexec.Exit(0))
+		Expect(strings.TrimSpace(string(migrate("version").Out.Contents()))).To(Equal(fmt.Sprint(latestVersion)))
+		Expect(relations()).To(Equal(migrated))
+	})
+
+	It("rolls back the newest migration and applies it again", func() {
+		Expect(migrate("migrate", "-to", "latest")).To(gexec.Exit(0))
+		Expect(migrate("redo")).To(gexec.Exit(0))
+		Expect(strings.TrimSpace(string(migrate("version").Out.Contents()))).To(Equal(fmt.Sprint(latestVersion)))
+	})
+
+	It("rolls back to a version", func() {
+		Expect(migrate("migrate", "-to", "latest")).To(gexec.Exit(0))
+		session := migrate("down", "-to", "20230722194028", "-dry-run")
+		Expect(session).To(gexec.Exit(0))
+		Expect(string(session.Out.Contents())).To(ContainSubstring("DROP TABLE IF EXISTS event;"))
+		Expect(string(session.Out.Contents())).NotTo(ContainSubstring("DROP VIEW IF EXISTS vehicle_denormalized;"))
+		Expect(migrate("down", "-to", "20230722194028")).To(gexec.Exit(0))
+		Expect(strings.TrimSpace(string(migrate("version").Out.Contents()))).To(Equal("20230722194028"))
+	})
+
+	It("exits w/ 1 for unknown versions", func() {
+		Expect(migrate("migrate", "-to", "123")).To(gexec.Exit(1))
+	})
+
+	It("exits w/ 2 for invalid usage", func() {
+		Expect(migrate("migrate")).To(gexec.Exit(2))
+		Expect(migrate("down", "-to", "yesterday")).To(gexec.Exit(2))
+		Expect(migrate("sideways")).To(gexec.Exit(2))
+	})
+})
@@ -0,0 +1,52 @@
+package acceptance
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/technopolitica/open-transit/test/acceptance/testutils"
+)
+
+var _ = Describe("Operational metrics", func() {
+	var providerID uuid.UUID
+	BeforeEach(func() {
+		providerID = testutils.GenerateRandomUUID()
+		apiClient.AuthenticateAsProvider(providerID)
+	})
+
+	It("counts requests by route and status", func() {
+		Expect(apiClient.ListVehicles(testutils.ListVehiclesOptions{Limit: 10})).To(HaveHTTPStatus(http.StatusOK))
+		// Requests are counted once their response has been written.
+		Eventually(apiServer.Metrics).Should(SatisfyAll(
+			MatchRegexp(`open_transit_http_requests_total\{method="GET",route="/vehicles",status="200"\} \d+`),
+			ContainSubstring(`open_transit_http_request_duration_seconds_count{method="GET",route="/vehicles"}`),
+		))
+	})
+
+	It("counts bulk items by result", func() {
+		valid := testutils.MakeValidVehicle(providerID)
+		invalid := testutils.MakeValidVehicle(providerID)
+		invalid.DeviceID = uuid.Nil
+		Expect(apiClient.RegisterVehicles([]any{valid, invalid})).To(HaveHTTPStatus(http.StatusCreated))
+
+		metrics, err := apiServer.Metrics()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(metrics).To(ContainSubstring(fmt.Sprintf(`open_transit_bulk_items_total{provider_id="%s",result="success",route="/vehicles"} 1`, providerID)))
+		Expect(metrics).To(ContainSubstring(fmt.Sprintf(`open_transit_bulk_items_total{provider_id="%s",result="bad_param",route="/vehicles"} 1`, providerID)))
+	})
+
+	It("measures the ingestion lag of telemetry", func() {
+		vehicle := testutils.MakeValidVehicle(providerID)
+		Expect(apiClient.RegisterVehicles([]any{vehicle})).To(HaveHTTPStatus(http.StatusCreated))
+		Expect(apiClient.SubmitTelemetry([]any{testutils.MakeValidTelemetry(vehicle)})).To(HaveHTTPStatus(http.StatusCreated))
+
+		Expect(apiServer.Metrics()).To(ContainSubstring(fmt.Sprintf(`open_transit_ingestion_lag_seconds_count{provider_id="%s",type="telemetry"} 1`, providerID)))
+	})
+
+	It("exports database connection pool statistics", func() {
+		Expect(apiServer.Metrics()).To(ContainSubstring("open_transit_db_pool_max_conns"))
+	})
+})
@@ -0,0 +1,190 @@
+package acceptance
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	"github.com/technopolitica/open-transit/internal/domain"
+	. "github.com/technopolitica/open-transit/test/acceptance/matchers"
+	"github.com/technopolitica/open-transit/test/acceptance/testutils"
+)
+
+var _ = Describe("/policies", func() {
+	Context("unauthenticated", func() {
+		When("user attempts to list policies", func() {
+			AssertHasStandardUnauthorizedResponse(func() *http.Response {
+				return apiClient.ListPolicies()
+			})
+		})
+	})
+
+	Context("authenticated as provider", func() {
+		BeforeEach(func() {
+			apiClient.AuthenticateAsProvider(testutils.GenerateRandomUUID())
+		})
+
+		It("is not allowed to create policies", func() {
+			Expect(apiClient.CreatePolicy(testutils.MakeValidPolicy())).To(HaveHTTPStatus(http.StatusForbidden))
+		})
+	})
+
+	Context("authenticated as admin", func() {
+		var policy *domain.Policy
+		BeforeEach(func() {
+			apiClient.AuthenticateAsAdmin()
+			policy = testutils.MakeValidPolicy()
+			Expect(apiClient.CreatePolicy(policy)).To(HaveHTTPStatus(http.StatusCreated))
+		})
+
+		It("rejects invalid policies", func() {
+			invalidPolicy := testutils.MakeValidPolicy()
+			invalidPolicy.Rules = nil
+			Expect(apiClient.CreatePolicy(invalidPolicy)).To(SatisfyAll(
+				HaveHTTPStatus(http.StatusBadRequest),
+				HaveHTTPBody(MatchJSONObject(MatchKeys(IgnoreExtras, Keys{
+					"error":         Equal("bad_param"),
+					"error_details": ConsistOf("rules: must contain at least one rule"),
+				}))),
+			))
+		})
+
+		It("rejects duplicate policy_ids", func() {
+			Expect(apiClient.CreatePolicy(policy)).To(HaveHTTPStatus(http.StatusConflict))
+		})
+
+		It("can edit a draft policy", func() {
+			policy.Name = "Revised speed limit"
+			Expect(apiClient.UpdatePolicy(policy.PolicyID.String(), policy)).To(HaveHTTPStatus(http.StatusOK))
+			Expect(apiClient.GetPolicy(policy.PolicyID.String())).To(HaveHTTPBody(MatchJSONObject(MatchKeys(IgnoreExtras, Keys{
+				"policies": ConsistOf(MatchJSONObject(policy)),
+			}))))
+		})
+
+		It("does not allow agency staff to publish policies", func() {
+			apiClient.AuthenticateAsAgency()
+			Expect(apiClient.PublishPolicy(policy.PolicyID.String())).To(SatisfyAll(
+				HaveHTTPStatus(http.StatusForbidden),
+				HaveHTTPBody(MatchJSONObject(MatchKeys(IgnoreExtras, Keys{
+					"error":         Equal("forbidden"),
+					"error_details": ConsistOf("missing required scope policies:publish"),
+				}))),
+			))
+		})
+
+		It("hides draft policies from providers", func() {
+			apiClient.AuthenticateAsProvider(testutils.GenerateRandomUUID())
+			Expect(apiClient.GetPolicy(policy.PolicyID.String())).To(HaveHTTPStatus(http.StatusNotFound))
+		})
+
+		When("the policy is published", func() {
+			BeforeEach(func() {
+				Expect(apiClient.PublishPolicy(policy.PolicyID.String())).To(HaveHTTPStatus(http.StatusOK))
+			})
+
+			It("is visible to providers", func() {
+				apiClient.AuthenticateAsProvider(testutils.GenerateRandomUUID())
+				Expect(apiClient.GetPolicy(policy.PolicyID.String())).To(SatisfyAll(
+					HaveHTTPStatus(http.StatusOK),
+					HaveHTTPBody(MatchJSONObject(MatchAllKeys(Keys{
+						"version": Equal("2.0.0"),
+						"updated": BeNumerically(">", 0),
+						"policies": ConsistOf(MatchKeys(IgnoreExtras, Keys{
+							"policy_id":      Equal(policy.PolicyID.String()),
+							"published_date": BeNumerically(">", 0),
+						})),
+					}))),
+				))
+				Expect(apiClient.ListPolicies()).To(HaveHTTPBody(MatchJSONObject(MatchKeys(IgnoreExtras, Keys{
+					"policies": ContainElement(MatchKeys(IgnoreExtras, Keys{
+						"policy_id": Equal(policy.PolicyID.String()),
+					})),
+				}))))
+			})
+
+			It("can no longer be edited", func() {
+				policy.Name = "Revised speed limit"
+				Expect(apiClient.UpdatePolicy(policy.PolicyID.String(), policy)).To(SatisfyAll(
+					HaveHTTPStatus(http.StatusConflict),
+					HaveHTTPBody(MatchJSONObject(MatchKeys(IgnoreExtras, Keys{
+						"error_details": ConsistOf("published_date: published policies cannot be modified"),
+					}))),
+				))
+			})
+
+			It("cannot be published again", func() {
+				Expect(apiClient.PublishPolicy(policy.PolicyID.String())).To(HaveHTTPStatus(http.StatusConflict))
+			})
+
+			It("is no longer listed once superseded", func() {
+				successor := testutils.MakeValidPolicy()
+				successor.PrevPolicies = []uuid.UUID{policy.PolicyID}
+				successor.StartDate = domain.NewTimestamp(time.Now().Add(-time.Minute))
+				Expect(apiClient.CreatePolicy(successor)).To(HaveHTTPStatus(http.StatusCreated))
+				Expect(apiClient.PublishPolicy(successor.PolicyID.String())).To(HaveHTTPStatus(http.StatusOK))
+
+				Expect(apiClient.ListPolicies()).To(HaveHTTPBody(MatchJSONObject(MatchKeys(IgnoreExtras, Keys{
+					"policies": SatisfyAll(
+						ContainElement(MatchKeys(IgnoreExtras, Keys{"policy_id": Equal(successor.PolicyID.String())})),
+						Not(ContainElement(MatchKeys(IgnoreExtras, Keys{"policy_id": Equal(policy.PolicyID.String())}))),
+					),
+				}))))
+			})
+		})
+
+		It("cannot publish a policy that supersedes a draft", func() {
+			successor := testutils.MakeValidPolicy()
+			successor.PrevPolicies = []uuid.UUID{policy.PolicyID}
+			Expect(apiClient.CreatePolicy(successor)).To(HaveHTTPStatus(http.StatusCreated))
+			Expect(apiClient.PublishPolicy(successor.PolicyID.String())).To(SatisfyAll(
+				HaveHTTPStatus(http.StatusBadRequest),
+				HaveHTTPBody(MatchJSONObject(MatchKeys(IgnoreExtras, Keys{
+					"error_details": ConsistOf("prev_policies: " + policy.PolicyID.String() + " is not a published policy"),
+				}))),
+			))
+		})
+	})
+})
+
+var _ = Describe("/requirements", func() {
+	Context("authenticated as provider", func() {
+		BeforeEach(func() {
+			apiClient.AuthenticateAsProvider(testutils.GenerateRandomUUID())
+		})
+
+		It("is not allowed to update requirements", func() {
+			Expect(apiClient.UpdateRequirements(map[string]any{
+				"metadata":     map[string]any{"mds_versions": []string{"2.0.0"}},
+				"requirements": []any{},
+			})).To(HaveHTTPStatus(http.StatusForbidden))
+		})
+	})
+
+	Context("authenticated as agency", func() {
+		BeforeEach(func() {
+			apiClient.AuthenticateAsAgency()
+		})
+
+		It("serves the requirements published by the agency", func() {
+			requirements := map[string]any{
+				"metadata":     map[string]any{"mds_versions": []any{"2.0.0"}},
+				"requirements": []any{map[string]any{"programs": []any{}}},
+			}
+			Expect(apiClient.UpdateRequirements(requirements)).To(HaveHTTPStatus(http.StatusOK))
+
+			apiClient.AuthenticateAsProvider(testutils.GenerateRandomUUID())
+			Expect(apiClient.GetRequirements()).To(SatisfyAll(
+				HaveHTTPStatus(http.StatusOK),
+				HaveHTTPBody(MatchJSONObject(MatchAllKeys(Keys{
+					"version":      Equal("2.0.0"),
+					"last_updated": BeNumerically(">", 0),
+					"metadata":     Equal(requirements["metadata"]),
+					"requirements": Equal(requirements["requirements"]),
+				}))),
+			))
+		})
+	})
+})
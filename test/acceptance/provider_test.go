@@ -0,0 +1,186 @@
+package acceptance
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	"github.com/technopolitica/open-transit/internal/domain"
+	. "github.com/technopolitica/open-transit/test/acceptance/matchers"
+	"github.com/technopolitica/open-transit/test/acceptance/testutils"
+)
+
+var _ = Describe("/provider", func() {
+	// A completed hour far enough in the past that data submitted for it is served by the hourly endpoints.
+	completedHour := time.Now().UTC().Add(-2 * time.Hour).Truncate(time.Hour)
+	completedHourParam := completedHour.Format(domain.HourFormat)
+
+	Context("unauthenticated", func() {
+		When("user requests trips", func() {
+			AssertHasStandardUnauthorizedResponse(func() *http.Response {
+				return apiClient.ListProviderTrips(completedHourParam)
+			})
+		})
+	})
+
+	Context("authenticated as provider", func() {
+		var providerID uuid.UUID
+		var vehicle *domain.Vehicle
+		BeforeEach(func() {
+			providerID = testutils.GenerateRandomUUID()
+			apiClient.AuthenticateAsProvider(providerID)
+			vehicle = testutils.MakeValidVehicle(providerID)
+			Expect(apiClient.RegisterVehicles([]any{vehicle})).To(HaveHTTPStatus(http.StatusCreated))
+		})
+
+		Describe("GET /provider/trips", func() {
+			It("returns trips that ended during the requested hour", func() {
+				trip := testutils.MakeValidTrip(vehicle)
+				trip.StartTime = domain.NewTimestamp(completedHour.Add(10 * time.Minute))
+				trip.EndTime = domain.NewTimestamp(completedHour.Add(25 * time.Minute))
+				laterTrip := testutils.MakeValidTrip(vehicle)
+				laterTrip.StartTime = domain.NewTimestamp(completedHour.Add(50 * time.Minute))
+				laterTrip.EndTime = domain.NewTimestamp(completedHour.Add(70 * time.Minute))
+				Expect(apiClient.SubmitTrips([]any{trip, laterTrip})).To(HaveHTTPStatus(http.StatusCreated))
+
+				Expect(apiClient.ListProviderTrips(completedHourParam)).To(SatisfyAll(
+					HaveHTTPStatus(http.StatusOK),
+					HaveHTTPBody(MatchJSONObject(MatchAllKeys(Keys{
+						"version": Equal("2.0.0"),
+						"trips":   ConsistOf(MatchJSONObject(trip)),
+					}))),
+				))
+			})
+
+			It("does not return trips for other providers", func() {
+				trip := testutils.MakeValidTrip(vehicle)
+				trip.StartTime = domain.NewTimestamp(completedHour.Add(10 * time.Minute))
+				trip.EndTime = domain.NewTimestamp(completedHour.Add(25 * time.Minute))
+				Expect(apiClient.SubmitTrips([]any{trip})).To(HaveHTTPStatus(http.StatusCreated))
+
+				apiClient.AuthenticateAsProvider(testutils.MakeUUIDExcluding(providerID))
+				Expect(apiClient.ListProviderTrips(completedHourParam)).To(HaveHTTPBody(MatchJSONObject(MatchKeys(IgnoreExtras, Keys{
+					"trips": BeEmpty(),
+				}))))
+			})
+
+			It("requires end_time", func() {
+				Expect(apiClient.ListProviderTrips("")).To(SatisfyAll(
+					HaveHTTPStatus(http.StatusBadRequest),
+					HaveHTTPBody(MatchJSONObject(MatchAllKeys(Keys{
+						"error":         Equal("bad_param"),
+						"error_details": ConsistOf("end_time: missing required parameter"),
+					}))),
+				))
+			})
+
+			It("rejects a malformed end_time", func() {
+				Expect(apiClient.ListProviderTrips("2023-07-20")).To(SatisfyAll(
+					HaveHTTPStatus(http.StatusBadRequest),
+					HaveHTTPBody(MatchJSONObject(MatchAllKeys(Keys{
+						"error":         Equal("bad_param"),
+						"error_details": ConsistOf("end_time: must be an hour in the format YYYY-MM-DDTHH"),
+					}))),
+				))
+			})
+
+			It("returns HTTP 404 Not Found for an hour that has not finished yet", func() {
+				Expect(apiClient.ListProviderTrips(time.Now().UTC().Format(domain.HourFormat))).To(HaveHTTPStatus(http.StatusNotFound))
+			})
+		})
+
+		Describe("GET /provider/telemetry", func() {
+			It("returns telemetry recorded during the requested hour", func() {
+				telemetry := testutils.MakeValidTelemetry(vehicle)
+				telemetry.Timestamp = domain.NewTimestamp(completedHour.Add(30 * time.Minute))
+				Expect(apiClient.SubmitTelemetry([]any{telemetry})).To(HaveHTTPStatus(http.StatusCreated))
+
+				Expect(apiClient.ListProviderTelemetry(completedHourParam)).To(SatisfyAll(
+					HaveHTTPStatus(http.StatusOK),
+					HaveHTTPBody(MatchJSONObject(MatchAllKeys(Keys{
+						"version":   Equal("2.0.0"),
+						"telemetry": ConsistOf(MatchJSONObject(telemetry)),
+					}))),
+				))
+			})
+		})
+
+		Describe("GET /provider/events", func() {
+			var event *domain.Event
+			BeforeEach(func() {
+				event = testutils.MakeValidEvent(vehicle, domain.VehicleStateAvailable, domain.EventTypeProviderDropOff)
+				event.Timestamp = domain.NewTimestamp(completedHour.Add(5 * time.Minute))
+				Expect(apiClient.SubmitEvents([]any{event})).To(HaveHTTPStatus(http.StatusCreated))
+			})
+
+			It("returns historical events for the requested hour", func() {
+				Expect(apiClient.ListHistoricalEvents(completedHourParam)).To(SatisfyAll(
+					HaveHTTPStatus(http.StatusOK),
+					HaveHTTPBody(MatchJSONObject(MatchAllKeys(Keys{
+						"version": Equal("2.0.0"),
+						"events":  ConsistOf(MatchJSONObject(event)),
+					}))),
+				))
+			})
+
+			It("returns recent events between start_time and end_time", func() {
+				Expect(apiClient.ListRecentEvents(completedHour, time.Now())).To(SatisfyAll(
+					HaveHTTPStatus(http.StatusOK),
+					HaveHTTPBody(MatchJSONObject(MatchAllKeys(Keys{
+						"version": Equal("2.0.0"),
+						"events":  ConsistOf(MatchJSONObject(event)),
+					}))),
+				))
+			})
+
+			It("rejects recent event requests older than two weeks", func() {
+				Expect(apiClient.ListRecentEvents(time.Now().Add(-15*24*time.Hour), time.Now())).To(SatisfyAll(
+					HaveHTTPStatus(http.StatusBadRequest),
+					HaveHTTPBody(MatchJSONObject(MatchAllKeys(Keys{
+						"error":         Equal("bad_param"),
+						"error_details": ConsistOf("start_time: must be within the last two weeks"),
+					}))),
+				))
+			})
+		})
+
+		Describe("GET /provider/reports", func() {
+			It("returns the provider's reports", func() {
+				report := testutils.MakeValidReport(providerID)
+				Expect(apiClient.SubmitReports([]any{report})).To(HaveHTTPStatus(http.StatusCreated))
+
+				Expect(apiClient.ListProviderReports()).To(SatisfyAll(
+					HaveHTTPStatus(http.StatusOK),
+					HaveHTTPBody(MatchJSONObject(MatchAllKeys(Keys{
+						"version": Equal("2.0.0"),
+						"reports": ConsistOf(MatchJSONObject(report)),
+					}))),
+				))
+			})
+		})
+
+		Describe("GET /provider/vehicles", func() {
+			It("returns the provider's vehicles", func() {
+				Expect(apiClient.Get("/provider/vehicles?page[limit]=10")).To(SatisfyAll(
+					HaveHTTPStatus(http.StatusOK),
+					HaveHTTPBody(MatchJSONObject(MatchKeys(IgnoreExtras, Keys{
+						"vehicles": ConsistOf(MatchJSONObject(vehicle)),
+					}))),
+				))
+			})
+
+			It("rejects device IDs that aren't UUIDs", func() {
+				Expect(apiClient.Get("/provider/vehicles/not-a-uuid")).To(SatisfyAll(
+					HaveHTTPStatus(http.StatusBadRequest),
+					HaveHTTPBody(MatchJSONObject(MatchAllKeys(Keys{
+						"error":         Equal("bad_param"),
+						"error_details": ConsistOf("device_id: must be a valid UUID"),
+					}))),
+				))
+			})
+		})
+	})
+})
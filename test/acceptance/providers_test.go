@@ -0,0 +1,161 @@
+package acceptance
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	"github.com/technopolitica/open-transit/internal/domain"
+	. "github.com/technopolitica/open-transit/test/acceptance/matchers"
+	"github.com/technopolitica/open-transit/test/acceptance/testutils"
+)
+
+var _ = Describe("/providers", func() {
+	Context("unauthenticated", func() {
+		When("user attempts to list providers", func() {
+			AssertHasStandardUnauthorizedResponse(func() *http.Response {
+				return apiClient.ListProviders()
+			})
+		})
+	})
+
+	Context("authenticated as agency", func() {
+		BeforeEach(func() {
+			apiClient.AuthenticateAsAgency()
+		})
+
+		It("can list providers", func() {
+			Expect(apiClient.ListProviders()).To(HaveHTTPStatus(http.StatusOK))
+		})
+
+		It("is not allowed to register providers", func() {
+			Expect(apiClient.CreateProvider(testutils.MakeValidProvider(testutils.GenerateRandomUUID()))).To(HaveHTTPStatus(http.StatusForbidden))
+		})
+	})
+
+	Context("authenticated as admin", func() {
+		BeforeEach(func() {
+			apiClient.AuthenticateAsAdmin()
+		})
+
+		When("admin registers a valid provider", func() {
+			It("adds the provider to the registry", func() {
+				provider := testutils.MakeValidProvider(testutils.GenerateRandomUUID())
+				provider.VehicleTypes = domain.NewSet(domain.VehicleTypeBicycle)
+				Expect(apiClient.CreateProvider(provider)).To(HaveHTTPStatus(http.StatusCreated))
+				Expect(apiClient.GetProvider(provider.ProviderID.String())).To(SatisfyAll(
+					HaveHTTPStatus(http.StatusOK),
+					HaveHTTPBody(MatchJSONObject(MatchAllKeys(Keys{
+						"version": Equal("2.0.0"),
+						"provider": MatchAllKeys(Keys{
+							"provider_id":    Equal(provider.ProviderID.String()),
+							"provider_name":  Equal(provider.ProviderName),
+							"url":            Equal(provider.URL),
+							"contact":        Equal(provider.Contact),
+							"status":         Equal("active"),
+							"vehicle_types":  ConsistOf("bicycle"),
+							"data_providers": BeEmpty(),
+						}),
+					}))),
+				))
+				Expect(apiClient.ListProviders()).To(HaveHTTPBody(MatchJSONObject(MatchKeys(IgnoreExtras, Keys{
+					"providers": ContainElement(MatchKeys(IgnoreExtras, Keys{
+						"provider_id": Equal(provider.ProviderID.String()),
+					})),
+				}))))
+			})
+		})
+
+		When("admin registers a provider twice", func() {
+			It("returns HTTP 409 Conflict", func() {
+				provider := testutils.MakeValidProvider(testutils.GenerateRandomUUID())
+				Expect(apiClient.CreateProvider(provider)).To(HaveHTTPStatus(http.StatusCreated))
+				Expect(apiClient.CreateProvider(provider)).To(HaveHTTPStatus(http.StatusConflict))
+			})
+		})
+
+		When("admin registers a provider w/o a name", func() {
+			It("returns HTTP 400 Bad Request", func() {
+				provider := testutils.MakeValidProvider(testutils.GenerateRandomUUID())
+				provider.ProviderName = ""
+				Expect(apiClient.CreateProvider(provider)).To(SatisfyAll(
+					HaveHTTPStatus(http.StatusBadRequest),
+					HaveHTTPBody(MatchJSONObject(MatchKeys(IgnoreExtras, Keys{
+						"error_details": ConsistOf("provider_name: missing required field"),
+					}))),
+				))
+			})
+		})
+
+		When("admin updates an unknown provider", func() {
+			It("returns HTTP 404 Not Found", func() {
+				provider := testutils.MakeValidProvider(testutils.GenerateRandomUUID())
+				Expect(apiClient.UpdateProvider(provider.ProviderID.String(), provider)).To(HaveHTTPStatus(http.StatusNotFound))
+			})
+		})
+
+		When("admin deletes a provider", func() {
+			It("removes the provider from the registry", func() {
+				provider := testutils.MakeValidProvider(testutils.GenerateRandomUUID())
+				Expect(apiClient.CreateProvider(provider)).To(HaveHTTPStatus(http.StatusCreated))
+				Expect(apiClient.DeleteProvider(provider.ProviderID.String())).To(HaveHTTPStatus(http.StatusNoContent))
+				Expect(apiClient.GetProvider(provider.ProviderID.String())).To(HaveHTTPStatus(http.StatusNotFound))
+			})
+		})
+	})
+
+	Describe("onboarding", func() {
+		var providerID uuid.UUID
+		BeforeEach(func() {
+			providerID = testutils.GenerateRandomUUID()
+		})
+
+		It("rejects vehicles from unregistered providers", func() {
+			apiClient.AuthenticateAsProviderWithoutRegistering(providerID)
+			Expect(apiClient.RegisterVehicles([]any{testutils.MakeValidVehicle(providerID)})).To(SatisfyAll(
+				HaveHTTPStatus(http.StatusForbidden),
+				HaveHTTPBody(MatchJSONObject(MatchKeys(IgnoreExtras, Keys{
+					"error":         Equal("forbidden"),
+					"error_details": ConsistOf("provider_id: provider is not registered"),
+				}))),
+			))
+		})
+
+		It("rejects vehicles from suspended providers", func() {
+			apiClient.AuthenticateAsProvider(providerID)
+			apiClient.AuthenticateAsAdmin()
+			provider := testutils.MakeValidProvider(providerID)
+			provider.Status = domain.ProviderStatusSuspended
+			Expect(apiClient.UpdateProvider(providerID.String(), provider)).To(HaveHTTPStatus(http.StatusOK))
+
+			apiClient.AuthenticateAsProviderWithoutRegistering(providerID)
+			Expect(apiClient.RegisterVehicles([]any{testutils.MakeValidVehicle(providerID)})).To(SatisfyAll(
+				HaveHTTPStatus(http.StatusForbidden),
+				HaveHTTPBody(MatchJSONObject(MatchKeys(IgnoreExtras, Keys{
+					"error_details": ConsistOf("provider_id: provider is suspended"),
+				}))),
+			))
+		})
+
+		It("rejects vehicle types the provider isn't allowed to operate", func() {
+			apiClient.AuthenticateAsAdmin()
+			provider := testutils.MakeValidProvider(providerID)
+			provider.VehicleTypes = domain.NewSet(domain.VehicleTypeBicycle)
+			Expect(apiClient.CreateProvider(provider)).To(HaveHTTPStatus(http.StatusCreated))
+
+			apiClient.AuthenticateAsProviderWithoutRegistering(providerID)
+			vehicle := testutils.MakeValidVehicle(providerID)
+			vehicle.VehicleType = domain.VehicleTypeCar
+			Expect(apiClient.RegisterVehicles([]any{vehicle})).To(SatisfyAll(
+				HaveHTTPStatus(http.StatusBadRequest),
+				HaveHTTPBody(MatchJSONObject(MatchKeys(IgnoreExtras, Keys{
+					"failures": ConsistOf(MatchKeys(IgnoreExtras, Keys{
+						"error_details": ConsistOf("vehicle_type: provider is not allowed to operate car vehicles"),
+					})),
+				}))),
+			))
+		})
+	})
+})
@@ -0,0 +1,56 @@
+package acceptance
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/technopolitica/open-transit/internal/db"
+	"github.com/technopolitica/open-transit/internal/domain"
+)
+
+var _ = Describe("GET /ready", func() {
+	var latestVersion int64
+	BeforeEach(func() {
+		var err error
+		latestVersion, err = db.LatestMigrationVersion()
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("is ready when the database is migrated", func() {
+		res := apiClient.Get("/ready")
+		Expect(res).To(HaveHTTPStatus(http.StatusOK))
+		var readiness domain.Readiness
+		Expect(json.NewDecoder(res.Body).Decode(&readiness)).To(Succeed())
+		Expect(readiness).To(Equal(domain.Readiness{
+			Ready:    true,
+			Database: domain.DatabaseReadiness{Ready: true},
+			Schema:   domain.SchemaReadiness{Ready: true, Version: latestVersion, LatestVersion: latestVersion},
+		}))
+	})
+
+	When("the latest migration has been rolled back", func() {
+		BeforeEach(func(ctx context.Context) {
+			// goose's version table is a log, so the latest migration is restored by recording it
+			// as applied again.
+			Expect(apiServer.ExecSQL(ctx, "INSERT INTO goose_db_version (version_id, is_applied) VALUES ($1, false)", latestVersion)).To(Succeed())
+			DeferCleanup(func(ctx context.Context) {
+				Expect(apiServer.ExecSQL(ctx, "INSERT INTO goose_db_version (version_id, is_applied) VALUES ($1, true)", latestVersion)).To(Succeed())
+			})
+		})
+
+		It("returns HTTP 503 Service Unavailable", func() {
+			res := apiClient.Get("/ready")
+			Expect(res).To(HaveHTTPStatus(http.StatusServiceUnavailable))
+			var readiness domain.Readiness
+			Expect(json.NewDecoder(res.Body).Decode(&readiness)).To(Succeed())
+			Expect(readiness.Ready).To(BeFalse())
+			Expect(readiness.Database.Ready).To(BeTrue())
+			Expect(readiness.Schema.Ready).To(BeFalse())
+			Expect(readiness.Schema.Version).To(BeNumerically("<", latestVersion))
+			Expect(readiness.Schema.LatestVersion).To(Equal(latestVersion))
+		})
+	})
+})
@@ -0,0 +1,89 @@
+package acceptance
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	. "github.com/technopolitica/open-transit/test/acceptance/matchers"
+	"github.com/technopolitica/open-transit/test/acceptance/testutils"
+)
+
+var _ = Describe("/reports", func() {
+	Context("unauthenticated", func() {
+		When("user attempts to submit a valid report", func() {
+			AssertHasStandardUnauthorizedResponse(func() *http.Response {
+				return apiClient.SubmitReports([]any{testutils.MakeValidReport(testutils.GenerateRandomUUID())})
+			})
+		})
+	})
+
+	Context("authenticated as provider", func() {
+		var providerID uuid.UUID
+		BeforeEach(func() {
+			providerID = testutils.GenerateRandomUUID()
+			apiClient.AuthenticateAsProvider(providerID)
+		})
+
+		When("provider submits a valid report", func() {
+			It("returns HTTP 201 Created status w/ a bulk success response", func() {
+				Expect(apiClient.SubmitReports([]any{testutils.MakeValidReport(providerID)})).To(SatisfyAll(
+					HaveHTTPStatus(http.StatusCreated),
+					HaveHTTPBody(MatchJSONObject(MatchKeys(IgnoreExtras, Keys{
+						"success":  Equal(float64(1)),
+						"total":    Equal(float64(1)),
+						"failures": BeEmpty(),
+					}))),
+				))
+			})
+		})
+
+		When("provider submits a mix of valid and invalid reports", func() {
+			It("reports a failure for each invalid row", func() {
+				validReport := testutils.MakeValidReport(providerID)
+				invalidReport := testutils.MakeValidReport(providerID)
+				invalidReport.TripCount = 5
+				Expect(apiClient.SubmitReports([]any{validReport, invalidReport})).To(SatisfyAll(
+					HaveHTTPStatus(http.StatusCreated),
+					HaveHTTPBody(MatchJSONObject(MatchKeys(IgnoreExtras, Keys{
+						"success": Equal(float64(1)),
+						"total":   Equal(float64(2)),
+						"failures": ConsistOf(MatchKeys(IgnoreExtras, Keys{
+							"error":         Equal("bad_param"),
+							"error_details": ConsistOf("trip_count: counts of 10 or fewer must be reported as -1"),
+							"item":          MatchJSONObject(invalidReport),
+						})),
+					}))),
+				))
+			})
+		})
+
+		When("provider submits the same report twice", func() {
+			It("returns a bulk error response w/ already_registered failure", func() {
+				report := testutils.MakeValidReport(providerID)
+				Expect(apiClient.SubmitReports([]any{report})).To(HaveHTTPStatus(http.StatusCreated))
+				Expect(apiClient.SubmitReports([]any{report})).To(HaveHTTPBody(MatchJSONObject(MatchKeys(IgnoreExtras, Keys{
+					"success": Equal(float64(0)),
+					"failures": ConsistOf(MatchKeys(IgnoreExtras, Keys{
+						"error": Equal("already_registered"),
+					})),
+				}))))
+			})
+		})
+
+		When("provider submits a report for another provider", func() {
+			It("returns a bulk error response w/ bad_param failure", func() {
+				report := testutils.MakeValidReport(testutils.MakeUUIDExcluding(providerID))
+				Expect(apiClient.SubmitReports([]any{report})).To(HaveHTTPBody(MatchJSONObject(MatchKeys(IgnoreExtras, Keys{
+					"success": Equal(float64(0)),
+					"failures": ConsistOf(MatchKeys(IgnoreExtras, Keys{
+						"error":         Equal("bad_param"),
+						"error_details": ConsistOf("provider_id: not allowed to submit reports for another provider"),
+					})),
+				}))))
+			})
+		})
+	})
+})
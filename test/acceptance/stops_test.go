@@ -0,0 +1,157 @@
+package acceptance
+
+import (
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	"github.com/technopolitica/open-transit/internal/domain"
+	. "github.com/technopolitica/open-transit/test/acceptance/matchers"
+	"github.com/technopolitica/open-transit/test/acceptance/testutils"
+)
+
+var _ = Describe("/stops", func() {
+	Context("unauthenticated", func() {
+		When("user attempts to register a valid stop", func() {
+			AssertHasStandardUnauthorizedResponse(func() *http.Response {
+				return apiClient.RegisterStops([]any{testutils.MakeValidStop()})
+			})
+		})
+
+		When("user attempts to list stops", func() {
+			AssertHasStandardUnauthorizedResponse(func() *http.Response {
+				return apiClient.ListStops()
+			})
+		})
+	})
+
+	Context("authenticated as provider", func() {
+		BeforeEach(func() {
+			apiClient.AuthenticateAsProvider(testutils.GenerateRandomUUID())
+		})
+
+		It("is not allowed to register stops", func() {
+			Expect(apiClient.RegisterStops([]any{testutils.MakeValidStop()})).To(HaveHTTPStatus(http.StatusForbidden))
+		})
+
+		It("is not allowed to update stops", func() {
+			Expect(apiClient.UpdateStops([]any{testutils.MakeValidStop()})).To(HaveHTTPStatus(http.StatusForbidden))
+		})
+
+		It("reads stops registered by the agency", func() {
+			stop := testutils.MakeValidStop()
+			apiClient.AuthenticateAsAgency()
+			Expect(apiClient.RegisterStops([]any{stop})).To(HaveHTTPStatus(http.StatusCreated))
+
+			apiClient.AuthenticateAsProvider(testutils.GenerateRandomUUID())
+			Expect(apiClient.GetStop(stop.StopID.String())).To(SatisfyAll(
+				HaveHTTPStatus(http.StatusOK),
+				HaveHTTPBody(MatchJSONObject(MatchKeys(IgnoreExtras, Keys{
+					"stops": ConsistOf(MatchJSONObject(stop)),
+				}))),
+			))
+		})
+	})
+
+	Context("authenticated as agency", func() {
+		BeforeEach(func() {
+			apiClient.AuthenticateAsAgency()
+		})
+
+		When("a valid stop is registered", Ordered, func() {
+			var stop *domain.Stop
+			BeforeAll(func() {
+				stop = testutils.MakeValidStop()
+			})
+
+			It("returns HTTP 201 Created status", func() {
+				Expect(apiClient.RegisterStops([]any{stop})).To(HaveHTTPStatus(http.StatusCreated))
+			})
+
+			It("returns the stop when fetched by stop_id", func() {
+				Expect(apiClient.GetStop(stop.StopID.String())).To(SatisfyAll(
+					HaveHTTPStatus(http.StatusOK),
+					HaveHTTPBody(MatchJSONObject(MatchKeys(IgnoreExtras, Keys{
+						"stops": ConsistOf(MatchJSONObject(stop)),
+					}))),
+				))
+			})
+
+			It("includes the stop when listing stops", func() {
+				Expect(apiClient.ListStops()).To(HaveHTTPBody(MatchJSONObject(MatchKeys(IgnoreExtras, Keys{
+					"version": Equal("2.0.0"),
+					"stops":   ContainElement(MatchJSONObject(stop)),
+				}))))
+			})
+
+			It("rejects registering the same stop again", func() {
+				Expect(apiClient.RegisterStops([]any{stop})).To(HaveHTTPBody(MatchJSONObject(MatchKeys(IgnoreExtras, Keys{
+					"success": Equal(float64(0)),
+					"failures": ConsistOf(MatchKeys(IgnoreExtras, Keys{
+						"error": Equal("already_registered"),
+					})),
+				}))))
+			})
+
+			It("updates the stop", func() {
+				stop.NumVehiclesAvailable = domain.VehicleTypeCounts{domain.VehicleTypeScooterStanding: 1}
+				stop.Status.IsRenting = false
+				Expect(apiClient.UpdateStops([]any{stop})).To(HaveHTTPStatus(http.StatusOK))
+				Expect(apiClient.GetStop(stop.StopID.String())).To(HaveHTTPBody(MatchJSONObject(MatchKeys(IgnoreExtras, Keys{
+					"stops": ConsistOf(MatchJSONObject(stop)),
+				}))))
+			})
+		})
+
+		When("a stop is registered w/ a parent stop that doesn't exist", func() {
+			It("returns a bulk error response w/ bad_param failure", func() {
+				stop := testutils.MakeValidStop()
+				parentStop := testutils.GenerateRandomUUID()
+				stop.ParentStop = &parentStop
+				Expect(apiClient.RegisterStops([]any{stop})).To(HaveHTTPBody(MatchJSONObject(MatchKeys(IgnoreExtras, Keys{
+					"success": Equal(float64(0)),
+					"failures": ConsistOf(MatchKeys(IgnoreExtras, Keys{
+						"error": Equal("bad_param"),
+					})),
+				}))))
+			})
+		})
+
+		When("an unregistered stop is updated", func() {
+			It("returns a bulk error response w/ unregistered failure", func() {
+				Expect(apiClient.UpdateStops([]any{testutils.MakeValidStop()})).To(HaveHTTPBody(MatchJSONObject(MatchKeys(IgnoreExtras, Keys{
+					"success": Equal(float64(0)),
+					"failures": ConsistOf(MatchKeys(IgnoreExtras, Keys{
+						"error": Equal("unregistered"),
+					})),
+				}))))
+			})
+		})
+
+		When("an unregistered stop is fetched", func() {
+			It("returns HTTP 404 Not Found status", func() {
+				Expect(apiClient.GetStop(testutils.GenerateRandomUUID().String())).To(HaveHTTPStatus(http.StatusNotFound))
+			})
+		})
+	})
+
+	When("a provider attempts to update another provider's stop", func() {
+		It("returns HTTP 403 Forbidden and leaves the stop unchanged", func() {
+			stop := testutils.MakeValidStop()
+			stop.ProviderID = testutils.GenerateRandomUUID()
+			apiClient.AuthenticateAsAgency()
+			Expect(apiClient.RegisterStops([]any{stop})).To(HaveHTTPStatus(http.StatusCreated))
+
+			otherProviderID := testutils.GenerateRandomUUID()
+			apiClient.AuthenticateAsProvider(otherProviderID)
+			takeover := *stop
+			takeover.ProviderID = otherProviderID
+			takeover.Status.IsRenting = false
+			Expect(apiClient.UpdateStops([]any{takeover})).To(HaveHTTPStatus(http.StatusForbidden))
+			Expect(apiClient.GetStop(stop.StopID.String())).To(HaveHTTPBody(MatchJSONObject(MatchKeys(IgnoreExtras, Keys{
+				"stops": ConsistOf(MatchJSONObject(stop)),
+			}))))
+		})
+	})
+})
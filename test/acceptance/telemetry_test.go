@@ -0,0 +1,107 @@
+package acceptance
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	"github.com/technopolitica/open-transit/internal/domain"
+	. "github.com/technopolitica/open-transit/test/acceptance/matchers"
+	"github.com/technopolitica/open-transit/test/acceptance/testutils"
+)
+
+var _ = Describe("/telemetry", func() {
+	Context("unauthenticated", func() {
+		When("user attempts to submit valid telemetry", func() {
+			var validTelemetry *domain.Telemetry
+			BeforeEach(func() {
+				vehicle := testutils.MakeValidVehicle(testutils.GenerateRandomUUID())
+				validTelemetry = testutils.MakeValidTelemetry(vehicle)
+			})
+
+			AssertHasStandardUnauthorizedResponse(func() *http.Response {
+				return apiClient.SubmitTelemetry([]any{validTelemetry})
+			})
+		})
+	})
+
+	Context("authenticated as provider", func() {
+		var providerID uuid.UUID
+		var vehicle *domain.Vehicle
+		BeforeEach(func() {
+			providerID = testutils.GenerateRandomUUID()
+			apiClient.AuthenticateAsProvider(providerID)
+			vehicle = testutils.MakeValidVehicle(providerID)
+			Expect(apiClient.RegisterVehicles([]any{vehicle})).To(HaveHTTPStatus(http.StatusCreated))
+		})
+
+		When("provider submits valid telemetry for a vehicle they own", func() {
+			It("returns HTTP 201 Created status w/ a bulk success response", func() {
+				telemetry := []any{testutils.MakeValidTelemetry(vehicle), testutils.MakeValidTelemetry(vehicle)}
+				Expect(apiClient.SubmitTelemetry(telemetry)).To(SatisfyAll(
+					HaveHTTPStatus(http.StatusCreated),
+					HaveHTTPBody(MatchJSONObject(MatchKeys(IgnoreExtras, Keys{
+						"success":  Equal(float64(2)),
+						"total":    Equal(float64(2)),
+						"failures": BeEmpty(),
+					}))),
+				))
+			})
+		})
+
+		When("provider submits telemetry for an unregistered vehicle", func() {
+			It("returns a bulk error response w/ unregistered failure", func() {
+				telemetry := testutils.MakeValidTelemetry(testutils.MakeValidVehicle(providerID))
+				Expect(apiClient.SubmitTelemetry([]any{telemetry})).To(SatisfyAll(
+					HaveHTTPStatus(http.StatusBadRequest),
+					HaveHTTPBody(MatchJSONObject(MatchKeys(IgnoreExtras, Keys{
+						"success": Equal(float64(0)),
+						"total":   Equal(float64(1)),
+						"failures": ConsistOf(MatchKeys(IgnoreExtras, Keys{
+							"error": Equal("unregistered"),
+							"item":  MatchJSONObject(telemetry),
+						})),
+					}))),
+				))
+			})
+		})
+
+		When("provider submits telemetry for a vehicle owned by another provider", func() {
+			var telemetry *domain.Telemetry
+			BeforeEach(func() {
+				otherProvidersID := testutils.MakeUUIDExcluding(providerID)
+				otherProvidersVehicle := testutils.MakeValidVehicle(otherProvidersID)
+				apiClient.AuthenticateAsProvider(otherProvidersID)
+				Expect(apiClient.RegisterVehicles([]any{otherProvidersVehicle})).To(HaveHTTPStatus(http.StatusCreated))
+				apiClient.AuthenticateAsProvider(providerID)
+
+				telemetry = testutils.MakeValidTelemetry(otherProvidersVehicle)
+				telemetry.ProviderID = providerID
+			})
+
+			It("returns a bulk error response w/ unregistered failure that does not confirm the existence of the vehicle", func() {
+				Expect(apiClient.SubmitTelemetry([]any{telemetry})).To(HaveHTTPBody(MatchJSONObject(MatchKeys(IgnoreExtras, Keys{
+					"success": Equal(float64(0)),
+					"failures": ConsistOf(MatchKeys(IgnoreExtras, Keys{
+						"error": Equal("unregistered"),
+					})),
+				}))))
+			})
+		})
+
+		When("provider submits the same telemetry twice", func() {
+			It("returns a bulk error response w/ already_registered failure", func() {
+				telemetry := testutils.MakeValidTelemetry(vehicle)
+				Expect(apiClient.SubmitTelemetry([]any{telemetry})).To(HaveHTTPStatus(http.StatusCreated))
+				Expect(apiClient.SubmitTelemetry([]any{telemetry})).To(HaveHTTPBody(MatchJSONObject(MatchKeys(IgnoreExtras, Keys{
+					"success": Equal(float64(0)),
+					"failures": ConsistOf(MatchKeys(IgnoreExtras, Keys{
+						"error": Equal("already_registered"),
+					})),
+				}))))
+			})
+		})
+	})
+})
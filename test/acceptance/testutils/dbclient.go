@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os/exec"
+	"strings"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/onsi/ginkgo/v2"
@@ -23,7 +24,8 @@ func migrateToLatest(ctx context.Context, migrateBinaryPath string, dbURL string
 	}
 	select {
 	case <-session.Exited:
-		if session.ExitCode() != 0 {
+		// 3 means that the database was already up to date.
+		if session.ExitCode() != 0 && session.ExitCode() != 3 {
 			err = fmt.Errorf("exited with non-zero code %d", session.ExitCode())
 			return
 		}
@@ -89,6 +91,19 @@ func (client DBClient) CreateTestDB(ctx context.Context) (testDB TestDB, err err
 	return
 }
 
+// CreateEmptyDB creates a database without any migrations applied.
+func (client DBClient) CreateEmptyDB(ctx context.Context) (testDB TestDB, err error) {
+	testDB.Name = GenerateRandomUUID().String()
+	_, err = client.conn.Exec(ctx, fmt.Sprintf(`CREATE DATABASE "%s"`, testDB.Name))
+	if err != nil {
+		err = fmt.Errorf("failed to create database: %w", err)
+		return
+	}
+	config := client.conn.Config().Copy()
+	testDB.ConnectionString = fmt.Sprintf("%s/%s?sslmode=disable", strings.TrimSuffix(connectionString(config.Config), "?sslmode=disable"), testDB.Name)
+	return
+}
+
 func (client DBClient) CleanupTestDB(ctx context.Context, testDBName string) (err error) {
 	_, err = client.conn.Exec(ctx, fmt.Sprintf(`DROP DATABASE IF EXISTS "%s"`, testDBName))
 	if err != nil {
@@ -1,31 +1,47 @@
 package testutils
 
 import (
+	"bytes"
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
+	"encoding/json"
 	"encoding/pem"
 	"fmt"
+	"io"
 	"net"
 	"net/http"
 	"net/url"
 	"os"
 	"os/exec"
+	"strings"
 	"time"
 
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	. "github.com/onsi/ginkgo/v2"
 	"github.com/onsi/gomega/gexec"
 )
 
 type APIServer struct {
-	BaseURL    *url.URL
-	PrivateKey *rsa.PrivateKey
-	session    *gexec.Session
+	BaseURL *url.URL
+	// AdminURL is the base URL of the operational endpoints.
+	AdminURL           *url.URL
+	PrivateKey         *rsa.PrivateKey
+	HTTPClient         *http.Client
+	session            *gexec.Session
+	binaryPath         string
+	dbConnectionString string
 }
 
 const rsa256BitSize = 128 * 8
 
+// MaxBodyBytes is the request body limit the server is started with.
+const MaxBodyBytes = 1 << 20
+
 func writePublicKeyFile(publicKey *rsa.PublicKey) (filePath string, err error) {
 	file, err := os.CreateTemp("", "open-transit-public-key*.pem")
 	if err != nil {
@@ -38,6 +54,25 @@ func writePublicKeyFile(publicKey *rsa.PublicKey) (filePath string, err error) {
 	return
 }
 
+func writeSigningKeyFile() (filePath string, err error) {
+	signingKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return
+	}
+	serializedKey, err := x509.MarshalPKCS8PrivateKey(signingKey)
+	if err != nil {
+		return
+	}
+	file, err := os.CreateTemp("", "open-transit-signing-key*.pem")
+	if err != nil {
+		return
+	}
+	defer file.Close()
+	err = pem.Encode(file, &pem.Block{Type: "PRIVATE KEY", Bytes: serializedKey})
+	filePath = file.Name()
+	return
+}
+
 func findOpenPort() (addr *net.TCPAddr, err error) {
 	addr, err = net.ResolveTCPAddr("tcp", "localhost:0")
 	if err != nil {
@@ -77,7 +112,7 @@ func (server APIServer) waitToAcceptConnections(ctx context.Context) (err error)
 
 func (server APIServer) pingHealthEndpoint() (err error) {
 	healthEndpoint := server.BaseURL.JoinPath("health")
-	res, err := http.Get(healthEndpoint.String())
+	res, err := server.HTTPClient.Get(healthEndpoint.String())
 	if err == nil && res.StatusCode != http.StatusOK {
 		err = fmt.Errorf("got unexpected http status code in response: %s", res.Status)
 	}
@@ -85,6 +120,21 @@ func (server APIServer) pingHealthEndpoint() (err error) {
 }
 
 func StartAPIServer(ctx context.Context, serverBinaryPath string, dbConnectionString string) (server APIServer, err error) {
+	return startAPIServer(ctx, serverBinaryPath, dbConnectionString, "http", http.DefaultClient)
+}
+
+// StartTLSServer starts another server against the same database that only accepts HTTPS
+// connections and lets clients authenticate with certificates issued by the fixture's CA.
+func (server APIServer) StartTLSServer(ctx context.Context, fixture TLSFixture) (APIServer, error) {
+	return startAPIServer(
+		ctx, server.binaryPath, server.dbConnectionString, "https", fixture.HTTPClient(false),
+		"-tls-cert", fixture.ServerCertFile,
+		"-tls-key", fixture.ServerKeyFile,
+		"-tls-client-ca", fixture.CAFile,
+	)
+}
+
+func startAPIServer(ctx context.Context, serverBinaryPath string, dbConnectionString string, scheme string, httpClient *http.Client, args ...string) (server APIServer, err error) {
 	privateKey, err := rsa.GenerateKey(rand.Reader, rsa256BitSize)
 	if err != nil {
 		err = fmt.Errorf("failed to generate private/public key pair: %w", err)
@@ -95,37 +145,158 @@ func StartAPIServer(ctx context.Context, serverBinaryPath string, dbConnectionSt
 		err = fmt.Errorf("failed to write public key file: %w", err)
 		return
 	}
+	signingKeyFilePath, err := writeSigningKeyFile()
+	if err != nil {
+		err = fmt.Errorf("failed to write token signing key file: %w", err)
+		return
+	}
 
 	addr, err := findOpenPort()
 	if err != nil {
 		err = fmt.Errorf("failed to find open port: %w", err)
 		return
 	}
+	adminAddr, err := findOpenPort()
+	if err != nil {
+		err = fmt.Errorf("failed to find open admin port: %w", err)
+		return
+	}
 	serverCmd := exec.Command(
 		serverBinaryPath,
-		"-port", fmt.Sprint(addr.Port),
-		"-db-url", dbConnectionString,
-		"-public-key", fmt.Sprintf("file://%s", publicKeyFilePath),
+		append([]string{
+			"-port", fmt.Sprint(addr.Port),
+			"-admin-port", fmt.Sprint(adminAddr.Port),
+			"-db-url", dbConnectionString,
+			"-public-key", fmt.Sprintf("file://%s", publicKeyFilePath),
+			"-token-signing-key", signingKeyFilePath,
+			"-max-body-bytes", fmt.Sprint(MaxBodyBytes),
+		}, args...)...,
 	)
 	session, err := gexec.Start(serverCmd, GinkgoWriter, GinkgoWriter)
 	if err != nil {
 		err = fmt.Errorf("failed to start server: %w", err)
 		return
 	}
-	baseURL, err := url.Parse(fmt.Sprintf("http://%s", addr.String()))
+	baseURL, err := url.Parse(fmt.Sprintf("%s://%s", scheme, addr.String()))
 	if err != nil {
 		err = fmt.Errorf("failed to parse base URL: %w", err)
 		return
 	}
+	adminURL, err := url.Parse(fmt.Sprintf("http://%s", adminAddr.String()))
+	if err != nil {
+		err = fmt.Errorf("failed to parse admin URL: %w", err)
+		return
+	}
 	server = APIServer{
-		PrivateKey: privateKey,
-		BaseURL:    baseURL,
-		session:    session,
+		PrivateKey:         privateKey,
+		BaseURL:            baseURL,
+		AdminURL:           adminURL,
+		HTTPClient:         httpClient,
+		session:            session,
+		binaryPath:         serverBinaryPath,
+		dbConnectionString: dbConnectionString,
 	}
 	err = server.waitToAcceptConnections(ctx)
 	return
 }
 
+// RequestLog returns the line the server logged for the request with requestID, or nil if it hasn't
+// been logged yet.
+func (server APIServer) RequestLog(requestID string) map[string]any {
+	for _, line := range bytes.Split(server.session.Err.Contents(), []byte("\n")) {
+		var entry map[string]any
+		if json.Unmarshal(line, &entry) != nil {
+			continue
+		}
+		if entry["msg"] == "request" && entry["request_id"] == requestID {
+			return entry
+		}
+	}
+	return nil
+}
+
+// Metrics returns the metrics the server exports in the Prometheus text format.
+func (server APIServer) Metrics() (metrics string, err error) {
+	res, err := http.Get(server.AdminURL.JoinPath("metrics").String())
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		err = fmt.Errorf("unexpected status %s", res.Status)
+		return
+	}
+	body, err := io.ReadAll(res.Body)
+	metrics = string(body)
+	return
+}
+
+// ExecSQL runs sql in the server's database.
+func (server APIServer) ExecSQL(ctx context.Context, sql string, args ...any) (err error) {
+	conn, err := pgx.Connect(ctx, server.dbConnectionString)
+	if err != nil {
+		err = fmt.Errorf("failed to connect to database: %w", err)
+		return
+	}
+	defer conn.Close(ctx)
+	_, err = conn.Exec(ctx, sql, args...)
+	return
+}
+
 func (server APIServer) Terminate() {
 	server.session.Terminate().Wait()
 }
+
+func (server APIServer) runCommand(args ...string) (output string, err error) {
+	args = append([]string{"-db-url", server.dbConnectionString}, args...)
+	session, err := gexec.Start(exec.Command(server.binaryPath, args...), GinkgoWriter, GinkgoWriter)
+	if err != nil {
+		err = fmt.Errorf("failed to run command: %w", err)
+		return
+	}
+	session.Wait(10 * time.Second)
+	if session.ExitCode() != 0 {
+		err = fmt.Errorf("exited with non-zero code %d", session.ExitCode())
+		return
+	}
+	output = string(session.Out.Contents())
+	return
+}
+
+// CreateProviderCredential creates client credentials for providerID with the credentials
+// subcommand. An empty scope grants every provider scope.
+func (server APIServer) CreateProviderCredential(providerID uuid.UUID, scope string) (clientID string, clientSecret string, err error) {
+	args := []string{"credentials", "create", "-provider-id", providerID.String()}
+	if scope != "" {
+		args = append(args, "-scope", scope)
+	}
+	output, err := server.runCommand(args...)
+	if err != nil {
+		return
+	}
+	for _, line := range strings.Split(output, "\n") {
+		key, value, _ := strings.Cut(line, ": ")
+		switch key {
+		case "client_id":
+			clientID = value
+		case "client_secret":
+			clientSecret = value
+		}
+	}
+	if clientID == "" || clientSecret == "" {
+		err = fmt.Errorf("unexpected output: %s", output)
+	}
+	return
+}
+
+func (server APIServer) RevokeProviderCredential(clientID string) (err error) {
+	_, err = server.runCommand("credentials", "revoke", "-client-id", clientID)
+	return
+}
+
+// BindClientCertificate lets the holder of a client certificate with subject authenticate as
+// providerID with every provider scope.
+func (server APIServer) BindClientCertificate(providerID uuid.UUID, subject string) (err error) {
+	_, err = server.runCommand("credentials", "create", "-provider-id", providerID.String(), "-tls-subject", subject)
+	return
+}
@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strings"
+	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
@@ -24,10 +26,17 @@ type TestClient struct {
 	baseURL    url.URL
 	authToken  string
 	signingKey rsa.PrivateKey
+	httpClient *http.Client
 }
 
 func NewTestClient(baseURL url.URL, signingKey rsa.PrivateKey) *TestClient {
-	return &TestClient{baseURL: baseURL, signingKey: signingKey}
+	return &TestClient{baseURL: baseURL, signingKey: signingKey, httpClient: http.DefaultClient}
+}
+
+// NewTestClientWithHTTPClient creates a client that sends its requests with httpClient, for example
+// to present a TLS client certificate.
+func NewTestClientWithHTTPClient(baseURL url.URL, signingKey rsa.PrivateKey, httpClient *http.Client) *TestClient {
+	return &TestClient{baseURL: baseURL, signingKey: signingKey, httpClient: httpClient}
 }
 
 func (client *TestClient) endpoint(path ...string) *url.URL {
@@ -51,7 +60,18 @@ func (client *TestClient) AuthenticateWithUnsignedJWT() {
 	})
 }
 
+// AuthenticateAsProvider registers providerID as an active provider, unless it is already
+// registered, and authenticates as that provider.
 func (client *TestClient) AuthenticateAsProvider(providerID uuid.UUID) {
+	client.AuthenticateAsAdmin()
+	res := client.CreateProvider(MakeValidProvider(providerID))
+	res.Body.Close()
+	Expect(res.StatusCode).To(BeElementOf(http.StatusCreated, http.StatusConflict))
+	client.AuthenticateAsProviderWithoutRegistering(providerID)
+}
+
+// AuthenticateAsProviderWithoutRegistering authenticates as providerID without registering it first.
+func (client *TestClient) AuthenticateAsProviderWithoutRegistering(providerID uuid.UUID) {
 	client.authenticateWithAuthToken(jwt.SigningMethodRS256, &client.signingKey, struct {
 		jwt.RegisteredClaims
 		Provider uuid.UUID `json:"provider_id"`
@@ -60,6 +80,75 @@ func (client *TestClient) AuthenticateAsProvider(providerID uuid.UUID) {
 	})
 }
 
+// AuthenticateAsDataProvider registers dataProviderID as an active provider, unless it is already
+// registered, and authenticates as a data provider that submits data on behalf of the providers
+// that have delegated to dataProviderID.
+func (client *TestClient) AuthenticateAsDataProvider(dataProviderID uuid.UUID) {
+	client.AuthenticateAsAdmin()
+	res := client.CreateProvider(MakeValidProvider(dataProviderID))
+	res.Body.Close()
+	Expect(res.StatusCode).To(BeElementOf(http.StatusCreated, http.StatusConflict))
+	client.AuthenticateAsDataProviderWithoutRegistering(dataProviderID)
+}
+
+// AuthenticateAsDataProviderWithoutRegistering authenticates as a data provider without
+// registering it first.
+func (client *TestClient) AuthenticateAsDataProviderWithoutRegistering(dataProviderID uuid.UUID) {
+	client.authenticateWithAuthToken(jwt.SigningMethodRS256, &client.signingKey, struct {
+		jwt.RegisteredClaims
+		Provider uuid.UUID `json:"provider_id"`
+		Role     string    `json:"role"`
+	}{
+		Provider: dataProviderID,
+		Role:     "data_provider",
+	})
+}
+
+type roleClaims struct {
+	jwt.RegisteredClaims
+	Role  string `json:"role"`
+	Scope string `json:"scope,omitempty"`
+}
+
+func (client *TestClient) AuthenticateAsAgency() {
+	client.authenticateWithAuthToken(jwt.SigningMethodRS256, &client.signingKey, roleClaims{Role: "agency"})
+}
+
+func (client *TestClient) AuthenticateAsAdmin() {
+	client.authenticateWithAuthToken(jwt.SigningMethodRS256, &client.signingKey, roleClaims{Role: "admin"})
+}
+
+// AuthenticateWithRoleAndScope authenticates with a token limited to the given space-delimited scopes.
+func (client *TestClient) AuthenticateWithRoleAndScope(role string, scope string) {
+	client.authenticateWithAuthToken(jwt.SigningMethodRS256, &client.signingKey, roleClaims{Role: role, Scope: scope})
+}
+
+// AuthenticateWithoutRole authenticates with a validly signed token that carries neither a role
+// nor a provider_id claim.
+func (client *TestClient) AuthenticateWithoutRole() {
+	client.authenticateWithAuthToken(jwt.SigningMethodRS256, &client.signingKey, jwt.RegisteredClaims{})
+}
+
+// AuthenticateWithToken authenticates with a token obtained from the server, such as one issued by
+// the token endpoint.
+func (client *TestClient) AuthenticateWithToken(token string) {
+	client.authToken = token
+}
+
+// RequestToken posts form to the token endpoint, authenticating with HTTP Basic credentials unless
+// clientID is empty.
+func (client *TestClient) RequestToken(clientID string, clientSecret string, form url.Values) (res *http.Response) {
+	req, err := http.NewRequest("POST", client.endpoint("/oauth/token").String(), strings.NewReader(form.Encode()))
+	Expect(err).NotTo(HaveOccurred())
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if clientID != "" {
+		req.SetBasicAuth(url.QueryEscape(clientID), url.QueryEscape(clientSecret))
+	}
+	res, err = client.httpClient.Do(req)
+	Expect(err).NotTo(HaveOccurred())
+	return
+}
+
 func (client *TestClient) Unauthenticate() {
 	client.authToken = ""
 }
@@ -80,7 +169,7 @@ func (client *TestClient) sendRequestWithDefaultHeaders(method string, endpoint
 	}
 	req.Header.Set("Content-Type", "application/vnd.mds+json")
 
-	res, err = http.DefaultClient.Do(req)
+	res, err = client.httpClient.Do(req)
 	Expect(err).NotTo(HaveOccurred())
 	return
 }
@@ -97,6 +186,48 @@ func (client *TestClient) GetVehicle(vehicleID string) (response *http.Response)
 	return client.sendRequestWithDefaultHeaders("GET", client.endpoint("/vehicles", vehicleID), nil)
 }
 
+func (client *TestClient) ListVehiclesForProvider(providerID string, options ListVehiclesOptions) (response *http.Response) {
+	endpoint := client.endpoint("/vehicles")
+	endpoint.RawQuery = url.Values{"provider_id": {providerID}}.Encode()
+	return client.listPaginated(endpoint, options)
+}
+
+func (client *TestClient) SubmitEvents(events any) (response *http.Response) {
+	return client.sendRequestWithDefaultHeaders("POST", client.endpoint("/events"), events)
+}
+
+func (client *TestClient) SubmitTelemetry(telemetry any) (response *http.Response) {
+	return client.sendRequestWithDefaultHeaders("POST", client.endpoint("/telemetry"), telemetry)
+}
+
+func (client *TestClient) SubmitTrips(trips any) (response *http.Response) {
+	return client.sendRequestWithDefaultHeaders("POST", client.endpoint("/trips"), trips)
+}
+
+func (client *TestClient) RegisterStops(stops any) (response *http.Response) {
+	return client.sendRequestWithDefaultHeaders("POST", client.endpoint("/stops"), stops)
+}
+
+func (client *TestClient) UpdateStops(stops any) (response *http.Response) {
+	return client.sendRequestWithDefaultHeaders("PUT", client.endpoint("/stops"), stops)
+}
+
+func (client *TestClient) GetStop(stopID string) (response *http.Response) {
+	return client.sendRequestWithDefaultHeaders("GET", client.endpoint("/stops", stopID), nil)
+}
+
+func (client *TestClient) ListStops() (response *http.Response) {
+	return client.sendRequestWithDefaultHeaders("GET", client.endpoint("/stops"), nil)
+}
+
+func (client *TestClient) SubmitReports(reports any) (response *http.Response) {
+	return client.sendRequestWithDefaultHeaders("POST", client.endpoint("/reports"), reports)
+}
+
+func (client *TestClient) GetVehicleStatus(vehicleID string) (response *http.Response) {
+	return client.sendRequestWithDefaultHeaders("GET", client.endpoint("/vehicles/status", vehicleID), nil)
+}
+
 func (client *TestClient) Get(path string) (response *http.Response) {
 	uri, err := url.ParseRequestURI(path)
 	if err != nil {
@@ -107,13 +238,116 @@ func (client *TestClient) Get(path string) (response *http.Response) {
 	return client.sendRequestWithDefaultHeaders("GET", endpoint, nil)
 }
 
+func (client *TestClient) getWithQuery(endpoint *url.URL, query url.Values) (response *http.Response) {
+	endpoint.RawQuery = query.Encode()
+	return client.sendRequestWithDefaultHeaders("GET", endpoint, nil)
+}
+
+func (client *TestClient) ListProviderTrips(endTime string) (response *http.Response) {
+	return client.getWithQuery(client.endpoint("/provider/trips"), url.Values{"end_time": {endTime}})
+}
+
+func (client *TestClient) ListProviderTelemetry(telemetryTime string) (response *http.Response) {
+	return client.getWithQuery(client.endpoint("/provider/telemetry"), url.Values{"telemetry_time": {telemetryTime}})
+}
+
+func (client *TestClient) ListHistoricalEvents(eventTime string) (response *http.Response) {
+	return client.getWithQuery(client.endpoint("/provider/events/historical"), url.Values{"event_time": {eventTime}})
+}
+
+func (client *TestClient) ListRecentEvents(startTime time.Time, endTime time.Time) (response *http.Response) {
+	return client.getWithQuery(client.endpoint("/provider/events/recent"), url.Values{
+		"start_time": {fmt.Sprint(startTime.UnixMilli())},
+		"end_time":   {fmt.Sprint(endTime.UnixMilli())},
+	})
+}
+
+func (client *TestClient) ListProviderReports() (response *http.Response) {
+	return client.sendRequestWithDefaultHeaders("GET", client.endpoint("/provider/reports"), nil)
+}
+
+func (client *TestClient) CreatePolicy(policy any) (response *http.Response) {
+	return client.sendRequestWithDefaultHeaders("POST", client.endpoint("/policies"), policy)
+}
+
+func (client *TestClient) UpdatePolicy(policyID string, policy any) (response *http.Response) {
+	return client.sendRequestWithDefaultHeaders("PUT", client.endpoint("/policies", policyID), policy)
+}
+
+func (client *TestClient) PublishPolicy(policyID string) (response *http.Response) {
+	return client.sendRequestWithDefaultHeaders("POST", client.endpoint("/policies", policyID, "publish"), nil)
+}
+
+func (client *TestClient) GetPolicy(policyID string) (response *http.Response) {
+	return client.sendRequestWithDefaultHeaders("GET", client.endpoint("/policies", policyID), nil)
+}
+
+func (client *TestClient) ListPolicies() (response *http.Response) {
+	return client.sendRequestWithDefaultHeaders("GET", client.endpoint("/policies"), nil)
+}
+
+func (client *TestClient) UpdateRequirements(requirements any) (response *http.Response) {
+	return client.sendRequestWithDefaultHeaders("PUT", client.endpoint("/requirements"), requirements)
+}
+
+func (client *TestClient) GetRequirements() (response *http.Response) {
+	return client.sendRequestWithDefaultHeaders("GET", client.endpoint("/requirements"), nil)
+}
+
+func (client *TestClient) CreateGeography(geography any) (response *http.Response) {
+	return client.sendRequestWithDefaultHeaders("POST", client.endpoint("/geographies"), geography)
+}
+
+func (client *TestClient) GetGeography(geographyID string) (response *http.Response) {
+	return client.sendRequestWithDefaultHeaders("GET", client.endpoint("/geographies", geographyID), nil)
+}
+
+func (client *TestClient) ListGeographies() (response *http.Response) {
+	return client.sendRequestWithDefaultHeaders("GET", client.endpoint("/geographies"), nil)
+}
+
+func (client *TestClient) CreateJurisdiction(jurisdiction any) (response *http.Response) {
+	return client.sendRequestWithDefaultHeaders("POST", client.endpoint("/jurisdictions"), jurisdiction)
+}
+
+func (client *TestClient) UpdateJurisdiction(jurisdictionID string, jurisdiction any) (response *http.Response) {
+	return client.sendRequestWithDefaultHeaders("PUT", client.endpoint("/jurisdictions", jurisdictionID), jurisdiction)
+}
+
+func (client *TestClient) GetJurisdiction(jurisdictionID string, effective *time.Time) (response *http.Response) {
+	query := url.Values{}
+	if effective != nil {
+		query.Set("effective", fmt.Sprint(effective.UnixMilli()))
+	}
+	return client.getWithQuery(client.endpoint("/jurisdictions", jurisdictionID), query)
+}
+
+func (client *TestClient) ListJurisdictions() (response *http.Response) {
+	return client.sendRequestWithDefaultHeaders("GET", client.endpoint("/jurisdictions"), nil)
+}
+
+func (client *TestClient) GetMetricsDiscovery() (response *http.Response) {
+	return client.sendRequestWithDefaultHeaders("GET", client.endpoint("/metrics"), nil)
+}
+
+func (client *TestClient) QueryMetrics(query any) (response *http.Response) {
+	return client.sendRequestWithDefaultHeaders("POST", client.endpoint("/metrics/query"), query)
+}
+
 type ListVehiclesOptions struct {
 	Limit  int
 	Offset int
 }
 
 func (client *TestClient) ListVehicles(options ListVehiclesOptions) (response *http.Response) {
-	url := client.endpoint("/vehicles")
+	return client.listPaginated(client.endpoint("/vehicles"), options)
+}
+
+func (client *TestClient) ListVehicleStatuses(options ListVehiclesOptions) (response *http.Response) {
+	return client.listPaginated(client.endpoint("/vehicles/status"), options)
+}
+
+func (client *TestClient) listPaginated(url *url.URL, options ListVehiclesOptions) (response *http.Response) {
 	query := url.Query()
 	// Default to a limit of 10 so that we can use the zero value of the options struct to make tests a little more readable.
 	if options.Limit == 0 {
@@ -127,3 +361,23 @@ func (client *TestClient) ListVehicles(options ListVehiclesOptions) (response *h
 
 	return client.sendRequestWithDefaultHeaders("GET", url, nil)
 }
+
+func (client *TestClient) CreateProvider(provider any) (response *http.Response) {
+	return client.sendRequestWithDefaultHeaders("POST", client.endpoint("/providers"), provider)
+}
+
+func (client *TestClient) UpdateProvider(providerID string, provider any) (response *http.Response) {
+	return client.sendRequestWithDefaultHeaders("PUT", client.endpoint("/providers", providerID), provider)
+}
+
+func (client *TestClient) DeleteProvider(providerID string) (response *http.Response) {
+	return client.sendRequestWithDefaultHeaders("DELETE", client.endpoint("/providers", providerID), nil)
+}
+
+func (client *TestClient) GetProvider(providerID string) (response *http.Response) {
+	return client.sendRequestWithDefaultHeaders("GET", client.endpoint("/providers", providerID), nil)
+}
+
+func (client *TestClient) ListProviders() (response *http.Response) {
+	return client.sendRequestWithDefaultHeaders("GET", client.endpoint("/providers"), nil)
+}
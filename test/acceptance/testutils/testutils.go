@@ -1,7 +1,9 @@
 package testutils
 
 import (
+	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	. "github.com/onsi/gomega"
@@ -17,6 +19,136 @@ func MakeValidVehicle(provider uuid.UUID) *domain.Vehicle {
 	}
 }
 
+func MakeValidEvent(vehicle *domain.Vehicle, vehicleState domain.VehicleState, eventTypes ...domain.EventType) *domain.Event {
+	return &domain.Event{
+		EventID:      uuid.New(),
+		DeviceID:     vehicle.DeviceID,
+		ProviderID:   vehicle.ProviderID,
+		VehicleState: vehicleState,
+		EventTypes:   eventTypes,
+		Timestamp:    domain.NewTimestamp(time.Now()),
+		Location:     domain.GPS{Lat: 45.5152, Lng: -122.6784},
+	}
+}
+
+func MakeValidTelemetry(vehicle *domain.Vehicle) *domain.Telemetry {
+	return &domain.Telemetry{
+		TelemetryID: uuid.New(),
+		DeviceID:    vehicle.DeviceID,
+		ProviderID:  vehicle.ProviderID,
+		Timestamp:   domain.NewTimestamp(time.Now()),
+		Location:    domain.GPS{Lat: 45.5152, Lng: -122.6784, Heading: 90, Speed: 4.2},
+		JourneyID:   uuid.New(),
+	}
+}
+
+func MakeValidTrip(vehicle *domain.Vehicle) *domain.Trip {
+	endTime := time.Now()
+	startTime := endTime.Add(-15 * time.Minute)
+	return &domain.Trip{
+		TripID:        uuid.New(),
+		DeviceID:      vehicle.DeviceID,
+		ProviderID:    vehicle.ProviderID,
+		JourneyID:     uuid.New(),
+		StartTime:     domain.NewTimestamp(startTime),
+		EndTime:       domain.NewTimestamp(endTime),
+		StartLocation: domain.GPS{Lat: 45.5152, Lng: -122.6784},
+		EndLocation:   domain.GPS{Lat: 45.5231, Lng: -122.6765},
+		Duration:      int(endTime.Sub(startTime).Seconds()),
+		Distance:      1200,
+		Fare:          &domain.Fare{ActualCost: 325, Currency: "USD"},
+	}
+}
+
+func MakeValidStop() *domain.Stop {
+	return &domain.Stop{
+		StopID:       uuid.New(),
+		Name:         "Pioneer Courthouse Square",
+		LastReported: domain.NewTimestamp(time.Now()),
+		Location:     domain.GPS{Lat: 45.5189, Lng: -122.6793},
+		Status: domain.StopStatus{
+			IsInstalled: true,
+			IsRenting:   true,
+			IsReturning: true,
+		},
+		NumVehiclesAvailable: domain.VehicleTypeCounts{domain.VehicleTypeScooterStanding: 4},
+		NumPlacesAvailable:   domain.VehicleTypeCounts{domain.VehicleTypeScooterStanding: 6},
+	}
+}
+
+func MakeValidReport(provider uuid.UUID) *domain.MonthlyReport {
+	return &domain.MonthlyReport{
+		ProviderID:       provider,
+		StartDate:        domain.NewTimestamp(time.Date(2023, time.June, 1, 0, 0, 0, 0, time.UTC)),
+		Duration:         domain.ReportDuration,
+		SpecialGroupType: domain.SpecialGroupTypeLowIncome,
+		GeographyID:      uuid.New(),
+		VehicleType:      domain.VehicleTypeScooterStanding,
+		TripCount:        1240,
+		RiderCount:       -1,
+	}
+}
+
+func MakeValidPolicy() *domain.Policy {
+	maximum := 15.0
+	return &domain.Policy{
+		PolicyID:    uuid.New(),
+		Name:        "Downtown speed limit",
+		Description: "Scooters may not exceed 15 mph downtown",
+		StartDate:   domain.NewTimestamp(time.Now().Add(-time.Hour)),
+		Rules: []domain.Rule{
+			{
+				RuleID:       uuid.New(),
+				Name:         "Speed limit",
+				RuleType:     domain.RuleTypeSpeed,
+				RuleUnits:    domain.RuleUnitsMph,
+				Geographies:  []uuid.UUID{uuid.New()},
+				States:       map[domain.VehicleState][]domain.EventType{domain.VehicleStateOnTrip: {}},
+				VehicleTypes: []domain.VehicleType{domain.VehicleTypeScooterStanding},
+				Maximum:      &maximum,
+			},
+		},
+	}
+}
+
+func MakeValidGeography() *domain.Geography {
+	return &domain.Geography{
+		GeographyID:   uuid.New(),
+		Name:          "Downtown",
+		GeographyType: "policy_zone",
+		GeographyJSON: domain.FeatureCollection{
+			Type: "FeatureCollection",
+			Features: []domain.Feature{
+				{
+					Type: "Feature",
+					Geometry: &domain.Geometry{
+						Type:        "Polygon",
+						Coordinates: json.RawMessage(`[[[-122.68,45.51],[-122.66,45.51],[-122.66,45.53],[-122.68,45.51]]]`),
+					},
+				},
+			},
+		},
+	}
+}
+
+func MakeValidJurisdiction(geographyID uuid.UUID) *domain.Jurisdiction {
+	return &domain.Jurisdiction{
+		JurisdictionID: uuid.New(),
+		AgencyKey:      "pdx",
+		AgencyName:     "City of Portland",
+		GeographyID:    geographyID,
+	}
+}
+
+func MakeValidProvider(providerID uuid.UUID) *domain.Provider {
+	return &domain.Provider{
+		ProviderID:   providerID,
+		ProviderName: "Scoot",
+		URL:          "https://example.com",
+		Contact:      "ops@example.com",
+	}
+}
+
 func GenerateRandomUUID() uuid.UUID {
 	id, err := uuid.NewRandom()
 	Expect(err).NotTo(HaveOccurred())
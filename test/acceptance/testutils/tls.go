@@ -0,0 +1,144 @@
+package testutils
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"time"
+)
+
+// TLSFixture is a throwaway CA with a server certificate for localhost and a client certificate
+// issued by it.
+type TLSFixture struct {
+	CAFile            string
+	CAPool            *x509.CertPool
+	ServerCertFile    string
+	ServerKeyFile     string
+	ClientCertificate tls.Certificate
+	// ClientSubject is the subject of ClientCertificate, as the server sees it.
+	ClientSubject string
+}
+
+type issuedCert struct {
+	der  []byte
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+func issueCert(template *x509.Certificate, parent *issuedCert) (issued *issuedCert, err error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return
+	}
+	serialNumber, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	if err != nil {
+		return
+	}
+	template.SerialNumber = serialNumber
+	template.NotBefore = time.Now().Add(-time.Minute)
+	template.NotAfter = time.Now().Add(time.Hour)
+	parentCert := template
+	var parentKey crypto.Signer = key
+	if parent != nil {
+		parentCert = parent.cert
+		parentKey = parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parentCert, &key.PublicKey, parentKey)
+	if err != nil {
+		return
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return
+	}
+	issued = &issuedCert{der: der, cert: cert, key: key}
+	return
+}
+
+func writePEMFile(pattern string, blockType string, bytes []byte) (filePath string, err error) {
+	file, err := os.CreateTemp("", pattern)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+	err = pem.Encode(file, &pem.Block{Type: blockType, Bytes: bytes})
+	filePath = file.Name()
+	return
+}
+
+// GenerateTLSFixture issues a client certificate for clientCommonName along with the CA and server
+// certificates.
+func GenerateTLSFixture(clientCommonName string) (fixture TLSFixture, err error) {
+	ca, err := issueCert(&x509.Certificate{
+		Subject:               pkix.Name{CommonName: "Open Transit Test CA"},
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}, nil)
+	if err != nil {
+		err = fmt.Errorf("failed to issue CA certificate: %w", err)
+		return
+	}
+	server, err := issueCert(&x509.Certificate{
+		Subject:     pkix.Name{CommonName: "localhost"},
+		DNSNames:    []string{"localhost"},
+		IPAddresses: []net.IP{net.IPv4(127, 0, 0, 1), net.IPv6loopback},
+		KeyUsage:    x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}, ca)
+	if err != nil {
+		err = fmt.Errorf("failed to issue server certificate: %w", err)
+		return
+	}
+	client, err := issueCert(&x509.Certificate{
+		Subject:     pkix.Name{CommonName: clientCommonName, Organization: []string{"Open Transit Test Provider"}},
+		KeyUsage:    x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}, ca)
+	if err != nil {
+		err = fmt.Errorf("failed to issue client certificate: %w", err)
+		return
+	}
+
+	fixture.CAFile, err = writePEMFile("open-transit-ca*.pem", "CERTIFICATE", ca.der)
+	if err != nil {
+		return
+	}
+	fixture.ServerCertFile, err = writePEMFile("open-transit-server-cert*.pem", "CERTIFICATE", server.der)
+	if err != nil {
+		return
+	}
+	serverKey, err := x509.MarshalPKCS8PrivateKey(server.key)
+	if err != nil {
+		return
+	}
+	fixture.ServerKeyFile, err = writePEMFile("open-transit-server-key*.pem", "PRIVATE KEY", serverKey)
+	if err != nil {
+		return
+	}
+	fixture.CAPool = x509.NewCertPool()
+	fixture.CAPool.AddCert(ca.cert)
+	fixture.ClientCertificate = tls.Certificate{Certificate: [][]byte{client.der}, PrivateKey: client.key}
+	fixture.ClientSubject = client.cert.Subject.String()
+	return
+}
+
+// HTTPClient returns a client that trusts the fixture's CA and, if withClientCertificate is set,
+// presents the client certificate.
+func (fixture TLSFixture) HTTPClient(withClientCertificate bool) *http.Client {
+	config := &tls.Config{RootCAs: fixture.CAPool}
+	if withClientCertificate {
+		config.Certificates = []tls.Certificate{fixture.ClientCertificate}
+	}
+	return &http.Client{Transport: &http.Transport{TLSClientConfig: config}}
+}
@@ -0,0 +1,74 @@
+package acceptance
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/technopolitica/open-transit/test/acceptance/testutils"
+)
+
+var _ = Describe("TLS", Ordered, func() {
+	var fixture testutils.TLSFixture
+	var tlsServer testutils.APIServer
+	BeforeAll(func(ctx context.Context) {
+		var err error
+		fixture, err = testutils.GenerateTLSFixture("scoot")
+		Expect(err).NotTo(HaveOccurred())
+		tlsServer, err = apiServer.StartTLSServer(ctx, fixture)
+		Expect(err).NotTo(HaveOccurred(), "failed to start TLS server")
+		DeferCleanup(tlsServer.Terminate)
+	})
+
+	var providerID uuid.UUID
+	BeforeEach(func() {
+		providerID = testutils.GenerateRandomUUID()
+		apiClient.AuthenticateAsProvider(providerID)
+		apiClient.Unauthenticate()
+	})
+
+	When("provider authenticates w/ a bearer token over HTTPS", func() {
+		It("accepts the request", func() {
+			client := testutils.NewTestClientWithHTTPClient(*tlsServer.BaseURL, *tlsServer.PrivateKey, fixture.HTTPClient(false))
+			client.AuthenticateAsProviderWithoutRegistering(providerID)
+			Expect(client.ListVehicles(testutils.ListVehiclesOptions{Limit: 10})).To(HaveHTTPStatus(http.StatusOK))
+		})
+	})
+
+	Context("authenticated w/ a client certificate", func() {
+		var client *testutils.TestClient
+		BeforeEach(func() {
+			client = testutils.NewTestClientWithHTTPClient(*tlsServer.BaseURL, *tlsServer.PrivateKey, fixture.HTTPClient(true))
+		})
+
+		When("the certificate's subject is bound to a provider", func() {
+			BeforeEach(func() {
+				Expect(apiServer.BindClientCertificate(providerID, fixture.ClientSubject)).To(Succeed())
+			})
+
+			It("acts on behalf of the provider", func() {
+				vehicle := testutils.MakeValidVehicle(providerID)
+				Expect(client.RegisterVehicles([]any{vehicle})).To(HaveHTTPStatus(http.StatusCreated))
+				Expect(client.GetVehicle(vehicle.DeviceID.String())).To(HaveHTTPStatus(http.StatusOK))
+			})
+
+			It("is not allowed to act on behalf of another provider", func() {
+				vehicle := testutils.MakeValidVehicle(testutils.MakeUUIDExcluding(providerID))
+				Expect(client.RegisterVehicles([]any{vehicle})).To(HaveHTTPStatus(http.StatusBadRequest))
+			})
+
+			It("stops authenticating once the binding is revoked", func() {
+				Expect(apiServer.RevokeProviderCredential(fixture.ClientSubject)).To(Succeed())
+				Expect(client.ListVehicles(testutils.ListVehiclesOptions{Limit: 10})).To(HaveHTTPStatus(http.StatusUnauthorized))
+			})
+		})
+
+		When("the certificate's subject is not bound to a provider", func() {
+			It("returns HTTP 401 Unauthorized", func() {
+				Expect(client.ListVehicles(testutils.ListVehiclesOptions{Limit: 10})).To(HaveHTTPStatus(http.StatusUnauthorized))
+			})
+		})
+	})
+})
@@ -0,0 +1,131 @@
+package acceptance
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	"github.com/technopolitica/open-transit/internal/domain"
+	. "github.com/technopolitica/open-transit/test/acceptance/matchers"
+	"github.com/technopolitica/open-transit/test/acceptance/testutils"
+)
+
+func accessTokenFrom(res *http.Response) string {
+	defer res.Body.Close()
+	var body struct {
+		AccessToken string `json:"access_token"`
+	}
+	Expect(json.NewDecoder(res.Body).Decode(&body)).To(Succeed())
+	return body.AccessToken
+}
+
+var _ = Describe("/oauth/token", func() {
+	clientCredentialsGrant := url.Values{"grant_type": {"client_credentials"}}
+
+	var providerID uuid.UUID
+	var clientID, clientSecret string
+	BeforeEach(func() {
+		providerID = testutils.GenerateRandomUUID()
+		apiClient.AuthenticateAsProvider(providerID)
+		apiClient.Unauthenticate()
+		var err error
+		clientID, clientSecret, err = apiServer.CreateProviderCredential(providerID, "vehicles:read vehicles:write stops:read")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	When("provider requests a token w/ valid client credentials", func() {
+		It("issues a bearer token", func() {
+			Expect(apiClient.RequestToken(clientID, clientSecret, clientCredentialsGrant)).To(SatisfyAll(
+				HaveHTTPStatus(http.StatusOK),
+				HaveHTTPHeaderWithValue("Cache-Control", "no-store"),
+				HaveHTTPBody(MatchJSONObject(MatchAllKeys(Keys{
+					"access_token": Not(BeEmpty()),
+					"token_type":   Equal("Bearer"),
+					"expires_in":   BeNumerically(">", 0),
+					"scope":        Equal("stops:read vehicles:read vehicles:write"),
+				}))),
+			))
+		})
+
+		It("accepts the credentials in the request body", func() {
+			form := url.Values{
+				"grant_type":    {"client_credentials"},
+				"client_id":     {clientID},
+				"client_secret": {clientSecret},
+			}
+			Expect(apiClient.RequestToken("", "", form)).To(HaveHTTPStatus(http.StatusOK))
+		})
+
+		It("authenticates requests on behalf of the provider", func() {
+			apiClient.AuthenticateWithToken(accessTokenFrom(apiClient.RequestToken(clientID, clientSecret, clientCredentialsGrant)))
+			vehicle := testutils.MakeValidVehicle(providerID)
+			Expect(apiClient.RegisterVehicles([]any{vehicle})).To(HaveHTTPStatus(http.StatusCreated))
+			Expect(apiClient.GetVehicle(vehicle.DeviceID.String())).To(HaveHTTPStatus(http.StatusOK))
+		})
+	})
+
+	When("provider requests a narrower scope", func() {
+		It("limits the token to that scope", func() {
+			form := url.Values{"grant_type": {"client_credentials"}, "scope": {"stops:read"}}
+			apiClient.AuthenticateWithToken(accessTokenFrom(apiClient.RequestToken(clientID, clientSecret, form)))
+			Expect(apiClient.ListStops()).To(HaveHTTPStatus(http.StatusOK))
+			Expect(apiClient.RegisterVehicles([]any{testutils.MakeValidVehicle(providerID)})).To(HaveHTTPStatus(http.StatusForbidden))
+		})
+	})
+
+	When("provider requests a scope the client wasn't granted", func() {
+		It("returns HTTP 400 Bad Request w/ an invalid_scope error", func() {
+			form := url.Values{"grant_type": {"client_credentials"}, "scope": {"trips:write"}}
+			Expect(apiClient.RequestToken(clientID, clientSecret, form)).To(SatisfyAll(
+				HaveHTTPStatus(http.StatusBadRequest),
+				HaveHTTPBody(MatchJSONObject(MatchKeys(IgnoreExtras, Keys{"error": Equal("invalid_scope")}))),
+			))
+		})
+	})
+
+	When("provider requests a token w/ the wrong client secret", func() {
+		It("returns HTTP 401 Unauthorized w/ an invalid_client error", func() {
+			Expect(apiClient.RequestToken(clientID, "wrong", clientCredentialsGrant)).To(SatisfyAll(
+				HaveHTTPStatus(http.StatusUnauthorized),
+				HaveHTTPBody(MatchJSONObject(MatchKeys(IgnoreExtras, Keys{"error": Equal("invalid_client")}))),
+			))
+		})
+	})
+
+	When("provider requests a token w/ revoked client credentials", func() {
+		It("returns HTTP 401 Unauthorized w/ an invalid_client error", func() {
+			Expect(apiServer.RevokeProviderCredential(clientID)).To(Succeed())
+			Expect(apiClient.RequestToken(clientID, clientSecret, clientCredentialsGrant)).To(SatisfyAll(
+				HaveHTTPStatus(http.StatusUnauthorized),
+				HaveHTTPBody(MatchJSONObject(MatchKeys(IgnoreExtras, Keys{"error": Equal("invalid_client")}))),
+			))
+		})
+	})
+
+	When("provider requests a token after being suspended", func() {
+		It("returns HTTP 400 Bad Request w/ an unauthorized_client error", func() {
+			apiClient.AuthenticateAsAdmin()
+			suspended := testutils.MakeValidProvider(providerID)
+			suspended.Status = domain.ProviderStatusSuspended
+			Expect(apiClient.UpdateProvider(providerID.String(), suspended)).To(HaveHTTPStatus(http.StatusOK))
+			Expect(apiClient.RequestToken(clientID, clientSecret, clientCredentialsGrant)).To(SatisfyAll(
+				HaveHTTPStatus(http.StatusBadRequest),
+				HaveHTTPBody(MatchJSONObject(MatchKeys(IgnoreExtras, Keys{"error": Equal("unauthorized_client")}))),
+			))
+		})
+	})
+
+	When("provider requests an unsupported grant", func() {
+		It("returns HTTP 400 Bad Request w/ an unsupported_grant_type error", func() {
+			form := url.Values{"grant_type": {"password"}}
+			Expect(apiClient.RequestToken(clientID, clientSecret, form)).To(SatisfyAll(
+				HaveHTTPStatus(http.StatusBadRequest),
+				HaveHTTPBody(MatchJSONObject(MatchKeys(IgnoreExtras, Keys{"error": Equal("unsupported_grant_type")}))),
+			))
+		})
+	})
+})
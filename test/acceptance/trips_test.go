@@ -0,0 +1,111 @@
+package acceptance
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	"github.com/technopolitica/open-transit/internal/domain"
+	. "github.com/technopolitica/open-transit/test/acceptance/matchers"
+	"github.com/technopolitica/open-transit/test/acceptance/testutils"
+)
+
+var _ = Describe("/trips", func() {
+	Context("unauthenticated", func() {
+		When("user attempts to submit a valid trip", func() {
+			var validTrip *domain.Trip
+			BeforeEach(func() {
+				vehicle := testutils.MakeValidVehicle(testutils.GenerateRandomUUID())
+				validTrip = testutils.MakeValidTrip(vehicle)
+			})
+
+			AssertHasStandardUnauthorizedResponse(func() *http.Response {
+				return apiClient.SubmitTrips([]any{validTrip})
+			})
+		})
+	})
+
+	Context("authenticated as provider", func() {
+		var providerID uuid.UUID
+		var vehicle *domain.Vehicle
+		BeforeEach(func() {
+			providerID = testutils.GenerateRandomUUID()
+			apiClient.AuthenticateAsProvider(providerID)
+			vehicle = testutils.MakeValidVehicle(providerID)
+			Expect(apiClient.RegisterVehicles([]any{vehicle})).To(HaveHTTPStatus(http.StatusCreated))
+		})
+
+		When("provider submits a valid trip for a vehicle they own", func() {
+			It("returns HTTP 201 Created status w/ a bulk success response", func() {
+				Expect(apiClient.SubmitTrips([]any{testutils.MakeValidTrip(vehicle)})).To(SatisfyAll(
+					HaveHTTPStatus(http.StatusCreated),
+					HaveHTTPBody(MatchJSONObject(MatchKeys(IgnoreExtras, Keys{
+						"success":  Equal(float64(1)),
+						"total":    Equal(float64(1)),
+						"failures": BeEmpty(),
+					}))),
+				))
+			})
+		})
+
+		When("provider submits the same trip twice", func() {
+			var trip *domain.Trip
+			BeforeEach(func() {
+				trip = testutils.MakeValidTrip(vehicle)
+				Expect(apiClient.SubmitTrips([]any{trip})).To(HaveHTTPStatus(http.StatusCreated))
+			})
+
+			It("returns a bulk error response w/ already_registered failure", func() {
+				Expect(apiClient.SubmitTrips([]any{trip})).To(SatisfyAll(
+					HaveHTTPStatus(http.StatusBadRequest),
+					HaveHTTPBody(MatchJSONObject(MatchKeys(IgnoreExtras, Keys{
+						"success": Equal(float64(0)),
+						"total":   Equal(float64(1)),
+						"failures": ConsistOf(MatchKeys(IgnoreExtras, Keys{
+							"error":         Equal("already_registered"),
+							"error_details": ConsistOf("A trip with trip_id is already registered"),
+							"item":          MatchJSONObject(trip),
+						})),
+					}))),
+				))
+			})
+		})
+
+		When("provider submits a trip for a vehicle owned by another provider", func() {
+			var trip *domain.Trip
+			BeforeEach(func() {
+				otherProvidersID := testutils.MakeUUIDExcluding(providerID)
+				otherProvidersVehicle := testutils.MakeValidVehicle(otherProvidersID)
+				apiClient.AuthenticateAsProvider(otherProvidersID)
+				Expect(apiClient.RegisterVehicles([]any{otherProvidersVehicle})).To(HaveHTTPStatus(http.StatusCreated))
+				apiClient.AuthenticateAsProvider(providerID)
+
+				trip = testutils.MakeValidTrip(otherProvidersVehicle)
+			})
+
+			It("returns a bulk error response w/ bad_param failure", func() {
+				Expect(apiClient.SubmitTrips([]any{trip})).To(HaveHTTPBody(MatchJSONObject(MatchKeys(IgnoreExtras, Keys{
+					"success": Equal(float64(0)),
+					"failures": ConsistOf(MatchKeys(IgnoreExtras, Keys{
+						"error":         Equal("bad_param"),
+						"error_details": ConsistOf("provider_id: not allowed to submit trips for another provider"),
+					})),
+				}))))
+			})
+		})
+
+		When("provider submits a trip for an unregistered vehicle", func() {
+			It("returns a bulk error response w/ unregistered failure", func() {
+				trip := testutils.MakeValidTrip(testutils.MakeValidVehicle(providerID))
+				Expect(apiClient.SubmitTrips([]any{trip})).To(HaveHTTPBody(MatchJSONObject(MatchKeys(IgnoreExtras, Keys{
+					"success": Equal(float64(0)),
+					"failures": ConsistOf(MatchKeys(IgnoreExtras, Keys{
+						"error": Equal("unregistered"),
+					})),
+				}))))
+			})
+		})
+	})
+})
@@ -0,0 +1,129 @@
+package acceptance
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	"github.com/technopolitica/open-transit/internal/domain"
+	. "github.com/technopolitica/open-transit/test/acceptance/matchers"
+	"github.com/technopolitica/open-transit/test/acceptance/testutils"
+)
+
+var _ = Describe("/vehicles/status", func() {
+	Context("unauthenticated", func() {
+		When("user attempts to list vehicle statuses", func() {
+			AssertHasStandardUnauthorizedResponse(func() *http.Response {
+				return apiClient.ListVehicleStatuses(testutils.ListVehiclesOptions{Limit: 2})
+			})
+		})
+	})
+
+	Context("authenticated as provider", func() {
+		var providerID uuid.UUID
+		BeforeEach(OncePerOrdered, func() {
+			providerID = testutils.GenerateRandomUUID()
+			apiClient.AuthenticateAsProvider(providerID)
+		})
+
+		When("provider fetches the status of a vehicle w/o any events or telemetry", func() {
+			var vehicle *domain.Vehicle
+			BeforeEach(func() {
+				vehicle = testutils.MakeValidVehicle(providerID)
+				Expect(apiClient.RegisterVehicles([]any{vehicle})).To(HaveHTTPStatus(http.StatusCreated))
+			})
+
+			It("returns a status w/ null last_event and last_telemetry", func() {
+				Expect(apiClient.GetVehicleStatus(vehicle.DeviceID.String())).To(SatisfyAll(
+					HaveHTTPStatus(http.StatusOK),
+					HaveHTTPBody(MatchJSONObject(MatchKeys(IgnoreExtras, Keys{
+						"device_id":      Equal(vehicle.DeviceID.String()),
+						"last_event":     BeNil(),
+						"last_telemetry": BeNil(),
+					}))),
+				))
+			})
+		})
+
+		When("provider fetches the status of a vehicle w/ events and telemetry", Ordered, func() {
+			var vehicle *domain.Vehicle
+			var lastEvent *domain.Event
+			var lastTelemetry *domain.Telemetry
+			BeforeAll(func() {
+				vehicle = testutils.MakeValidVehicle(providerID)
+				Expect(apiClient.RegisterVehicles([]any{vehicle})).To(HaveHTTPStatus(http.StatusCreated))
+
+				dropOff := testutils.MakeValidEvent(vehicle, domain.VehicleStateAvailable, domain.EventTypeProviderDropOff)
+				lastEvent = testutils.MakeValidEvent(vehicle, domain.VehicleStateReserved, domain.EventTypeReservationStart)
+				lastEvent.Timestamp = domain.NewTimestamp(dropOff.Timestamp.Add(time.Minute))
+				Expect(apiClient.SubmitEvents([]any{dropOff, lastEvent})).To(HaveHTTPStatus(http.StatusCreated))
+
+				firstTelemetry := testutils.MakeValidTelemetry(vehicle)
+				lastTelemetry = testutils.MakeValidTelemetry(vehicle)
+				lastTelemetry.Timestamp = domain.NewTimestamp(firstTelemetry.Timestamp.Add(time.Minute))
+				Expect(apiClient.SubmitTelemetry([]any{lastTelemetry, firstTelemetry})).To(HaveHTTPStatus(http.StatusCreated))
+			})
+
+			It("returns the most recent event and telemetry", func() {
+				Expect(apiClient.GetVehicleStatus(vehicle.DeviceID.String())).To(HaveHTTPBody(MatchJSONObject(domain.VehicleStatus{
+					DeviceID:      vehicle.DeviceID,
+					ProviderID:    providerID,
+					LastEvent:     lastEvent,
+					LastTelemetry: lastTelemetry,
+				})))
+			})
+
+			It("includes the status when listing vehicle statuses", func() {
+				Expect(apiClient.ListVehicleStatuses(testutils.ListVehiclesOptions{})).To(HaveHTTPBody(MatchJSONObject(MatchKeys(IgnoreExtras, Keys{
+					"version":         Equal("2.0.0"),
+					"vehicles_status": ContainElement(MatchKeys(IgnoreExtras, Keys{"device_id": Equal(vehicle.DeviceID.String())})),
+				}))))
+			})
+		})
+
+		When("provider fetches the status of a vehicle they don't own", func() {
+			It("returns HTTP 404 Not Found status", func() {
+				otherProvidersID := testutils.MakeUUIDExcluding(providerID)
+				otherProvidersVehicle := testutils.MakeValidVehicle(otherProvidersID)
+				apiClient.AuthenticateAsProvider(otherProvidersID)
+				Expect(apiClient.RegisterVehicles([]any{otherProvidersVehicle})).To(HaveHTTPStatus(http.StatusCreated))
+				apiClient.AuthenticateAsProvider(providerID)
+
+				Expect(apiClient.GetVehicleStatus(otherProvidersVehicle.DeviceID.String())).To(HaveHTTPStatus(http.StatusNotFound))
+			})
+		})
+
+		When("provider pages through vehicle statuses", Ordered, func() {
+			var registeredVehicles []*domain.Vehicle
+			BeforeAll(func() {
+				for i := 0; i < 5; i++ {
+					registeredVehicles = append(registeredVehicles, testutils.MakeValidVehicle(providerID))
+				}
+				Expect(apiClient.RegisterVehicles(registeredVehicles)).To(HaveHTTPStatus(http.StatusCreated))
+			})
+
+			It("allows user to page through all statuses by following next links", func() {
+				var foundIDs []uuid.UUID
+				page := readJSONBody[domain.PaginatedVehicleStatusesResponse](apiClient.ListVehicleStatuses(testutils.ListVehiclesOptions{Limit: 2}))
+				for {
+					for _, status := range page.VehiclesStatus {
+						foundIDs = append(foundIDs, status.DeviceID)
+					}
+					if page.Links.Next == "" {
+						break
+					}
+					page = readJSONBody[domain.PaginatedVehicleStatusesResponse](apiClient.Get(page.Links.Next))
+				}
+
+				expectedIDs := make([]uuid.UUID, 0, len(registeredVehicles))
+				for _, vehicle := range registeredVehicles {
+					expectedIDs = append(expectedIDs, vehicle.DeviceID)
+				}
+				Expect(foundIDs).To(ConsistOf(expectedIDs))
+			})
+		})
+	})
+})
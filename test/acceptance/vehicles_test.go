@@ -2,6 +2,7 @@ package acceptance
 
 import (
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
 
@@ -169,6 +170,22 @@ var _ = Describe("/vehicles", func() {
 			})
 		})
 
+		When("provider submits a payload larger than the request body limit", func() {
+			It("returns HTTP 413 Request Entity Too Large", func() {
+				vehicles := make([]any, 0, 10000)
+				for i := 0; i < cap(vehicles); i++ {
+					vehicles = append(vehicles, testutils.MakeValidVehicle(providerID))
+				}
+				Expect(apiClient.RegisterVehicles(vehicles)).To(SatisfyAll(
+					HaveHTTPStatus(http.StatusRequestEntityTooLarge),
+					HaveHTTPBody(MatchJSONObject(MatchKeys(IgnoreExtras, Keys{
+						"error":         Equal("bad_param"),
+						"error_details": ConsistOf(fmt.Sprintf("request body must not exceed %d bytes", testutils.MaxBodyBytes)),
+					}))),
+				))
+			})
+		})
+
 		When("provider registers a valid vehicle that they own", Ordered, func() {
 			var validVehicle *domain.Vehicle
 			BeforeAll(func() {
@@ -324,6 +341,12 @@ var _ = Describe("/vehicles", func() {
 			})
 		})
 
+		When("provider attempts to fetch a vehicle w/ a device ID that isn't a UUID", func() {
+			It("returns HTTP 400 Bad Request status", func() {
+				Expect(apiClient.GetVehicle("not-a-uuid")).To(HaveHTTPStatus(http.StatusBadRequest))
+			})
+		})
+
 		When("provider attempts to register a single vehicle that they don't own", func() {
 			var notProvidersVehicle *domain.Vehicle
 			BeforeEach(func() {
@@ -460,4 +483,69 @@ var _ = Describe("/vehicles", func() {
 			})
 		})
 	})
+
+	Context("authenticated as agency", func() {
+		var firstProvidersVehicle, secondProvidersVehicle *domain.Vehicle
+		BeforeEach(func() {
+			firstProviderID := testutils.GenerateRandomUUID()
+			apiClient.AuthenticateAsProvider(firstProviderID)
+			firstProvidersVehicle = testutils.MakeValidVehicle(firstProviderID)
+			Expect(apiClient.RegisterVehicles([]any{firstProvidersVehicle})).To(HaveHTTPStatus(http.StatusCreated))
+
+			secondProviderID := testutils.MakeUUIDExcluding(firstProviderID)
+			apiClient.AuthenticateAsProvider(secondProviderID)
+			secondProvidersVehicle = testutils.MakeValidVehicle(secondProviderID)
+			Expect(apiClient.RegisterVehicles([]any{secondProvidersVehicle})).To(HaveHTTPStatus(http.StatusCreated))
+
+			apiClient.AuthenticateAsAgency()
+		})
+
+		It("lists every provider's vehicles", func() {
+			Expect(apiClient.ListVehicles(testutils.ListVehiclesOptions{})).To(SatisfyAll(
+				HaveHTTPStatus(http.StatusOK),
+				HaveHTTPBody(MatchJSONObject(HaveKeyWithValue("vehicles", ConsistOf(JSONValue(firstProvidersVehicle), JSONValue(secondProvidersVehicle))))),
+			))
+		})
+
+		It("lists a single provider's vehicles when filtered by provider_id", func() {
+			Expect(apiClient.ListVehiclesForProvider(firstProvidersVehicle.ProviderID.String(), testutils.ListVehiclesOptions{})).To(SatisfyAll(
+				HaveHTTPStatus(http.StatusOK),
+				HaveHTTPBody(MatchJSONObject(HaveKeyWithValue("vehicles", ConsistOf(JSONValue(firstProvidersVehicle))))),
+			))
+		})
+
+		It("fetches any provider's vehicle", func() {
+			Expect(apiClient.GetVehicle(secondProvidersVehicle.DeviceID.String())).To(SatisfyAll(
+				HaveHTTPStatus(http.StatusOK),
+				HaveHTTPBody(MatchJSONObject(secondProvidersVehicle)),
+			))
+		})
+
+		It("is not allowed to register vehicles", func() {
+			Expect(apiClient.RegisterVehicles([]any{testutils.MakeValidVehicle(testutils.GenerateRandomUUID())})).To(SatisfyAll(
+				HaveHTTPStatus(http.StatusForbidden),
+				HaveHTTPBody(MatchJSONObject(MatchKeys(IgnoreExtras, Keys{
+					"error":         Equal("forbidden"),
+					"error_details": ConsistOf("missing required scope vehicles:write"),
+				}))),
+			))
+		})
+
+		It("is limited to the scopes in its token", func() {
+			apiClient.AuthenticateWithRoleAndScope("agency", "trips:read")
+			Expect(apiClient.ListVehicles(testutils.ListVehiclesOptions{})).To(HaveHTTPStatus(http.StatusForbidden))
+		})
+	})
+
+	Context("authenticated w/o a role or provider_id", func() {
+		It("returns HTTP 403 Forbidden instead of an empty list", func() {
+			apiClient.AuthenticateWithoutRole()
+			Expect(apiClient.ListVehicles(testutils.ListVehiclesOptions{})).To(SatisfyAll(
+				HaveHTTPStatus(http.StatusForbidden),
+				HaveHTTPBody(MatchJSONObject(MatchKeys(IgnoreExtras, Keys{
+					"error_details": ConsistOf("missing required scope vehicles:read"),
+				}))),
+			))
+		})
+	})
 })